@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szdatasources"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szloader"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const recordDefinition = `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAME_FULL": "Robert Smith", "PHONE_NUMBER": "702-919-1300"}`
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestCmd_badOutput(test *testing.T) {
+	server := getTestServer(test)
+	_, err := execute(test, server, "", "product", "version", "--output", "yaml")
+	require.ErrorContains(test, err, "--output")
+}
+
+func TestCmd_environment(test *testing.T) {
+	server := getTestServer(test)
+	test.Setenv(environmentVariable("output"), OutputTable)
+	actual, err := execute(test, server, "", "product", "version")
+	require.NoError(test, err)
+	assert.Regexp(test, `(?m)^FIELD +VALUE$`, actual)
+	actual, err = execute(test, server, "", "product", "version", "--output", OutputJSON)
+	require.NoError(test, err)
+	assert.True(test, strings.HasPrefix(actual, `{"PRODUCT_NAME"`), actual)
+}
+
+func TestCmd_tokenWithoutTLS(test *testing.T) {
+	server := getTestServer(test)
+	_, err := execute(test, server, "", "product", "version", "--token", "secret")
+	require.Error(test, err)
+	_, err = execute(test, server, "", "product", "version", "--token", "secret", "--insecure-token")
+	require.NoError(test, err)
+}
+
+func TestConfig_addDataSource(test *testing.T) {
+	server := getTestServer(test)
+	before, err := execute(test, server, "", "config", "default")
+	require.NoError(test, err)
+	after, err := execute(test, server, "", "config", "add-data-source", "CUSTOMERS", "WATCHLIST")
+	require.NoError(test, err)
+	assert.NotEqual(test, before, after)
+	actual, err := execute(test, server, "", "config", "default")
+	require.NoError(test, err)
+	assert.Equal(test, after, actual)
+	actual, err = execute(test, server, "", "config", "data-sources", "--output", OutputTable)
+	require.NoError(test, err)
+	assert.Contains(test, actual, "CUSTOMERS")
+	assert.Contains(test, actual, "WATCHLIST")
+	actual, err = execute(test, server, "", "config", "list", "--output", OutputTable)
+	require.NoError(test, err)
+	assert.Len(test, strings.Split(strings.TrimSpace(actual), "\n"), 3)
+}
+
+func TestConfig_apply(test *testing.T) {
+	server := getTestServer(test)
+	fileName := filepath.Join(test.TempDir(), "data-sources.yaml")
+	require.NoError(test, os.WriteFile(fileName, []byte("dataSources: [CUSTOMERS, WATCHLIST]\ndelete: [TEST, SEARCH]\nprune: true\n"), 0o600))
+	before, err := execute(test, server, "", "config", "default")
+	require.NoError(test, err)
+	actual, err := execute(test, server, "", "config", "plan", fileName)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"CONFIG_ID":`+strings.TrimSpace(before)+`,"ADD":["CUSTOMERS","WATCHLIST"],"DELETE":["TEST","SEARCH"],"UNCHANGED":[]}`, actual)
+	after, err := execute(test, server, "", "config", "apply", fileName)
+	require.NoError(test, err)
+	assert.NotEqual(test, before, after)
+	actual, err = execute(test, server, "", "config", "data-sources", "--output", OutputTable)
+	require.NoError(test, err)
+	assert.Equal(test, "DSRC_CODE  DSRC_ID\nCUSTOMERS  1001\nWATCHLIST  1002\n", actual)
+	actual, err = execute(test, server, "", "config", "apply", fileName)
+	require.NoError(test, err)
+	assert.Equal(test, after, actual)
+	require.NoError(test, os.WriteFile(fileName, []byte("dataSources: [CUSTOMERS, customers]\n"), 0o600))
+	_, err = execute(test, server, "", "config", "apply", fileName)
+	require.ErrorIs(test, err, szdatasources.ErrInvalidDesired)
+}
+
+func TestConfig_history(test *testing.T) {
+	server := getTestServer(test)
+	customers, err := execute(test, server, "", "config", "add-data-source", "CUSTOMERS", "--author", "Jane", "--revision", "3f2a9c1")
+	require.NoError(test, err)
+	customers = strings.TrimSpace(customers)
+	watchlist, err := execute(test, server, "", "config", "add-data-source", "WATCHLIST", "--author", "john", "--comment", "Screening")
+	require.NoError(test, err)
+	watchlist = strings.TrimSpace(watchlist)
+	testCases := []struct {
+		args     []string
+		expected []string
+	}{
+		{args: []string{"--author", "jane"}, expected: []string{customers}},
+		{args: []string{"--tool", ProgramName}, expected: []string{customers, watchlist}},
+		{args: []string{"--revision", "3f2a"}, expected: []string{customers}},
+		{args: []string{"--summary", "data sources customers"}, expected: []string{customers}},
+		{args: []string{"--summary", "screening"}, expected: []string{watchlist}},
+		{args: []string{"--parent", customers}, expected: []string{watchlist}},
+		{args: []string{"--until", "2000-01-01"}, expected: []string{}},
+	}
+	for _, testCase := range testCases {
+		actual, err := execute(test, server, "", append([]string{"config", "history"}, testCase.args...)...)
+		require.NoError(test, err)
+		history := struct {
+			Configs []struct {
+				ConfigID int64 `json:"CONFIG_ID"`
+			} `json:"CONFIGS"`
+		}{}
+		require.NoError(test, json.Unmarshal([]byte(actual), &history))
+		configIDs := []string{}
+		for _, entry := range history.Configs {
+			configIDs = append(configIDs, strconv.FormatInt(entry.ConfigID, 10))
+		}
+		assert.Equal(test, testCase.expected, configIDs, testCase.args)
+	}
+	_, err = execute(test, server, "", "config", "history", "--since", "yesterday")
+	require.ErrorContains(test, err, "--since")
+}
+func TestConfig_rollback(test *testing.T) {
+	server := getTestServer(test)
+	before, err := execute(test, server, "", "config", "default")
+	require.NoError(test, err)
+	before = strings.TrimSpace(before)
+	after, err := execute(test, server, "", "config", "add-data-source", "CUSTOMERS")
+	require.NoError(test, err)
+	after = strings.TrimSpace(after)
+	actual, err := execute(test, server, "", "config", "diff", before)
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"DATA_SOURCES":{"ADDED":["CUSTOMERS"],"DELETED":[],"CHANGED":[]}`)
+	_, err = execute(test, server, "", "config", "rollback", before)
+	require.ErrorContains(test, err, "reason")
+	rollback, err := execute(test, server, "", "config", "rollback", before, "--reason", "not ready", "--author", "tester")
+	require.NoError(test, err)
+	rollback = strings.TrimSpace(rollback)
+	assert.NotEqual(test, before, rollback)
+	actual, err = execute(test, server, "", "config", "diff", before, rollback)
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"DATA_SOURCES":{"ADDED":[],"DELETED":[],"CHANGED":[]}`)
+	actual, err = execute(test, server, "", "config", "history", "--output", OutputTable)
+	require.NoError(test, err)
+	lines := strings.Split(strings.TrimSpace(actual), "\n")
+	require.Len(test, lines, 4)
+	assert.Regexp(test, `^CONFIG_COMMENTS +CONFIG_ID +IS_DEFAULT +SYS_CREATE_DT$`, lines[0])
+	assert.Contains(test, actual, `"author":"tester"`)
+	assert.Contains(test, actual, `"summary":"Rollback to configuration `+before+`: not ready","parentConfigId":`+after)
+	assert.Regexp(test, rollback+` +true +`, actual)
+}
+
+func TestDiagnostic_purge(test *testing.T) {
+	server := getTestServer(test)
+	_, err := execute(test, server, "", "diagnostic", "purge")
+	require.ErrorContains(test, err, "--yes")
+	actual, err := execute(test, server, "", "diagnostic", "purge", "--yes")
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestEngine_addRecord(test *testing.T) {
+	server := getTestServer(test)
+	addCustomers(test, server)
+	actual, err := execute(test, server, recordDefinition, "engine", "add-record", "CUSTOMERS", "1001", "-", "--with-info")
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	actual, err = execute(test, server, "", "engine", "get-entity", "1", "--flags", "0", "--output", OutputPretty)
+	require.NoError(test, err)
+	assert.Equal(test, "{\n  \"RESOLVED_ENTITY\": {\n    \"ENTITY_ID\": 1\n  }\n}\n", actual)
+	_, err = execute(test, server, "", "engine", "get-entity-by-record", "CUSTOMERS", "9999")
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	_, err = execute(test, server, "", "engine", "get-entity", "one")
+	require.ErrorContains(test, err, "is not an ID")
+}
+
+func TestEngine_export(test *testing.T) {
+	server := getTestServer(test)
+	addCustomers(test, server)
+	_, err := execute(test, server, recordDefinition, "engine", "add-record", "CUSTOMERS", "1001", "-")
+	require.NoError(test, err)
+	actual, err := execute(test, server, "", "engine", "export", "--format", "csv", "--columns", "RESOLVED_ENTITY_ID,RECORD_ID")
+	require.NoError(test, err)
+	assert.Equal(test, "RESOLVED_ENTITY_ID,RECORD_ID\n1,\"1001\"\n", actual)
+	actual, err = execute(test, server, "", "engine", "export", "--flags", strconv.FormatInt(senzing.SzExportIncludeAllEntities, 10), "--output", OutputTable)
+	require.NoError(test, err)
+	assert.Equal(test, "ENTITY_ID\n1\n", actual)
+	_, err = execute(test, server, "", "engine", "export", "--format", "xml")
+	require.ErrorContains(test, err, "--format")
+}
+
+func TestEngine_processRedo(test *testing.T) {
+	server := getTestServer(test)
+	actual, err := execute(test, server, "", "engine", "process-redo")
+	require.NoError(test, err)
+	assert.Equal(test, "[]\n", actual)
+	actual, err = execute(test, server, "", "engine", "count-redo")
+	require.NoError(test, err)
+	assert.Equal(test, "0\n", actual)
+}
+
+func TestLoad(test *testing.T) {
+	server := getTestServer(test)
+	addCustomers(test, server)
+	directory := test.TempDir()
+	csvFile := filepath.Join(directory, "customers.csv")
+	require.NoError(test, os.WriteFile(csvFile, []byte("RECORD_ID,NAME_FULL\n1002,Bob Smith\n,No Record ID\n"), 0o600))
+	rejectsFile := filepath.Join(directory, "rejects.jsonl")
+	actual, err := execute(test, server, recordDefinition, "load", "-", csvFile, "--data-source", "CUSTOMERS", "--rejects", rejectsFile, "--workers", "2")
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"LOADED":2`)
+	assert.Contains(test, actual, `"REJECTED":1`)
+	rejects, err := os.ReadFile(rejectsFile)
+	require.NoError(test, err)
+	assert.Contains(test, string(rejects), `"line":3`)
+	_, err = execute(test, server, "", "engine", "get-record", "CUSTOMERS", "1002")
+	require.NoError(test, err)
+}
+
+func TestLoad_checkpoint(test *testing.T) {
+	server := getTestServer(test)
+	addCustomers(test, server)
+	directory := test.TempDir()
+	inputFile := filepath.Join(directory, "customers.jsonl")
+	require.NoError(test, os.WriteFile(inputFile, []byte(recordDefinition+"\n"), 0o600))
+	checkpointDir := filepath.Join(directory, "checkpoints")
+	require.NoError(test, os.Mkdir(checkpointDir, 0o700))
+	actual, err := execute(test, server, "", "load", inputFile, "--checkpoint-dir", checkpointDir)
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"LOADED":1`)
+	checkpoints, err := filepath.Glob(filepath.Join(checkpointDir, "customers.jsonl.*.checkpoint.json"))
+	require.NoError(test, err)
+	assert.Len(test, checkpoints, 1)
+	actual, err = execute(test, server, "", "load", inputFile, "--checkpoint-dir", checkpointDir)
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"LOADED":0`)
+	require.NoError(test, os.WriteFile(inputFile, []byte(recordDefinition+"\n\n"), 0o600))
+	_, err = execute(test, server, "", "load", inputFile, "--checkpoint-dir", checkpointDir)
+	require.ErrorIs(test, err, szloader.ErrInputChanged)
+	_, err = execute(test, server, recordDefinition, "load", "-", "--checkpoint-dir", checkpointDir)
+	require.Error(test, err)
+}
+
+func TestLoad_checkpointSameName(test *testing.T) {
+	server := getTestServer(test)
+	addCustomers(test, server)
+	directory := test.TempDir()
+	for _, subdirectory := range []string{"a", "b"} {
+		require.NoError(test, os.Mkdir(filepath.Join(directory, subdirectory), 0o700))
+		inputFile := filepath.Join(directory, subdirectory, "data.jsonl")
+		require.NoError(test, os.WriteFile(inputFile, []byte(recordDefinition+"\n"), 0o600))
+		actual, err := execute(test, server, "", "load", inputFile, "--checkpoint-dir", directory)
+		require.NoError(test, err)
+		assert.Contains(test, actual, `"LOADED":1`)
+	}
+	checkpoints, err := filepath.Glob(filepath.Join(directory, "data.jsonl.*.checkpoint.json"))
+	require.NoError(test, err)
+	assert.Len(test, checkpoints, 2)
+}
+
+func TestLoad_checkpointRejects(test *testing.T) {
+	server := getTestServer(test)
+	addCustomers(test, server)
+	directory := test.TempDir()
+	inputFile := filepath.Join(directory, "customers.jsonl")
+	require.NoError(test, os.WriteFile(inputFile, []byte(recordDefinition+"\n{\n"), 0o600))
+	rejectsFile := filepath.Join(directory, "rejects.jsonl")
+	for _, expected := range []string{`"REJECTED":1`, `"REJECTED":0`} {
+		actual, err := execute(test, server, "", "load", inputFile, "--checkpoint-dir", directory, "--rejects", rejectsFile)
+		require.NoError(test, err)
+		assert.Contains(test, actual, expected)
+	}
+	rejects, err := os.ReadFile(rejectsFile)
+	require.NoError(test, err)
+	assert.Equal(test, 1, strings.Count(string(rejects), `"line":2`))
+}
+
+func TestLoad_badFormat(test *testing.T) {
+	server := getTestServer(test)
+	_, err := execute(test, server, "", "load", "-", "--format", "xml")
+	require.ErrorContains(test, err, "--format")
+}
+
+func TestRedo(test *testing.T) {
+	server := getTestServer(test)
+	addCustomers(test, server)
+	for _, recordID := range []string{"1001", "1002"} {
+		_, err := execute(test, server, strings.ReplaceAll(recordDefinition, "1001", recordID), "engine", "add-record", "CUSTOMERS", recordID, "-")
+		require.NoError(test, err)
+	}
+	_, err := execute(test, server, "", "engine", "delete-record", "CUSTOMERS", "1001")
+	require.NoError(test, err)
+	actual, err := execute(test, server, "", "redo", "--once", "--with-info", "-")
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"RECORD_ID":"1001"`)
+	assert.Contains(test, actual, `{"FAILED":0,"PROCESSED":1,"RETRIED":0}`)
+	actual, err = execute(test, server, "", "engine", "count-redo")
+	require.NoError(test, err)
+	assert.Equal(test, "0\n", actual)
+}
+
+func TestPrintResult(test *testing.T) {
+	testCases := []struct {
+		name     string
+		output   string
+		result   string
+		expected string
+	}{
+		{name: "csv", output: OutputTable, result: "A,B\n1,2\n", expected: "A,B\n1,2\n"},
+		{name: "json", output: OutputJSON, result: `{"B":1,"A":[2]}`, expected: `{"B":1,"A":[2]}` + "\n"},
+		{name: "list", output: OutputTable, result: `{"CONFIGS":[{"CONFIG_ID":1,"CONFIG_COMMENTS":"first"},{"CONFIG_ID":22}]}`, expected: "CONFIG_COMMENTS  CONFIG_ID\nfirst            1\n                 22\n"},
+		{name: "number", output: OutputTable, result: "12345678901234567890", expected: "12345678901234567890\n"},
+		{name: "object", output: OutputTable, result: `{"B":{"C":true},"A":"x"}`, expected: "FIELD  VALUE\nA      x\nB      {\"C\":true}\n"},
+		{name: "pretty", output: OutputPretty, result: `{"A":[1]}`, expected: "{\n  \"A\": [\n    1\n  ]\n}\n"},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			actual := &bytes.Buffer{}
+			require.NoError(test, printResult(actual, testCase.output, testCase.result))
+			assert.Equal(test, testCase.expected, actual.String())
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func addCustomers(test *testing.T, server *fakeserver.Server) {
+	test.Helper()
+	_, err := execute(test, server, "", "config", "add-data-source", "CUSTOMERS")
+	require.NoError(test, err)
+}
+
+// Run the command against a fake server.  Returns what it printed to standard output.
+func execute(test *testing.T, server *fakeserver.Server, stdin string, args ...string) (string, error) {
+	test.Helper()
+	app := newApplication(
+		szabstractfactory.WithGrpcAddress(fakeserver.Address),
+		szabstractfactory.WithDialOptions(server.DialOptions()...),
+	)
+	command := app.newRootCommand("test")
+	stdout := &bytes.Buffer{}
+	command.SetArgs(args)
+	command.SetErr(io.Discard)
+	command.SetIn(strings.NewReader(stdin))
+	command.SetOut(stdout)
+	err := command.Execute()
+	return stdout.String(), err
+}
+
+func getTestServer(test *testing.T) *fakeserver.Server {
+	test.Helper()
+	server, err := fakeserver.New()
+	require.NoError(test, err)
+	test.Cleanup(server.Close)
+	return server
+}
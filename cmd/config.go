@@ -0,0 +1,460 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigcomment"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfighistory"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigupdater"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szdatasources"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/spf13/cobra"
+)
+
+// ----------------------------------------------------------------------------
+// application methods
+// ----------------------------------------------------------------------------
+
+func (app *application) newConfigCommand() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "config",
+		Short: "Manage Senzing configurations and their data sources",
+	}
+	result.AddCommand(
+		app.newDataSourceCommand("add-data-source", "Add data sources to the default configuration", "Added data sources",
+			func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr, dataSourceCode string) error {
+				_, err := szConfig.AddDataSource(ctx, configHandle, dataSourceCode)
+				return err
+			}),
+		app.newApplyCommand(),
+		app.newDataSourcesCommand(),
+		&cobra.Command{
+			Use:   "default",
+			Short: "Show the ID of the default configuration",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_ = args
+				return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+					configID, err := szConfigManager.GetDefaultConfigID(ctx)
+					return strconv.FormatInt(configID, 10), err
+				})
+			},
+		},
+		app.newDataSourceCommand("delete-data-source", "Delete data sources from the default configuration", "Deleted data sources",
+			func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr, dataSourceCode string) error {
+				return szConfig.DeleteDataSource(ctx, configHandle, dataSourceCode)
+			}),
+		&cobra.Command{
+			Use:   "diff FROM_CONFIG_ID [TO_CONFIG_ID]",
+			Short: "Compare the data sources, feature types and attributes of two configurations, by default with the default configuration",
+			Args:  cobra.RangeArgs(1, 2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				fromConfigID, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+				return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+					toConfigID, err := configIDArgument(ctx, szConfigManager, args[1:])
+					if err != nil {
+						return "", err
+					}
+					diff, err := szconfighistory.Diff(ctx, szConfigManager, fromConfigID, toConfigID)
+					if err != nil {
+						return "", err
+					}
+					return string(mustMarshal(diff)), nil
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "get [CONFIG_ID]",
+			Short: "Show a configuration, by default the default configuration",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+					configID, err := configIDArgument(ctx, szConfigManager, args)
+					if err != nil {
+						return "", err
+					}
+					return szConfigManager.GetConfig(ctx, configID)
+				})
+			},
+		},
+		app.newHistoryCommand(),
+		app.newImportCommand(),
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the registered configurations",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_ = args
+				return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+					return szConfigManager.GetConfigs(ctx)
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "plan FILE",
+			Short: "Show how the data sources of the default configuration differ from those of a YAML or JSON file",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				desired, err := szdatasources.ReadFile(args[0])
+				if err != nil {
+					return err
+				}
+				return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+					plan, err := szdatasources.NewPlan(ctx, factory, desired)
+					if err != nil {
+						return "", err
+					}
+					return string(mustMarshal(plan)), nil
+				})
+			},
+		},
+		app.newRollbackCommand(),
+		&cobra.Command{
+			Use:   "set-default CONFIG_ID",
+			Short: "Make a registered configuration the default",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				configID, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+				return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+					return "", szConfigManager.SetDefaultConfigID(ctx, configID)
+				})
+			},
+		},
+	)
+	return result
+}
+
+/*
+Create a command that changes the data sources of the default configuration:
+it registers a changed copy of the default configuration and makes it the default,
+making the change again if another update changed the default meanwhile.  Prints the new configuration ID.
+The summary of its comment is --comment, or the summary given followed by the data sources.
+*/
+func (app *application) newDataSourceCommand(use string, short string, summary string, change func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr, dataSourceCode string) error) *cobra.Command {
+	var comment string
+	result := &cobra.Command{
+		Use:   use + " DATA_SOURCE...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(comment) == 0 {
+				comment = summary + " " + strings.Join(args, ", ")
+			}
+			return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+				configID, err := updateDefaultConfig(ctx, factory, app.newComment(cmd, comment).String(), func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error {
+					for _, dataSourceCode := range args {
+						if err := change(ctx, szConfig, configHandle, dataSourceCode); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+				return strconv.FormatInt(configID, 10), err
+			})
+		},
+	}
+	result.Flags().StringVar(&comment, "comment", "", "summary of the change; default lists the data sources")
+	app.addCommentFlags(result)
+	return result
+}
+
+// Add the flags that set the author and revision of the structured comments of new configurations.
+func (app *application) addCommentFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&app.settings.author, "author", "", "author of the change; default is the current user")
+	cmd.Flags().StringVar(&app.settings.revision, "revision", "", "revision of the change, such as the git commit of the configuration files")
+}
+
+func (app *application) newApplyCommand() *cobra.Command {
+	var comment string
+	result := &cobra.Command{
+		Use:   "apply FILE",
+		Short: "Give the default configuration the data sources of a YAML or JSON file.  Prints its ID",
+		Long: `Give the default configuration the data sources of a YAML or JSON file, such as:
+
+  dataSources:
+    - CUSTOMERS
+    - WATCHLIST
+  prune: false
+
+Listed data sources that are missing are added.  With prune, data sources that are not listed are deleted,
+except the built-in TEST and SEARCH.  Data sources listed under delete, built-in or not, are always deleted.
+The plan is printed to standard error, then a changed copy of the default configuration is registered
+and made the default, failing if the default changed meanwhile.  Prints the ID of the default configuration.
+The summary of the comment of the new configuration is --comment, by default the plan.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			desired, err := szdatasources.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+				plan, err := szdatasources.NewPlan(ctx, factory, desired)
+				if err != nil {
+					return "", err
+				}
+				if _, err := fmt.Fprint(cmd.ErrOrStderr(), plan); err != nil {
+					return "", err
+				}
+				summary := comment
+				if len(summary) == 0 {
+					summary = plan.Comment()
+				}
+				configID, err := plan.Apply(ctx, factory, app.newComment(cmd, summary).String())
+				return strconv.FormatInt(configID, 10), err
+			})
+		},
+	}
+	result.Flags().StringVar(&comment, "comment", "", "summary of the change; default describes the plan")
+	app.addCommentFlags(result)
+	return result
+}
+
+func (app *application) newDataSourcesCommand() *cobra.Command {
+	var configID int64
+	result := &cobra.Command{
+		Use:   "data-sources",
+		Short: "List the data sources of a configuration, by default the default configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = args
+			return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+				szConfigManager, err := factory.CreateSzConfigManager(ctx)
+				if err != nil {
+					return "", err
+				}
+				if configID == 0 {
+					configID, err = szConfigManager.GetDefaultConfigID(ctx)
+					if err != nil {
+						return "", err
+					}
+				}
+				configDefinition, err := szConfigManager.GetConfig(ctx, configID)
+				if err != nil {
+					return "", err
+				}
+				szConfig, err := factory.CreateSzConfig(ctx)
+				if err != nil {
+					return "", err
+				}
+				configHandle, err := szConfig.ImportConfig(ctx, configDefinition)
+				if err != nil {
+					return "", err
+				}
+				defer func() { _ = szConfig.CloseConfig(ctx, configHandle) }()
+				return szConfig.GetDataSources(ctx, configHandle)
+			})
+		},
+	}
+	result.Flags().Int64Var(&configID, "config-id", 0, "ID of the configuration; default is the default configuration")
+	return result
+}
+
+func (app *application) newHistoryCommand() *cobra.Command {
+	var (
+		filter szconfighistory.Filter
+		since  string
+		until  string
+	)
+	result := &cobra.Command{
+		Use:   "history",
+		Short: "List the registered configurations, oldest first, marking the default",
+		Long: `List the registered configurations, oldest first, marking the default.
+The flags select configurations by the fields of their structured comments and their creation dates.
+A comment that is not structured only has a summary, its text.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = args
+			var err error
+			if filter.Since, err = parseTime("since", since); err != nil {
+				return err
+			}
+			if filter.Until, err = parseTime("until", until); err != nil {
+				return err
+			}
+			return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+				history, err := szconfighistory.Query(ctx, szConfigManager, filter)
+				if err != nil {
+					return "", err
+				}
+				return string(mustMarshal(map[string][]szconfighistory.Entry{"CONFIGS": history})), nil
+			})
+		},
+	}
+	flags := result.Flags()
+	flags.StringVar(&filter.Author, "author", "", "only configurations by this author, ignoring case")
+	flags.StringVar(&filter.Tool, "tool", "", "only configurations registered by a tool starting with this, such as "+ProgramName)
+	flags.StringVar(&filter.Revision, "revision", "", "only configurations of a revision starting with this")
+	flags.StringVar(&filter.Summary, "summary", "", "only configurations whose summary contains this, ignoring case")
+	flags.Int64Var(&filter.ParentConfigID, "parent", 0, "only configurations derived from this configuration")
+	flags.StringVar(&since, "since", "", "only configurations created at or after this date, such as 2024-06-01 or 2024-06-01T12:00:00Z")
+	flags.StringVar(&until, "until", "", "only configurations created before this date")
+	return result
+}
+
+func (app *application) newImportCommand() *cobra.Command {
+	var (
+		comment    string
+		setDefault bool
+	)
+	result := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Register a configuration from a file, or - for standard input.  Prints its ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configDefinition, err := readArgument(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+				summary := comment
+				if len(summary) == 0 {
+					summary = "Imported " + args[0]
+				}
+				configID, err := szConfigManager.AddConfig(ctx, configDefinition, app.newComment(cmd, summary).String())
+				if err != nil {
+					return "", err
+				}
+				if setDefault {
+					err = szConfigManager.SetDefaultConfigID(ctx, configID)
+				}
+				return strconv.FormatInt(configID, 10), err
+			})
+		},
+	}
+	result.Flags().StringVar(&comment, "comment", "", "summary of the configuration; default names the file")
+	result.Flags().BoolVar(&setDefault, "set-default", false, "make the configuration the default")
+	app.addCommentFlags(result)
+	return result
+}
+
+func (app *application) newRollbackCommand() *cobra.Command {
+	var reason string
+	result := &cobra.Command{
+		Use:   "rollback CONFIG_ID",
+		Short: "Make an earlier configuration the default again.  Prints the ID of the new default",
+		Long: `Make an earlier configuration the default again.
+A copy of the configuration is registered with a comment giving --reason, and made the default,
+failing if the default changed meanwhile.  Prints the ID of the copy.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			return app.runConfigManager(cmd, func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error) {
+				newConfigID, err := szconfighistory.Rollback(ctx, szConfigManager, configID, app.newComment(cmd, reason))
+				return strconv.FormatInt(newConfigID, 10), err
+			})
+		},
+	}
+	result.Flags().StringVar(&reason, "reason", "", "why, recorded in the comment of the new configuration")
+	_ = result.MarkFlagRequired("reason")
+	app.addCommentFlags(result)
+	return result
+}
+
+/*
+A structured comment for a new configuration: --author, by default the current user,
+the program and its version as the tool, --revision, and the summary given.
+*/
+func (app *application) newComment(cmd *cobra.Command, summary string) szconfigcomment.Comment {
+	result := szconfigcomment.Comment{
+		Author:   app.settings.author,
+		Tool:     strings.TrimSpace(ProgramName + " " + cmd.Root().Version),
+		Revision: app.settings.revision,
+		Summary:  summary,
+	}
+	if len(result.Author) == 0 {
+		if currentUser, err := user.Current(); err == nil {
+			result.Author = currentUser.Username
+		}
+	}
+	return result
+}
+
+// Connect, create an SzConfigManager, and print the result of a call.
+func (app *application) runConfigManager(cmd *cobra.Command, call func(ctx context.Context, szConfigManager senzing.SzConfigManager) (string, error)) error {
+	return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+		szConfigManager, err := factory.CreateSzConfigManager(ctx)
+		if err != nil {
+			return "", err
+		}
+		return call(ctx, szConfigManager)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// The configuration ID given as the only argument, or the default configuration ID.
+func configIDArgument(ctx context.Context, szConfigManager senzing.SzConfigManager, args []string) (int64, error) {
+	if len(args) == 0 {
+		return szConfigManager.GetDefaultConfigID(ctx)
+	}
+	return parseID(args[0])
+}
+
+// Parse a date flag, such as 2024-06-01 or 2024-06-01T12:00:00Z.  An empty value is the zero time.
+func parseTime(flagName string, value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		if result, err := time.Parse(layout, value); err == nil {
+			return result, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--%s must be a date, such as 2024-06-01 or 2024-06-01T12:00:00Z, not %q", flagName, value)
+}
+
+// Read a file argument, or standard input for "-".
+func readArgument(cmd *cobra.Command, fileName string) (string, error) {
+	if fileName == "-" {
+		result, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", fmt.Errorf("cannot read standard input: %w", err)
+		}
+		return string(result), nil
+	}
+	result, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("cannot read %s: %w", fileName, err)
+	}
+	return string(result), nil
+}
+
+/*
+Register a changed copy of the default configuration and make it the default.
+If another update changed the default configuration meanwhile, the change is made again to the new default.
+Returns the ID of the new configuration.
+*/
+func updateDefaultConfig(ctx context.Context, factory senzing.SzAbstractFactory, comment string, mutation szconfigupdater.Mutation) (int64, error) {
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	if err != nil {
+		return 0, err
+	}
+	szConfig, err := factory.CreateSzConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	updater, err := szconfigupdater.New(szConfigManager, szConfig, szconfigupdater.WithOrigin(ProgramName))
+	if err != nil {
+		return 0, err
+	}
+	result, err := updater.Update(ctx, comment, mutation)
+	return result.ConfigID, err
+}
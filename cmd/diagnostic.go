@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/spf13/cobra"
+)
+
+// ----------------------------------------------------------------------------
+// application methods
+// ----------------------------------------------------------------------------
+
+func (app *application) newDiagnosticCommand() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "diagnostic",
+		Short: "Inspect and maintain the Senzing datastore",
+	}
+	var secondsToRun int
+	checkPerformance := &cobra.Command{
+		Use:   "check-performance",
+		Short: "Measure the performance of the datastore",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = args
+			return app.runDiagnostic(cmd, func(ctx context.Context, szDiagnostic senzing.SzDiagnostic) (string, error) {
+				return szDiagnostic.CheckDatastorePerformance(ctx, secondsToRun)
+			})
+		},
+	}
+	checkPerformance.Flags().IntVar(&secondsToRun, "seconds", 1, "how long to run the check")
+	var confirmed bool
+	purge := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete every record and entity from the datastore",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = args
+			if !confirmed {
+				return errors.New("purge deletes all data; confirm with --yes")
+			}
+			return app.runDiagnostic(cmd, func(ctx context.Context, szDiagnostic senzing.SzDiagnostic) (string, error) {
+				return "", szDiagnostic.PurgeRepository(ctx)
+			})
+		},
+	}
+	purge.Flags().BoolVar(&confirmed, "yes", false, "confirm that all data is deleted")
+	result.AddCommand(
+		checkPerformance,
+		&cobra.Command{
+			Use:   "datastore-info",
+			Short: "Show information about the datastore",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_ = args
+				return app.runDiagnostic(cmd, func(ctx context.Context, szDiagnostic senzing.SzDiagnostic) (string, error) {
+					return szDiagnostic.GetDatastoreInfo(ctx)
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "feature FEATURE_ID",
+			Short: "Show a feature",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				featureID, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+				return app.runDiagnostic(cmd, func(ctx context.Context, szDiagnostic senzing.SzDiagnostic) (string, error) {
+					return szDiagnostic.GetFeature(ctx, featureID)
+				})
+			},
+		},
+		purge,
+	)
+	return result
+}
+
+// Connect, create an SzDiagnostic, and print the result of a call.
+func (app *application) runDiagnostic(cmd *cobra.Command, call func(ctx context.Context, szDiagnostic senzing.SzDiagnostic) (string, error)) error {
+	return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+		szDiagnostic, err := factory.CreateSzDiagnostic(ctx)
+		if err != nil {
+			return "", err
+		}
+		return call(ctx, szDiagnostic)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Parse an entity, feature or configuration ID.
+func parseID(argument string) (int64, error) {
+	result, err := strconv.ParseInt(argument, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an ID", argument)
+	}
+	return result, nil
+}
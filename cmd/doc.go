@@ -0,0 +1,32 @@
+/*
+The cmd package implements sz-grpc, a command-line client for a Senzing gRPC server.
+
+Subcommands are grouped by Senzing API: "config", "diagnostic", "engine" and "product".
+The "load" subcommand adds the records of JSON lines or CSV files with a pool of workers,
+and the "redo" subcommand processes redo records as they arrive.
+"config plan" and "config apply" compare the data sources of the default configuration
+with those listed in a YAML or JSON file, and make them match.
+"config history", "config diff" and "config rollback" list the registered configurations,
+compare two of them, and make an earlier one the default again.
+Configurations registered by sz-grpc get structured comments, see the szconfigcomment package,
+recording --author, --revision, the program and a summary, which "config history" can filter by.
+For example:
+
+	sz-grpc product version --output pretty
+	sz-grpc config add-data-source CUSTOMERS
+	sz-grpc config apply data-sources.yaml
+	sz-grpc config rollback 4015529876 --reason "CUSTOMERS is not ready"
+	sz-grpc config history --author jane --since 2024-06-01 --output table
+	sz-grpc engine add-record CUSTOMERS 1001 record.json --with-info
+	sz-grpc engine export --format csv
+	sz-grpc load customers.jsonl --workers 8 --rejects rejects.jsonl
+	sz-grpc redo --with-info info.jsonl
+
+The connection is configured with global flags: the address, TLS, and a bearer token.
+Every flag can also be set with an environment variable named SENZING_TOOLS_ followed by the flag name
+in upper case with underscores, such as SENZING_TOOLS_GRPC_ADDRESS for --grpc-address.
+A flag given on the command line takes precedence over the environment.
+
+Results are printed as returned by the server ("json"), indented ("pretty"), or as a table ("table").
+*/
+package cmd
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/spf13/cobra"
+)
+
+// ----------------------------------------------------------------------------
+// application methods
+// ----------------------------------------------------------------------------
+
+func (app *application) newEngineCommand() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "engine",
+		Short: "Add, resolve, search and export records and entities",
+	}
+	result.AddCommand(
+		app.newAddRecordCommand(),
+		app.newEngineCallCommand("count-redo", "Count the redo records waiting to be processed", cobra.NoArgs, -1,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				_ = args
+				_ = flags
+				count, err := szEngine.CountRedoRecords(ctx)
+				return strconv.FormatInt(count, 10), err
+			}),
+		app.newEngineCallCommand("delete-record DATA_SOURCE RECORD_ID", "Delete a record", cobra.ExactArgs(2), senzing.SzNoFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				return szEngine.DeleteRecord(ctx, args[0], args[1], flags)
+			}),
+		app.newExportCommand(),
+		app.newFindNetworkCommand(),
+		app.newFindPathCommand(),
+		app.newEngineCallCommand("get-entity ENTITY_ID", "Show an entity", cobra.ExactArgs(1), senzing.SzEntityDefaultFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				entityID, err := parseID(args[0])
+				if err != nil {
+					return "", err
+				}
+				return szEngine.GetEntityByEntityID(ctx, entityID, flags)
+			}),
+		app.newEngineCallCommand("get-entity-by-record DATA_SOURCE RECORD_ID", "Show the entity of a record", cobra.ExactArgs(2), senzing.SzEntityDefaultFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				return szEngine.GetEntityByRecordID(ctx, args[0], args[1], flags)
+			}),
+		app.newEngineCallCommand("get-record DATA_SOURCE RECORD_ID", "Show a record", cobra.ExactArgs(2), senzing.SzRecordDefaultFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				return szEngine.GetRecord(ctx, args[0], args[1], flags)
+			}),
+		app.newEngineCallCommand("how-entity ENTITY_ID", "Explain how an entity was resolved", cobra.ExactArgs(1), senzing.SzHowEntityDefaultFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				entityID, err := parseID(args[0])
+				if err != nil {
+					return "", err
+				}
+				return szEngine.HowEntityByEntityID(ctx, entityID, flags)
+			}),
+		app.newEngineCallCommand("prime", "Load the engine's caches", cobra.NoArgs, -1,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				_ = args
+				_ = flags
+				return "", szEngine.PrimeEngine(ctx)
+			}),
+		app.newProcessRedoCommand(),
+		app.newEngineCallCommand("reevaluate-entity ENTITY_ID", "Resolve an entity again", cobra.ExactArgs(1), senzing.SzNoFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				entityID, err := parseID(args[0])
+				if err != nil {
+					return "", err
+				}
+				return szEngine.ReevaluateEntity(ctx, entityID, flags)
+			}),
+		app.newEngineCallCommand("reevaluate-record DATA_SOURCE RECORD_ID", "Resolve a record again", cobra.ExactArgs(2), senzing.SzNoFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				return szEngine.ReevaluateRecord(ctx, args[0], args[1], flags)
+			}),
+		app.newSearchCommand(),
+		app.newEngineCallCommand("stats", "Show the engine's workload statistics since they were last shown", cobra.NoArgs, -1,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				_ = args
+				_ = flags
+				return szEngine.GetStats(ctx)
+			}),
+		app.newEngineCallCommand("why-entities ENTITY_ID_1 ENTITY_ID_2", "Explain why two entities are, or are not, related", cobra.ExactArgs(2), senzing.SzWhyEntitiesDefaultFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				entityIDs, err := parseIDs(args)
+				if err != nil {
+					return "", err
+				}
+				return szEngine.WhyEntities(ctx, entityIDs[0], entityIDs[1], flags)
+			}),
+		app.newEngineCallCommand("why-record-in-entity DATA_SOURCE RECORD_ID", "Explain why a record is in its entity", cobra.ExactArgs(2), senzing.SzWhyRecordInEntityIDefaultFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				return szEngine.WhyRecordInEntity(ctx, args[0], args[1], flags)
+			}),
+		app.newEngineCallCommand("why-records DATA_SOURCE_1 RECORD_ID_1 DATA_SOURCE_2 RECORD_ID_2", "Explain why two records did, or did not, resolve", cobra.ExactArgs(4), senzing.SzWhyRecordsDefaultFlags,
+			func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+				return szEngine.WhyRecords(ctx, args[0], args[1], args[2], args[3], flags)
+			}),
+	)
+	return result
+}
+
+func (app *application) newAddRecordCommand() *cobra.Command {
+	var result *cobra.Command
+	result = app.newEngineCallCommand("add-record DATA_SOURCE RECORD_ID FILE", "Add a record defined in a file, or - for standard input", cobra.ExactArgs(3), senzing.SzNoFlags,
+		func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+			recordDefinition, err := readArgument(result, args[2])
+			if err != nil {
+				return "", err
+			}
+			return szEngine.AddRecord(ctx, args[0], args[1], recordDefinition, flags)
+		})
+	return result
+}
+
+/*
+Create a command that makes one SzEngine call.
+Unless flagsDefault is negative, the command has a --flags flag with that default,
+and a --with-info flag for methods that can return info.
+*/
+func (app *application) newEngineCallCommand(use string, short string, args cobra.PositionalArgs, flagsDefault int64, call func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error)) *cobra.Command {
+	var (
+		flags    int64
+		withInfo bool
+	)
+	result := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if withInfo {
+				flags |= senzing.SzWithInfo
+			}
+			return app.runEngine(cmd, func(ctx context.Context, szEngine senzing.SzEngine) (string, error) {
+				return call(ctx, szEngine, args, flags)
+			})
+		},
+	}
+	if flagsDefault >= 0 {
+		result.Flags().Int64Var(&flags, "flags", flagsDefault, "Senzing flags of the call")
+		if slices.Contains(withInfoCommands, strings.Fields(use)[0]) {
+			result.Flags().BoolVar(&withInfo, "with-info", false, "return the entities affected by the call")
+		}
+	}
+	return result
+}
+
+func (app *application) newExportCommand() *cobra.Command {
+	var (
+		columns string
+		flags   int64
+		format  string
+	)
+	result := &cobra.Command{
+		Use:   "export",
+		Short: "Export entities as JSON lines or CSV",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = args
+			if format != formatJSON && format != formatCsv {
+				return fmt.Errorf("--format must be %s or %s, not %q", formatJSON, formatCsv, format)
+			}
+			ctx, cancel := app.context(cmd.Context())
+			defer cancel()
+			factory, err := app.getFactory(ctx)
+			if err != nil {
+				return err
+			}
+			szEngine, err := factory.CreateSzEngine(ctx)
+			if err != nil {
+				return err
+			}
+			fragments := szEngine.ExportJSONEntityReportIterator(ctx, flags)
+			if format == formatCsv {
+				fragments = szEngine.ExportCsvEntityReportIterator(ctx, columns, flags)
+			}
+			return app.printExport(cmd, format, fragments)
+		},
+	}
+	result.Flags().StringVar(&format, "format", formatJSON, "export format: json or csv")
+	result.Flags().StringVar(&columns, "columns", "", "comma-separated CSV columns; default is the server's")
+	result.Flags().Int64Var(&flags, "flags", senzing.SzExportDefaultFlags, "Senzing flags of the export")
+	return result
+}
+
+func (app *application) newFindNetworkCommand() *cobra.Command {
+	var buildOutDegree, buildOutMaxEntities, maxDegrees int64
+	result := app.newEngineCallCommand("find-network ENTITY_ID...", "Find the network of paths between entities", cobra.MinimumNArgs(1), senzing.SzFindNetworkDefaultFlags,
+		func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+			entityIDs, err := parseIDs(args)
+			if err != nil {
+				return "", err
+			}
+			return szEngine.FindNetworkByEntityID(ctx, entityList(entityIDs), maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
+		})
+	result.Flags().Int64Var(&maxDegrees, "max-degrees", 3, "maximum length of each path")
+	result.Flags().Int64Var(&buildOutDegree, "build-out-degree", 1, "degrees of related entities added around the network")
+	result.Flags().Int64Var(&buildOutMaxEntities, "build-out-max-entities", 10, "maximum number of related entities added")
+	return result
+}
+
+func (app *application) newFindPathCommand() *cobra.Command {
+	var (
+		avoid      []string
+		maxDegrees int64
+		required   []string
+	)
+	result := app.newEngineCallCommand("find-path START_ENTITY_ID END_ENTITY_ID", "Find a path between two entities", cobra.ExactArgs(2), senzing.SzFindPathDefaultFlags,
+		func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+			entityIDs, err := parseIDs(args)
+			if err != nil {
+				return "", err
+			}
+			avoidEntityIDs, err := parseIDs(avoid)
+			if err != nil {
+				return "", err
+			}
+			avoidList := ""
+			if len(avoidEntityIDs) > 0 {
+				avoidList = entityList(avoidEntityIDs)
+			}
+			requiredList := ""
+			if len(required) > 0 {
+				requiredList = string(mustMarshal(map[string][]string{"DATA_SOURCES": required}))
+			}
+			return szEngine.FindPathByEntityID(ctx, entityIDs[0], entityIDs[1], maxDegrees, avoidList, requiredList, flags)
+		})
+	result.Flags().Int64Var(&maxDegrees, "max-degrees", 3, "maximum length of the path")
+	result.Flags().StringSliceVar(&avoid, "avoid", nil, "IDs of entities the path avoids, if it can")
+	result.Flags().StringSliceVar(&required, "required-data-source", nil, "data sources of which the path must include a record")
+	return result
+}
+
+func (app *application) newProcessRedoCommand() *cobra.Command {
+	var limit int
+	result := app.newEngineCallCommand("process-redo", "Process redo records until none are left, or --limit is reached", cobra.NoArgs, senzing.SzNoFlags,
+		func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+			_ = args
+			results := []json.RawMessage{}
+			for processed := 0; limit <= 0 || processed < limit; processed++ {
+				redoRecord, err := szEngine.GetRedoRecord(ctx)
+				if err != nil {
+					return "", err
+				}
+				if len(redoRecord) == 0 {
+					break
+				}
+				info, err := szEngine.ProcessRedoRecord(ctx, redoRecord, flags)
+				if err != nil {
+					return "", err
+				}
+				if json.Valid([]byte(info)) {
+					results = append(results, json.RawMessage(info))
+				}
+			}
+			return string(mustMarshal(results)), nil
+		})
+	result.Flags().IntVar(&limit, "limit", 0, "maximum number of redo records processed; 0 means all")
+	return result
+}
+
+func (app *application) newSearchCommand() *cobra.Command {
+	var searchProfile string
+	result := app.newEngineCallCommand("search ATTRIBUTES", "Search for entities by attributes, given as JSON", cobra.ExactArgs(1), senzing.SzSearchByAttributesDefaultFlags,
+		func(ctx context.Context, szEngine senzing.SzEngine, args []string, flags int64) (string, error) {
+			return szEngine.SearchByAttributes(ctx, args[0], searchProfile, flags)
+		})
+	result.Flags().StringVar(&searchProfile, "profile", "", "search profile, such as SEARCH")
+	return result
+}
+
+// Print the fragments of an export as they arrive, or all at once for a table.
+func (app *application) printExport(cmd *cobra.Command, format string, fragments chan senzing.StringFragment) error {
+	writer := cmd.OutOrStdout()
+	table := []json.RawMessage{}
+	for fragment := range fragments {
+		if fragment.Error != nil {
+			return fragment.Error
+		}
+		switch {
+		case format == formatCsv:
+			if _, err := fmt.Fprint(writer, fragment.Value); err != nil {
+				return err
+			}
+		case app.settings.output == OutputTable:
+			table = append(table, json.RawMessage(strings.TrimSpace(fragment.Value)))
+		default:
+			if err := printResult(writer, app.settings.output, fragment.Value); err != nil {
+				return err
+			}
+		}
+	}
+	if format == formatJSON && app.settings.output == OutputTable {
+		return printResult(writer, OutputTable, string(mustMarshal(table)))
+	}
+	return nil
+}
+
+// Connect, create an SzEngine, and print the result of a call.
+func (app *application) runEngine(cmd *cobra.Command, call func(ctx context.Context, szEngine senzing.SzEngine) (string, error)) error {
+	return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+		szEngine, err := factory.CreateSzEngine(ctx)
+		if err != nil {
+			return "", err
+		}
+		return call(ctx, szEngine)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// A list of entities, such as {"ENTITIES": [{"ENTITY_ID": 1}]}.
+func entityList(entityIDs []int64) string {
+	entities := []map[string]int64{}
+	for _, entityID := range entityIDs {
+		entities = append(entities, map[string]int64{"ENTITY_ID": entityID})
+	}
+	return string(mustMarshal(map[string]any{"ENTITIES": entities}))
+}
+
+func mustMarshal(value any) []byte {
+	result, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// Parse entity IDs.
+func parseIDs(arguments []string) ([]int64, error) {
+	result := make([]int64, 0, len(arguments))
+	for _, argument := range arguments {
+		entityID, err := parseID(argument)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entityID)
+	}
+	return result, nil
+}
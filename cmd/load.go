@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szloader"
+	"github.com/spf13/cobra"
+)
+
+// The values of the flags of the load command.
+type loadSettings struct {
+	checkpointDir    string
+	dataSource       string
+	format           string
+	progressInterval time.Duration
+	rejectsFile      string
+	withInfoFile     string
+	workers          int
+}
+
+// ----------------------------------------------------------------------------
+// application methods
+// ----------------------------------------------------------------------------
+
+func (app *application) newLoadCommand() *cobra.Command {
+	settings := loadSettings{}
+	result := &cobra.Command{
+		Use:   "load FILE...",
+		Short: "Add the records of JSON lines or CSV files, or - for standard input",
+		Long: `Add the records of JSON lines or CSV files, or - for standard input.
+Records that cannot be parsed or added are written to --rejects with their line number and error, and the load continues.
+Progress is printed to standard error.  On SIGINT or SIGTERM, records being added finish and the load stops.
+With --checkpoint-dir, each file's progress is kept in a checkpoint file in the directory, and loading the file again
+resumes where the last load stopped.  A file that changed since its checkpoint was written is an error.
+--rejects and --with-info are then appended to, keeping the output of the loads resumed from.
+--timeout does not apply.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return app.load(ctx, cmd, args, settings)
+		},
+	}
+	flags := result.Flags()
+	flags.StringVar(&settings.checkpointDir, "checkpoint-dir", "", "directory of checkpoint files, one per input file, for resumable loads")
+	flags.StringVar(&settings.dataSource, "data-source", "", "data source of records without DATA_SOURCE")
+	flags.StringVar(&settings.format, "format", formatAuto, "file format: auto, jsonl or csv; auto chooses csv for names ending in .csv")
+	flags.DurationVar(&settings.progressInterval, "progress-interval", szloader.DefaultProgressInterval, "how often progress is printed")
+	flags.StringVar(&settings.rejectsFile, "rejects", "", "file where rejected records are written as JSON lines")
+	flags.StringVar(&settings.withInfoFile, "with-info", "", "file where the entities affected by each record are written as JSON lines")
+	flags.IntVar(&settings.workers, "workers", szloader.DefaultWorkers, "number of concurrent AddRecord calls")
+	return result
+}
+
+// Load the files in turn and print the totals.
+func (app *application) load(ctx context.Context, cmd *cobra.Command, fileNames []string, settings loadSettings) error {
+	if settings.format != formatAuto && settings.format != formatJSONL && settings.format != formatCsv {
+		return fmt.Errorf("--format must be %s, %s or %s, not %q", formatAuto, formatJSONL, formatCsv, settings.format)
+	}
+	factory, err := app.getFactory(ctx)
+	if err != nil {
+		return err
+	}
+	szEngine, err := factory.CreateSzEngine(ctx)
+	if err != nil {
+		return err
+	}
+	options := []szloader.Option{
+		szloader.WithWorkers(settings.workers),
+		szloader.WithProgress(func(progress szloader.Progress) {
+			fmt.Fprintln(cmd.ErrOrStderr(), progress)
+		}, settings.progressInterval),
+	}
+	if len(settings.dataSource) > 0 {
+		options = append(options, szloader.WithDataSource(settings.dataSource))
+	}
+	for _, output := range []struct {
+		fileName string
+		option   func(io.Writer) szloader.Option
+	}{
+		{settings.rejectsFile, szloader.WithRejects},
+		{settings.withInfoFile, szloader.WithInfo},
+	} {
+		if len(output.fileName) == 0 {
+			continue
+		}
+		flag := os.O_CREATE | os.O_TRUNC | os.O_WRONLY
+		if len(settings.checkpointDir) > 0 {
+			flag = os.O_APPEND | os.O_CREATE | os.O_WRONLY // Keep the output of the lines the checkpoints skip.
+		}
+		file, err := os.OpenFile(output.fileName, flag, 0o666)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		options = append(options, output.option(file))
+	}
+	total := szloader.Progress{}
+	for _, fileName := range fileNames {
+		fileOptions := options
+		if len(settings.checkpointDir) > 0 {
+			if fileName == "-" {
+				return errors.New("standard input cannot be loaded with --checkpoint-dir")
+			}
+			checkpoint, err := checkpointFile(settings.checkpointDir, fileName)
+			if err != nil {
+				return err
+			}
+			fileOptions = append(slices.Clip(options), szloader.WithCheckpoint(checkpoint))
+		}
+		loader, err := szloader.New(szEngine, fileOptions...)
+		if err != nil {
+			return err
+		}
+		progress, err := loadFile(ctx, cmd, loader, fileName, settings.format)
+		total.Read += progress.Read
+		total.Loaded += progress.Loaded
+		total.Rejected += progress.Rejected
+		total.Elapsed += progress.Elapsed
+		if err != nil {
+			return fmt.Errorf("%s: %w", fileName, err)
+		}
+	}
+	return printResult(cmd.OutOrStdout(), app.settings.output, string(mustMarshal(map[string]any{
+		"READ":               total.Read,
+		"LOADED":             total.Loaded,
+		"REJECTED":           total.Rejected,
+		"ELAPSED_SECONDS":    total.Elapsed.Seconds(),
+		"RECORDS_PER_SECOND": total.RecordsPerSecond(),
+	})))
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// The checkpoint file of an input file: its base name, a hash of its absolute path and ".checkpoint.json".
+// The hash keeps input files of the same name in different directories apart.
+func checkpointFile(checkpointDir string, fileName string) (string, error) {
+	absolute, err := filepath.Abs(fileName)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(absolute))
+	return filepath.Join(checkpointDir, filepath.Base(fileName)+"."+hex.EncodeToString(hash[:8])+".checkpoint.json"), nil
+}
+
+// Load a file, or standard input for "-".
+func loadFile(ctx context.Context, cmd *cobra.Command, loader *szloader.Loader, fileName string, format string) (szloader.Progress, error) {
+	fileFormat := szloader.FormatOf(fileName)
+	switch format {
+	case formatCsv:
+		fileFormat = szloader.FormatCSV
+	case formatJSONL:
+		fileFormat = szloader.FormatJSONL
+	}
+	if fileName == "-" {
+		return loader.Load(ctx, cmd.InOrStdin(), fileFormat)
+	}
+	return loader.LoadFile(ctx, fileName, fileFormat)
+}
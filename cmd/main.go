@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The state of one invocation of the command: its settings and the connection, once made.
+type application struct {
+	factory        *szabstractfactory.Szabstractfactory
+	factoryOptions []szabstractfactory.Option // Added to the options derived from settings, for tests.
+	settings       settings
+}
+
+// The values of the global flags, and of the flags shared by the config commands that register configurations.
+type settings struct {
+	author         string // Of structured configuration comments.
+	caCertFile     string
+	clientCertFile string
+	clientKeyFile  string
+	grpcAddress    string
+	insecureToken  bool
+	output         string
+	revision       string // Of structured configuration comments.
+	serverName     string
+	timeout        time.Duration
+	tls            bool
+	token          string
+	tokenFile      string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// EnvironmentPrefix starts the name of the environment variable of every flag.
+const EnvironmentPrefix = "SENZING_TOOLS_"
+
+// Output modes.
+const (
+	OutputJSON   = "json"
+	OutputPretty = "pretty"
+	OutputTable  = "table"
+)
+
+// ProgramName is the name of the command.
+const ProgramName = "sz-grpc"
+
+// Export and load formats.
+const (
+	formatAuto  = "auto"
+	formatCsv   = "csv"
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The engine commands that have a --with-info flag.
+var withInfoCommands = []string{"add-record", "delete-record", "process-redo", "reevaluate-entity", "reevaluate-record"}
+
+// OutputModes lists the values of --output.
+var OutputModes = []string{OutputJSON, OutputPretty, OutputTable}
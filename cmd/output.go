@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+Print a result in an output mode.
+Results that are not JSON, such as CSV, are printed unchanged in every mode.
+*/
+func printResult(writer io.Writer, output string, result string) error {
+	result = strings.TrimRight(result, "\n")
+	if output == OutputJSON || !json.Valid([]byte(result)) {
+		_, err := fmt.Fprintln(writer, result)
+		return err
+	}
+	if output == OutputPretty {
+		indented := &bytes.Buffer{}
+		if err := json.Indent(indented, []byte(result), "", "  "); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(writer, indented.String())
+		return err
+	}
+	var document any
+	decoder := json.NewDecoder(strings.NewReader(result))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return err
+	}
+	return printTable(writer, document)
+}
+
+/*
+Print a JSON document as a table.
+A list of objects, or an object holding only such a list, such as {"CONFIGS": [...]}, has a row per object.
+An object, or an object holding only an object, such as {"RESOLVED_ENTITY": {...}}, has a row per field.
+Nested values are printed as JSON.
+*/
+func printTable(writer io.Writer, document any) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	if object, ok := document.(map[string]any); ok && len(object) == 1 {
+		for _, value := range object {
+			switch value.(type) {
+			case []any, map[string]any:
+				document = value
+			}
+		}
+	}
+	switch value := document.(type) {
+	case []any:
+		rows := make([]map[string]any, 0, len(value))
+		for _, element := range value {
+			rows = append(rows, tableRow(element))
+		}
+		columns := tableColumns(rows)
+		fmt.Fprintln(table, strings.Join(columns, "\t"))
+		for _, row := range rows {
+			cells := make([]string, 0, len(columns))
+			for _, column := range columns {
+				cells = append(cells, tableCell(row[column]))
+			}
+			fmt.Fprintln(table, strings.Join(cells, "\t"))
+		}
+	case map[string]any:
+		fmt.Fprintln(table, "FIELD\tVALUE")
+		for _, column := range tableColumns([]map[string]any{value}) {
+			fmt.Fprintf(table, "%s\t%s\n", column, tableCell(value[column]))
+		}
+	default:
+		fmt.Fprintln(table, tableCell(value))
+	}
+	return table.Flush()
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Format a value as a table cell: strings and numbers as is, other values as compact JSON.
+func tableCell(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case json.Number:
+		return typed.String()
+	default:
+		result, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprint(typed)
+		}
+		return string(result)
+	}
+}
+
+// The columns of a table: the fields of every row, sorted.
+func tableColumns(rows []map[string]any) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				result = append(result, column)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// The fields of a table row.  An object wrapping a single object, such as {"RESOLVED_ENTITY": {...}}, is unwrapped.
+func tableRow(element any) map[string]any {
+	object, ok := element.(map[string]any)
+	if !ok {
+		return map[string]any{"VALUE": element}
+	}
+	if len(object) == 1 {
+		for _, value := range object {
+			if inner, isObject := value.(map[string]any); isObject {
+				return inner
+			}
+		}
+	}
+	return object
+}
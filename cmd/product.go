@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/spf13/cobra"
+)
+
+// ----------------------------------------------------------------------------
+// application methods
+// ----------------------------------------------------------------------------
+
+func (app *application) newProductCommand() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "product",
+		Short: "Show the license and version of the Senzing server",
+	}
+	result.AddCommand(
+		&cobra.Command{
+			Use:   "license",
+			Short: "Show the license",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_ = args
+				return app.runProduct(cmd, func(ctx context.Context, szProduct senzing.SzProduct) (string, error) {
+					return szProduct.GetLicense(ctx)
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "version",
+			Short: "Show the version",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_ = args
+				return app.runProduct(cmd, func(ctx context.Context, szProduct senzing.SzProduct) (string, error) {
+					return szProduct.GetVersion(ctx)
+				})
+			},
+		},
+	)
+	return result
+}
+
+// Connect, create an SzProduct, and print the result of a call.
+func (app *application) runProduct(cmd *cobra.Command, call func(ctx context.Context, szProduct senzing.SzProduct) (string, error)) error {
+	return app.run(cmd, func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error) {
+		szProduct, err := factory.CreateSzProduct(ctx)
+		if err != nil {
+			return "", err
+		}
+		return call(ctx, szProduct)
+	})
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szredoer"
+	"github.com/spf13/cobra"
+)
+
+// The values of the flags of the redo command.
+type redoSettings struct {
+	failuresFile    string
+	maxPollInterval time.Duration
+	once            bool
+	pollInterval    time.Duration
+	withInfoFile    string
+	workers         int
+}
+
+// ----------------------------------------------------------------------------
+// application methods
+// ----------------------------------------------------------------------------
+
+func (app *application) newRedoCommand() *cobra.Command {
+	settings := redoSettings{}
+	result := &cobra.Command{
+		Use:   "redo",
+		Short: "Process redo records as they arrive, until interrupted",
+		Long: `Process redo records as they arrive, until interrupted.
+Workers take and process redo records while there are some; when there are none, the queue is polled again
+after --poll-interval, doubling up to --max-poll-interval.  With --once, redo stops when the queue is empty.
+A record that cannot be processed is counted as failed, written to --failures, and redo goes on.
+On SIGINT or SIGTERM, records being processed finish and redo stops.  --timeout does not apply.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = args
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return app.redo(ctx, cmd, settings)
+		},
+	}
+	flags := result.Flags()
+	flags.StringVar(&settings.failuresFile, "failures", "", "file where redo records that could not be processed are written as JSON lines")
+	flags.DurationVar(&settings.maxPollInterval, "max-poll-interval", szredoer.DefaultMaxPollInterval, "longest wait between polls of an empty queue")
+	flags.BoolVar(&settings.once, "once", false, "stop when the queue is empty")
+	flags.DurationVar(&settings.pollInterval, "poll-interval", szredoer.DefaultPollInterval, "first wait between polls of an empty queue")
+	flags.StringVar(&settings.withInfoFile, "with-info", "", "file where the entities affected by each record are written as JSON lines, or - for standard output")
+	flags.IntVar(&settings.workers, "workers", szredoer.DefaultWorkers, "number of concurrent workers")
+	return result
+}
+
+// Process redo records and print what was done.
+func (app *application) redo(ctx context.Context, cmd *cobra.Command, settings redoSettings) error {
+	factory, err := app.getFactory(ctx)
+	if err != nil {
+		return err
+	}
+	szEngine, err := factory.CreateSzEngine(ctx)
+	if err != nil {
+		return err
+	}
+	options := []szredoer.Option{
+		szredoer.WithPollInterval(settings.pollInterval, settings.maxPollInterval),
+		szredoer.WithWorkers(settings.workers),
+	}
+	switch settings.withInfoFile {
+	case "":
+	case "-":
+		options = append(options, szredoer.WithSink(szredoer.NewWriterSink(cmd.OutOrStdout())))
+	default:
+		file, err := os.Create(settings.withInfoFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		options = append(options, szredoer.WithSink(szredoer.NewWriterSink(file)))
+	}
+	if len(settings.failuresFile) > 0 {
+		file, err := os.Create(settings.failuresFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		options = append(options, szredoer.WithFailures(file))
+	}
+	redoer, err := szredoer.New(szEngine, options...)
+	if err != nil {
+		return err
+	}
+	run := redoer.Run
+	if settings.once {
+		run = redoer.Drain
+	}
+	stats, err := run(ctx)
+	if err != nil {
+		return err
+	}
+	return printResult(cmd.OutOrStdout(), app.settings.output, string(mustMarshal(map[string]int64{
+		"FAILED":    stats.Failed,
+		"PROCESSED": stats.Processed,
+		"RETRIED":   stats.Retried,
+	})))
+}
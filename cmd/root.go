@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+	"github.com/senzing-garage/sz-sdk-go-grpc/tlscredentials"
+	"github.com/senzing-garage/sz-sdk-go-grpc/tokencredentials"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Execute function runs the command with the arguments of the process.
+
+Input
+  - version: The version reported by --version.
+
+Output
+  - The error that ended the command, already printed to standard error.
+*/
+func Execute(version string) error {
+	return New(version).Execute()
+}
+
+/*
+The New function creates the root command and its subcommands.
+
+Input
+  - version: The version reported by --version.
+*/
+func New(version string) *cobra.Command {
+	return newApplication().newRootCommand(version)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newApplication(factoryOptions ...szabstractfactory.Option) *application {
+	return &application{factoryOptions: factoryOptions}
+}
+
+// ----------------------------------------------------------------------------
+// application methods
+// ----------------------------------------------------------------------------
+
+// Close the connection, if one was made.
+func (app *application) close() error {
+	if app.factory == nil {
+		return nil
+	}
+	err := app.factory.Close()
+	app.factory = nil
+	return err
+}
+
+// The context of a call to the server, bounded by --timeout.
+func (app *application) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if app.settings.timeout > 0 {
+		return context.WithTimeout(ctx, app.settings.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// Connect to the server on first use.
+func (app *application) getFactory(ctx context.Context) (*szabstractfactory.Szabstractfactory, error) {
+	if app.factory != nil {
+		return app.factory, nil
+	}
+	options, err := app.newFactoryOptions()
+	if err != nil {
+		return nil, err
+	}
+	app.factory, err = szabstractfactory.NewSzAbstractFactory(ctx, options...)
+	return app.factory, err
+}
+
+// Translate the settings into szabstractfactory options.
+func (app *application) newFactoryOptions() ([]szabstractfactory.Option, error) {
+	settings := app.settings
+	result := []szabstractfactory.Option{szabstractfactory.WithGrpcAddress(settings.grpcAddress)}
+	if settings.tls || len(settings.caCertFile) > 0 || len(settings.clientCertFile) > 0 || len(settings.serverName) > 0 {
+		result = append(result, szabstractfactory.WithTLS(tlscredentials.Config{
+			CACertFile:     settings.caCertFile,
+			ClientCertFile: settings.clientCertFile,
+			ClientKeyFile:  settings.clientKeyFile,
+			ServerName:     settings.serverName,
+		}))
+	}
+	var tokenSource tokencredentials.TokenSource
+	switch {
+	case len(settings.token) > 0 && len(settings.tokenFile) > 0:
+		return nil, fmt.Errorf("--token and --token-file cannot be used together")
+	case len(settings.token) > 0:
+		tokenSource = tokencredentials.NewStaticToken(settings.token)
+	case len(settings.tokenFile) > 0:
+		tokenSource = tokencredentials.NewFileToken(settings.tokenFile)
+	}
+	if tokenSource != nil {
+		if settings.insecureToken {
+			result = append(result, szabstractfactory.WithPerRPCCredentials(tokencredentials.NewInsecure(tokenSource)))
+		} else {
+			result = append(result, szabstractfactory.WithPerRPCCredentials(tokencredentials.New(tokenSource)))
+		}
+	}
+	return append(result, app.factoryOptions...), nil
+}
+
+// Connect, make a call within --timeout, and print its result, if any.
+func (app *application) run(cmd *cobra.Command, call func(ctx context.Context, factory senzing.SzAbstractFactory) (string, error)) error {
+	ctx, cancel := app.context(cmd.Context())
+	defer cancel()
+	factory, err := app.getFactory(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := call(ctx, factory)
+	if err != nil || len(result) == 0 {
+		return err
+	}
+	return printResult(cmd.OutOrStdout(), app.settings.output, result)
+}
+
+func (app *application) newRootCommand(version string) *cobra.Command {
+	result := &cobra.Command{
+		Use:   ProgramName,
+		Short: "A command-line client for a Senzing gRPC server",
+		Long: `A command-line client for a Senzing gRPC server.
+Every flag can also be set with an environment variable named ` + EnvironmentPrefix + ` followed by the flag name
+in upper case with underscores, such as ` + environmentVariable("grpc-address") + ` for --grpc-address.`,
+		SilenceUsage: true,
+		Version:      version,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			_ = args
+			if err := setFromEnvironment(cmd.Flags()); err != nil {
+				return err
+			}
+			if !slices.Contains(OutputModes, app.settings.output) {
+				return fmt.Errorf("--output must be one of %s, not %q", strings.Join(OutputModes, ", "), app.settings.output)
+			}
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			_ = cmd
+			_ = args
+			return app.close()
+		},
+	}
+	flags := result.PersistentFlags()
+	flags.StringVar(&app.settings.grpcAddress, "grpc-address", szabstractfactory.DefaultGrpcAddress, "address of the Senzing gRPC server")
+	flags.BoolVar(&app.settings.tls, "tls", false, "connect with TLS; implied by the other TLS flags")
+	flags.StringVar(&app.settings.caCertFile, "ca-cert-file", "", "PEM bundle of certificate authorities for TLS; default is the host's")
+	flags.StringVar(&app.settings.clientCertFile, "client-cert-file", "", "PEM client certificate for mutual TLS")
+	flags.StringVar(&app.settings.clientKeyFile, "client-key-file", "", "PEM private key of --client-cert-file")
+	flags.StringVar(&app.settings.serverName, "server-name", "", "host name used to verify the server certificate")
+	flags.StringVar(&app.settings.token, "token", "", "bearer token sent with every call")
+	flags.StringVar(&app.settings.tokenFile, "token-file", "", "file holding the bearer token, re-read when it changes")
+	flags.BoolVar(&app.settings.insecureToken, "insecure-token", false, "allow the bearer token without TLS, for development")
+	flags.StringVarP(&app.settings.output, "output", "o", OutputJSON, "output mode: "+strings.Join(OutputModes, ", "))
+	flags.DurationVar(&app.settings.timeout, "timeout", 0, "time limit for each call, such as 30s; 0 means none")
+	result.AddCommand(
+		app.newConfigCommand(),
+		app.newDiagnosticCommand(),
+		app.newEngineCommand(),
+		app.newLoadCommand(),
+		app.newProductCommand(),
+		app.newRedoCommand(),
+	)
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// The environment variable of a flag, such as SENZING_TOOLS_GRPC_ADDRESS for grpc-address.
+func environmentVariable(flagName string) string {
+	return EnvironmentPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// Set the flags not given on the command line from their environment variables.
+func setFromEnvironment(flags *pflag.FlagSet) error {
+	var result error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed || result != nil {
+			return
+		}
+		value, ok := os.LookupEnv(environmentVariable(flag.Name))
+		if !ok {
+			return
+		}
+		if err := flags.Set(flag.Name, value); err != nil {
+			result = fmt.Errorf("%s: %w", environmentVariable(flag.Name), err)
+		}
+	})
+	return result
+}
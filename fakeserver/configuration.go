@@ -0,0 +1,143 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+)
+
+// An in-memory Senzing configuration, as created by SzConfig.CreateConfig or SzConfig.ImportConfig.
+type configuration struct {
+	dataSources []dataSource
+	sections    map[string]json.RawMessage // The G2_CONFIG sections other than CFG_DSRC, kept verbatim.
+}
+
+type dataSource struct {
+	ID   int64  `json:"DSRC_ID"`
+	Code string `json:"DSRC_CODE"`
+}
+
+// A configuration added with SzConfigManager.AddConfig.
+type registeredConfig struct {
+	comments    string
+	createDate  string
+	dataSources map[string]bool // Empty if the definition cannot be parsed.
+	definition  string
+	id          int64
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Parse a configuration definition exported by SzConfig.ExportConfig.
+func parseConfiguration(configDefinition string) (*configuration, error) {
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(configDefinition), &document); err != nil {
+		return nil, newError(reasonJSONParsing, err.Error())
+	}
+	result := &configuration{sections: map[string]json.RawMessage{}}
+	if g2Config, ok := document["G2_CONFIG"]; ok {
+		if err := json.Unmarshal(g2Config, &result.sections); err != nil {
+			return nil, newError(reasonJSONParsing, err.Error())
+		}
+	}
+	if dataSources, ok := result.sections["CFG_DSRC"]; ok {
+		if err := json.Unmarshal(dataSources, &result.dataSources); err != nil {
+			return nil, newError(reasonJSONParsing, err.Error())
+		}
+		delete(result.sections, "CFG_DSRC")
+	}
+	return result, nil
+}
+
+func newRegisteredConfig(configDefinition string, configComment string) *registeredConfig {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(configDefinition))
+	result := &registeredConfig{
+		comments:    configComment,
+		createDate:  time.Now().UTC().Format("2006-01-02 15:04:05.000"),
+		dataSources: map[string]bool{},
+		definition:  configDefinition,
+		id:          max(int64(hash.Sum32()), 1),
+	}
+	if configuration, err := parseConfiguration(configDefinition); err == nil {
+		for _, dataSource := range configuration.dataSources {
+			result.dataSources[dataSource.Code] = true
+		}
+	}
+	return result
+}
+
+// Data source codes are stored in upper case and may only contain letters, digits, "-" and "_".
+func normalizeDataSourceCode(dataSourceCode string) (string, error) {
+	result := strings.ToUpper(dataSourceCode)
+	valid := len(result) > 0
+	for _, character := range result {
+		if !(character >= 'A' && character <= 'Z' || character >= '0' && character <= '9' || character == '-' || character == '_') {
+			valid = false
+		}
+	}
+	if !valid {
+		return "", newError(reasonBadDataSourceCode, dataSourceCode)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// configuration methods
+// ----------------------------------------------------------------------------
+
+func (configuration *configuration) addDataSource(dataSourceCode string) (dataSource, error) {
+	code, err := normalizeDataSourceCode(dataSourceCode)
+	if err != nil {
+		return dataSource{}, err
+	}
+	result := dataSource{ID: firstDataSourceID, Code: code}
+	for _, existing := range configuration.dataSources {
+		if existing.Code == code {
+			return dataSource{}, newError(reasonDataSourceExists, code)
+		}
+		result.ID = max(result.ID, existing.ID+1)
+	}
+	configuration.dataSources = append(configuration.dataSources, result)
+	return result, nil
+}
+
+func (configuration *configuration) deleteDataSource(dataSourceCode string) error {
+	code, err := normalizeDataSourceCode(dataSourceCode)
+	if err != nil {
+		return err
+	}
+	configuration.dataSources = slices.DeleteFunc(configuration.dataSources, func(existing dataSource) bool {
+		return existing.Code == code
+	})
+	return nil
+}
+
+func (configuration *configuration) export() string {
+	g2Config := map[string]json.RawMessage{}
+	for key, value := range configuration.sections {
+		g2Config[key] = value
+	}
+	dataSources := configuration.dataSources
+	if dataSources == nil {
+		dataSources = []dataSource{}
+	}
+	g2Config["CFG_DSRC"] = mustMarshal(dataSources)
+	return string(mustMarshal(map[string]any{"G2_CONFIG": g2Config}))
+}
+
+func (configuration *configuration) getDataSources() string {
+	result := struct {
+		DataSources []dataSource `json:"DATA_SOURCES"`
+	}{
+		DataSources: configuration.dataSources,
+	}
+	if result.DataSources == nil {
+		result.DataSources = []dataSource{}
+	}
+	return string(mustMarshal(result))
+}
@@ -0,0 +1,41 @@
+/*
+The fakeserver package serves the Senzing gRPC services from memory, for tests that cannot reach a Senzing server.
+
+A Server implements SzConfig, SzConfigManager, SzDiagnostic, SzEngine and SzProduct over an in-process
+google.golang.org/grpc/test/bufconn listener, so the clients in this module work against it unchanged.
+Records, configurations and exports are kept in memory and discarded when the Server is closed.
+
+Entity resolution is a deterministic toy, not Senzing's.
+Records resolve into the same entity when they share a NAME feature (the same surname) and at least one other feature:
+DOB, ADDRESS, PHONE, SSN, DRLIC, PASSPORT or EMAIL.
+Entities that share a feature other than NAME without resolving are POSSIBLY_RELATED.
+Entity IDs are assigned in sequence.  When entities merge, the smallest entity ID is kept.
+When a record is deleted, the records left in its entity are resolved again into entities with new IDs.
+Results only depend on the order of requests.
+Deleting a record without info from an entity with other records queues one redo record for it;
+so SzEngine.CountRedoRecords can differ from what a Senzing server reports for the same requests.
+Errors carry the Senzing reason codes the real server reports for the same conditions,
+so helper.ConvertGrpcError() classifies them with the same szerror types.
+
+Faults can be injected into calls, per method and per call count, with WithFaults() or Server.SetFaults():
+latency, bursts of codes.Unavailable, malformed error descriptions, truncated responses
+and server streams dropped part way through an export.
+
+A minimal example:
+
+	server, err := fakeserver.New(fakeserver.WithDataSources("CUSTOMERS"))
+	...
+	defer server.Close()
+	grpcConnection, err := server.NewClient()
+	...
+	szEngine := &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+
+In a test, NewTestClient() does the same, closing the Server and the connection when the test finishes:
+
+	server, grpcConnection := fakeserver.NewTestClient(test, fakeserver.WithDataSources("CUSTOMERS"))
+
+The test suites of this module run against a fake server when SENZING_TOOLS_TEST_SERVER is "fake":
+
+	SENZING_TOOLS_TEST_SERVER=fake go test -run '^Test' ./...
+*/
+package fakeserver
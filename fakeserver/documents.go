@@ -0,0 +1,271 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// The JSON documents returned by SzEngine.  Field order follows the Senzing gRPC server.
+
+type entityDocument struct {
+	ResolvedEntity  resolvedEntityDocument   `json:"RESOLVED_ENTITY"`
+	RelatedEntities *[]relatedEntityDocument `json:"RELATED_ENTITIES,omitempty"`
+}
+
+type entityIDDocument struct {
+	EntityID int64 `json:"ENTITY_ID"`
+}
+
+type featureDocument struct {
+	FeatureDescription string `json:"FEAT_DESC"`
+	LibFeatID          int64  `json:"LIB_FEAT_ID"`
+	UsageType          string `json:"USAGE_TYPE,omitempty"`
+}
+
+type featureIDDocument struct {
+	LibFeatID int64  `json:"LIB_FEAT_ID"`
+	UsageType string `json:"USAGE_TYPE,omitempty"`
+}
+
+type infoDocument struct {
+	DataSource          string                      `json:"DATA_SOURCE,omitempty"`
+	RecordID            string                      `json:"RECORD_ID,omitempty"`
+	AffectedEntities    []entityIDDocument          `json:"AFFECTED_ENTITIES"`
+	InterestingEntities interestingEntitiesDocument `json:"INTERESTING_ENTITIES"`
+}
+
+type interestingEntitiesDocument struct {
+	Entities []entityIDDocument `json:"ENTITIES"`
+}
+
+type matchInfoDocument struct {
+	MatchKey       string `json:"MATCH_KEY"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	ErruleCode     string `json:"ERRULE_CODE"`
+}
+
+type recordDocument struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+	InternalID int64  `json:"INTERNAL_ID,omitempty"`
+	*matchInfoDocument
+	JSONData json.RawMessage     `json:"JSON_DATA,omitempty"`
+	Features []featureIDDocument `json:"FEATURES,omitempty"`
+}
+
+type recordKeyDocument struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+}
+
+type recordSummaryDocument struct {
+	DataSource  string `json:"DATA_SOURCE"`
+	RecordCount int    `json:"RECORD_COUNT"`
+}
+
+type relatedEntityDocument struct {
+	EntityID int64 `json:"ENTITY_ID"`
+	*matchInfoDocument
+	EntityName    string                  `json:"ENTITY_NAME,omitempty"`
+	RecordSummary []recordSummaryDocument `json:"RECORD_SUMMARY,omitempty"`
+	Records       []recordDocument        `json:"RECORDS,omitempty"`
+}
+
+type resolvedEntityDocument struct {
+	EntityID      int64                        `json:"ENTITY_ID"`
+	EntityName    string                       `json:"ENTITY_NAME,omitempty"`
+	Features      map[string][]featureDocument `json:"FEATURES,omitempty"`
+	RecordSummary []recordSummaryDocument      `json:"RECORD_SUMMARY,omitempty"`
+	Records       []recordDocument             `json:"RECORDS,omitempty"`
+}
+
+// Flags that add record details to entity documents.
+const recordFlags = senzing.SzEntityIncludeRecordData | senzing.SzEntityIncludeRecordMatchingInfo | senzing.SzEntityIncludeRecordJSONData | senzing.SzEntityIncludeRecordFeatureIDs
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func hasFlag(flags int64, flag int64) bool {
+	return flags&flag != 0
+}
+
+func newInfoDocument(dataSource string, recordID string, entityIDs []int64) string {
+	result := infoDocument{
+		DataSource:          dataSource,
+		RecordID:            recordID,
+		AffectedEntities:    newEntityIDDocuments(entityIDs),
+		InterestingEntities: interestingEntitiesDocument{Entities: []entityIDDocument{}},
+	}
+	return string(mustMarshal(result))
+}
+
+func newEntityIDDocuments(entityIDs []int64) []entityIDDocument {
+	result := make([]entityIDDocument, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		result = append(result, entityIDDocument{EntityID: entityID})
+	}
+	return result
+}
+
+func newMatchInfoDocument(match matchInfo) *matchInfoDocument {
+	return &matchInfoDocument{
+		MatchKey:       match.key,
+		MatchLevelCode: match.level,
+		ErruleCode:     match.rule,
+	}
+}
+
+func newRecordDocument(member *record, flags int64) recordDocument {
+	result := recordDocument{
+		DataSource: member.dataSource,
+		RecordID:   member.recordID,
+	}
+	if hasFlag(flags, senzing.SzEntityIncludeRecordData) {
+		result.InternalID = member.internalID
+	}
+	if hasFlag(flags, senzing.SzEntityIncludeRecordMatchingInfo) {
+		result.matchInfoDocument = newMatchInfoDocument(member.match)
+	}
+	if hasFlag(flags, senzing.SzEntityIncludeRecordJSONData) {
+		result.JSONData = member.definition
+	}
+	if hasFlag(flags, senzing.SzEntityIncludeRecordFeatureIDs) {
+		for _, recordFeature := range member.features {
+			result.Features = append(result.Features, featureIDDocument{LibFeatID: recordFeature.feature.id, UsageType: recordFeature.usageType})
+		}
+	}
+	return result
+}
+
+func newRecordKeyDocuments(records []*record) []recordKeyDocument {
+	result := make([]recordKeyDocument, 0, len(records))
+	for _, member := range records {
+		result = append(result, recordKeyDocument{DataSource: member.dataSource, RecordID: member.recordID})
+	}
+	return result
+}
+
+func newRecordSummary(records []*record) []recordSummaryDocument {
+	counts := map[string]int{}
+	for _, member := range records {
+		counts[member.dataSource]++
+	}
+	result := make([]recordSummaryDocument, 0, len(counts))
+	for dataSource, count := range counts {
+		result = append(result, recordSummaryDocument{DataSource: dataSource, RecordCount: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DataSource < result[j].DataSource })
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// entity methods
+// ----------------------------------------------------------------------------
+
+// The description of the first NAME feature of the entity's records.
+func (entity *entity) name() string {
+	for _, member := range entity.records {
+		for _, recordFeature := range member.features {
+			if recordFeature.feature.featureType == featureName {
+				return recordFeature.feature.description
+			}
+		}
+	}
+	return ""
+}
+
+// ----------------------------------------------------------------------------
+// repository methods
+// ----------------------------------------------------------------------------
+
+// Build the document for an entity, including the parts selected by SZ_ENTITY_INCLUDE_* flags.
+func (repository *repository) newEntityDocument(focus *entity, flags int64) entityDocument {
+	result := entityDocument{ResolvedEntity: newResolvedEntityDocument(focus, flags)}
+	if hasFlag(flags, senzing.SzEntityIncludeAllRelations) && repository.entities[focus.id] == focus {
+		relatedEntities := []relatedEntityDocument{}
+		related, matches := repository.relatedEntities(focus)
+		for _, relatedEntity := range related {
+			if !hasFlag(flags, relationFlag(matches[relatedEntity].level)) {
+				continue
+			}
+			relatedDocument := relatedEntityDocument{EntityID: relatedEntity.id}
+			if hasFlag(flags, senzing.SzEntityIncludeRelatedMatchingInfo) {
+				relatedDocument.matchInfoDocument = newMatchInfoDocument(matches[relatedEntity])
+			}
+			if hasFlag(flags, senzing.SzEntityIncludeRelatedEntityName) {
+				relatedDocument.EntityName = relatedEntity.name()
+			}
+			if hasFlag(flags, senzing.SzEntityIncludeRelatedRecordSummary) {
+				relatedDocument.RecordSummary = newRecordSummary(relatedEntity.records)
+			}
+			if hasFlag(flags, senzing.SzEntityIncludeRelatedRecordData) {
+				for _, member := range relatedEntity.records {
+					relatedDocument.Records = append(relatedDocument.Records, newRecordDocument(member, senzing.SzEntityIncludeRecordData))
+				}
+			}
+			relatedEntities = append(relatedEntities, relatedDocument)
+		}
+		result.RelatedEntities = &relatedEntities
+	}
+	return result
+}
+
+func (repository *repository) marshalEntity(focus *entity, flags int64) string {
+	return string(mustMarshal(repository.newEntityDocument(focus, flags)))
+}
+
+// Build entity documents for a list of entities, as used in the "ENTITIES" lists of path, network and why results.
+func (repository *repository) newEntityDocuments(entities []*entity, flags int64) []entityDocument {
+	result := make([]entityDocument, 0, len(entities))
+	for _, member := range entities {
+		result = append(result, repository.newEntityDocument(member, flags))
+	}
+	return result
+}
+
+func newResolvedEntityDocument(focus *entity, flags int64) resolvedEntityDocument {
+	result := resolvedEntityDocument{EntityID: focus.id}
+	if hasFlag(flags, senzing.SzEntityIncludeEntityName) {
+		result.EntityName = focus.name()
+	}
+	if hasFlag(flags, senzing.SzEntityIncludeAllFeatures|senzing.SzEntityIncludeRepresentativeFeatures) {
+		result.Features = map[string][]featureDocument{}
+		seen := map[int64]bool{}
+		for _, member := range focus.records {
+			for _, recordFeature := range member.features {
+				if seen[recordFeature.feature.id] {
+					continue
+				}
+				seen[recordFeature.feature.id] = true
+				featureType := recordFeature.feature.featureType
+				result.Features[featureType] = append(result.Features[featureType], featureDocument{
+					FeatureDescription: recordFeature.feature.description,
+					LibFeatID:          recordFeature.feature.id,
+					UsageType:          recordFeature.usageType,
+				})
+			}
+		}
+	}
+	if hasFlag(flags, senzing.SzEntityIncludeRecordSummary) {
+		result.RecordSummary = newRecordSummary(focus.records)
+	}
+	if hasFlag(flags, recordFlags) {
+		for _, member := range focus.records {
+			result.Records = append(result.Records, newRecordDocument(member, flags))
+		}
+	}
+	return result
+}
+
+// The SZ_ENTITY_INCLUDE_*_RELATIONS flag that selects relationships with the match level.
+func relationFlag(matchLevel string) int64 {
+	switch matchLevel {
+	case matchLevelNameOnly:
+		return senzing.SzEntityIncludeNameOnlyRelations
+	default:
+		return senzing.SzEntityIncludePossiblyRelatedRelations
+	}
+}
@@ -0,0 +1,180 @@
+package fakeserver
+
+import (
+	"fmt"
+	"sort"
+)
+
+// The documents returned by SzEngine.HowEntityByEntityID and SzEngine.Why*.
+
+type howDocument struct {
+	HowResults howResultsDocument `json:"HOW_RESULTS"`
+}
+
+type howMatchInfoDocument struct {
+	ErruleCode string `json:"ERRULE_CODE"`
+	MatchKey   string `json:"MATCH_KEY"`
+}
+
+type howResultsDocument struct {
+	FinalState      howFinalStateDocument `json:"FINAL_STATE"`
+	ResolutionSteps []howStepDocument     `json:"RESOLUTION_STEPS"`
+}
+
+type howFinalStateDocument struct {
+	NeedReevaluation int                     `json:"NEED_REEVALUATION"`
+	VirtualEntities  []virtualEntityDocument `json:"VIRTUAL_ENTITIES"`
+}
+
+type howStepDocument struct {
+	InboundVirtualEntityID string                `json:"INBOUND_VIRTUAL_ENTITY_ID"`
+	MatchInfo              howMatchInfoDocument  `json:"MATCH_INFO"`
+	ResultVirtualEntityID  string                `json:"RESULT_VIRTUAL_ENTITY_ID"`
+	Step                   int                   `json:"STEP"`
+	VirtualEntity1         virtualEntityDocument `json:"VIRTUAL_ENTITY_1"`
+	VirtualEntity2         virtualEntityDocument `json:"VIRTUAL_ENTITY_2"`
+}
+
+type memberRecordDocument struct {
+	InternalID int64               `json:"INTERNAL_ID"`
+	Records    []recordKeyDocument `json:"RECORDS"`
+}
+
+type virtualEntityDocument struct {
+	MemberRecords   []memberRecordDocument `json:"MEMBER_RECORDS"`
+	VirtualEntityID string                 `json:"VIRTUAL_ENTITY_ID"`
+}
+
+type whyDocument struct {
+	WhyResults []whyResultDocument `json:"WHY_RESULTS"`
+	Entities   []entityDocument    `json:"ENTITIES"`
+}
+
+type whyMatchInfoDocument struct {
+	WhyKey         string `json:"WHY_KEY"`
+	WhyErruleCode  string `json:"WHY_ERRULE_CODE"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+}
+
+// The fields of a why result depend on the method.  Unused fields are omitted.
+type whyResultDocument struct {
+	InternalID    int64                `json:"INTERNAL_ID,omitempty"`
+	EntityID      int64                `json:"ENTITY_ID"`
+	FocusRecords  []recordKeyDocument  `json:"FOCUS_RECORDS,omitempty"`
+	InternalID2   int64                `json:"INTERNAL_ID_2,omitempty"`
+	EntityID2     int64                `json:"ENTITY_ID_2,omitempty"`
+	FocusRecords2 []recordKeyDocument  `json:"FOCUS_RECORDS_2,omitempty"`
+	MatchInfo     whyMatchInfoDocument `json:"MATCH_INFO"`
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newVirtualEntityDocument(virtualEntityID string, records []*record) virtualEntityDocument {
+	result := virtualEntityDocument{VirtualEntityID: virtualEntityID}
+	for _, member := range records {
+		result.MemberRecords = append(result.MemberRecords, memberRecordDocument{
+			InternalID: member.internalID,
+			Records:    newRecordKeyDocuments([]*record{member}),
+		})
+	}
+	return result
+}
+
+func newWhyMatchInfoDocument(match matchInfo) whyMatchInfoDocument {
+	return whyMatchInfoDocument{
+		WhyKey:         match.key,
+		WhyErruleCode:  match.rule,
+		MatchLevelCode: match.level,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// repository methods
+// ----------------------------------------------------------------------------
+
+/*
+Explain how an entity was built: starting from its first record,
+each step adds the next record, in order of internal ID, to the virtual entity built so far.
+*/
+func (repository *repository) howEntity(focus *entity) string {
+	records := focus.records
+	virtualEntityID := func(step int) string {
+		if step == 0 {
+			return fmt.Sprintf("V%d", records[0].internalID)
+		}
+		return fmt.Sprintf("V%d-S%d", records[0].internalID, step)
+	}
+	result := howDocument{HowResults: howResultsDocument{ResolutionSteps: []howStepDocument{}}}
+	for index := 1; index < len(records); index++ {
+		inbound := fmt.Sprintf("V%d", records[index].internalID)
+		result.HowResults.ResolutionSteps = append(result.HowResults.ResolutionSteps, howStepDocument{
+			InboundVirtualEntityID: inbound,
+			MatchInfo: howMatchInfoDocument{
+				ErruleCode: records[index].match.rule,
+				MatchKey:   records[index].match.key,
+			},
+			ResultVirtualEntityID: virtualEntityID(index),
+			Step:                  index,
+			VirtualEntity1:        newVirtualEntityDocument(virtualEntityID(index-1), records[:index]),
+			VirtualEntity2:        newVirtualEntityDocument(inbound, records[index:index+1]),
+		})
+	}
+	result.HowResults.FinalState.VirtualEntities = []virtualEntityDocument{
+		newVirtualEntityDocument(virtualEntityID(len(records)-1), records),
+	}
+	return string(mustMarshal(result))
+}
+
+// Explain how two entities are related, by their best matching records.
+func (repository *repository) whyEntities(entity1 *entity, entity2 *entity, flags int64) string {
+	match := matchInfo{}
+	for _, record1 := range entity1.records {
+		if candidate := bestMatch(record1, entity2.records); candidate.score > match.score {
+			match = candidate
+		}
+	}
+	result := whyDocument{
+		WhyResults: []whyResultDocument{{
+			EntityID:  entity1.id,
+			EntityID2: entity2.id,
+			MatchInfo: newWhyMatchInfoDocument(match),
+		}},
+		Entities: repository.newEntityDocuments(uniqueEntities([]*entity{entity1, entity2}), flags),
+	}
+	return string(mustMarshal(result))
+}
+
+// Explain why a record is in its entity, by how it matched the records before it.
+func (repository *repository) whyRecordInEntity(focus *record, flags int64) string {
+	result := whyDocument{
+		WhyResults: []whyResultDocument{{
+			InternalID:   focus.internalID,
+			EntityID:     focus.entity.id,
+			FocusRecords: newRecordKeyDocuments([]*record{focus}),
+			MatchInfo:    newWhyMatchInfoDocument(focus.match),
+		}},
+		Entities: repository.newEntityDocuments([]*entity{focus.entity}, flags),
+	}
+	return string(mustMarshal(result))
+}
+
+// Explain how two records match, whether or not they resolved.
+func (repository *repository) whyRecords(record1 *record, record2 *record, flags int64) string {
+	entities := uniqueEntities([]*entity{record1.entity, record2.entity})
+	sort.Slice(entities, func(i, j int) bool { return entities[i].id < entities[j].id })
+	result := whyDocument{
+		WhyResults: []whyResultDocument{{
+			InternalID:    record1.internalID,
+			EntityID:      record1.entity.id,
+			FocusRecords:  newRecordKeyDocuments([]*record{record1}),
+			InternalID2:   record2.internalID,
+			EntityID2:     record2.entity.id,
+			FocusRecords2: newRecordKeyDocuments([]*record{record2}),
+			MatchInfo:     newWhyMatchInfoDocument(compareFeatures(record1.features, record2.features)),
+		}},
+		Entities: repository.newEntityDocuments(entities, flags),
+	}
+	return string(mustMarshal(result))
+}
@@ -0,0 +1,152 @@
+package fakeserver
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// The columns that SzEngine.ExportCsvEntityReport can include.
+var csvColumns = []string{
+	"RESOLVED_ENTITY_ID",
+	"RESOLVED_ENTITY_NAME",
+	"RELATED_ENTITY_ID",
+	"MATCH_LEVEL_CODE",
+	"MATCH_KEY",
+	"ERRULE_CODE",
+	"DATA_SOURCE",
+	"RECORD_ID",
+	"JSON_DATA",
+}
+
+// The export flags that select entities, by the match level of their relationships.
+var exportRelationFlags = map[string]int64{
+	matchLevelNameOnly:        senzing.SzExportIncludeNameOnly,
+	matchLevelPossiblyRelated: senzing.SzExportIncludePossiblyRelated,
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Quote a CSV value as the Senzing CSV export does.
+func csvQuote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+// Parse a CSV column list.  An empty list selects the default columns.
+func parseCsvColumnList(csvColumnList string) ([]string, error) {
+	if len(strings.TrimSpace(csvColumnList)) == 0 {
+		csvColumnList = defaultCsvColumnList
+	}
+	result := []string{}
+	for _, column := range strings.Split(csvColumnList, ",") {
+		column = strings.ToUpper(strings.TrimSpace(column))
+		if !slices.Contains(csvColumns, column) {
+			return nil, newError(reasonCsvColumn, column)
+		}
+		result = append(result, column)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// repository methods
+// ----------------------------------------------------------------------------
+
+// Export entities as CSV: a header line, then a line for each record of each exported entity and its related entities.
+func (repository *repository) exportCsv(csvColumnList string, flags int64) ([]string, error) {
+	columns, err := parseCsvColumnList(csvColumnList)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{strings.Join(columns, ",")}
+	for _, member := range repository.exportedEntities(flags) {
+		for _, memberRecord := range member.records {
+			result = append(result, csvLine(columns, member, 0, memberRecord.match, memberRecord))
+		}
+		if !hasFlag(flags, senzing.SzEntityIncludeAllRelations) {
+			continue
+		}
+		related, matches := repository.relatedEntities(member)
+		for _, relatedEntity := range related {
+			if !hasFlag(flags, relationFlag(matches[relatedEntity].level)) {
+				continue
+			}
+			for _, relatedRecord := range relatedEntity.records {
+				result = append(result, csvLine(columns, member, relatedEntity.id, matches[relatedEntity], relatedRecord))
+			}
+		}
+	}
+	return result, nil
+}
+
+// The entities selected by SZ_EXPORT_INCLUDE_* flags, in order of entity ID.
+func (repository *repository) exportedEntities(flags int64) []*entity {
+	result := []*entity{}
+	for _, member := range repository.entities {
+		include := hasFlag(flags, senzing.SzExportIncludeMultiRecordEntities) && len(member.records) > 1 ||
+			hasFlag(flags, senzing.SzExportIncludeSingleRecordEntities) && len(member.records) == 1
+		if !include && hasFlag(flags, senzing.SzExportIncludeNameOnly|senzing.SzExportIncludePossiblyRelated) {
+			_, matches := repository.relatedEntities(member)
+			for _, match := range matches {
+				include = include || hasFlag(flags, exportRelationFlags[match.level])
+			}
+		}
+		if include {
+			result = append(result, member)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].id < result[j].id })
+	return result
+}
+
+// Export entities as JSON, an entity document per line.
+func (repository *repository) exportJSON(flags int64) []string {
+	result := []string{}
+	for _, member := range repository.exportedEntities(flags) {
+		result = append(result, repository.marshalEntity(member, flags))
+	}
+	return result
+}
+
+// Keep exported lines to be read by SzEngine.FetchNext.  Returns the export handle.
+func (repository *repository) newExport(lines []string) int64 {
+	result := repository.newHandle()
+	repository.exports[result] = &export{lines: lines}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func csvLine(columns []string, member *entity, relatedEntityID int64, match matchInfo, memberRecord *record) string {
+	values := make([]string, 0, len(columns))
+	for _, column := range columns {
+		switch column {
+		case "RESOLVED_ENTITY_ID":
+			values = append(values, fmt.Sprint(member.id))
+		case "RESOLVED_ENTITY_NAME":
+			values = append(values, csvQuote(member.name()))
+		case "RELATED_ENTITY_ID":
+			values = append(values, fmt.Sprint(relatedEntityID))
+		case "MATCH_LEVEL_CODE":
+			values = append(values, csvQuote(match.level))
+		case "MATCH_KEY":
+			values = append(values, csvQuote(match.key))
+		case "ERRULE_CODE":
+			values = append(values, csvQuote(match.rule))
+		case "DATA_SOURCE":
+			values = append(values, csvQuote(memberRecord.dataSource))
+		case "RECORD_ID":
+			values = append(values, csvQuote(memberRecord.recordID))
+		case "JSON_DATA":
+			values = append(values, csvQuote(string(memberRecord.definition)))
+		}
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,198 @@
+package fakeserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	szconfigpb "github.com/senzing-garage/sz-sdk-proto/go/szconfig"
+	szconfigmanagerpb "github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager"
+	szdiagnosticpb "github.com/senzing-garage/sz-sdk-proto/go/szdiagnostic"
+	szenginepb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var (
+	sharedErr    error
+	sharedOnce   sync.Once
+	sharedServer *Server
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function starts a Server with a default configuration registered.
+The default configuration has the TEST and SEARCH data sources, plus those given by WithDataSources().
+
+Input
+  - options: Options that add data sources, faults and gRPC server options.
+
+Output
+  - A running Server.  Close it to stop serving.
+*/
+func New(options ...Option) (*Server, error) {
+	serverOptions := &serverOptions{}
+	for _, option := range options {
+		option(serverOptions)
+	}
+	repository, err := newRepository(serverOptions.dataSources)
+	if err != nil {
+		return nil, err
+	}
+	faults := &faultInjector{}
+	faults.set(serverOptions.faults)
+	grpcServerOptions := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(faults.unaryInterceptor),
+		grpc.ChainStreamInterceptor(faults.streamInterceptor),
+	}, serverOptions.serverOptions...)
+	result := &Server{
+		faults:     faults,
+		grpcServer: grpc.NewServer(grpcServerOptions...),
+		listener:   bufconn.Listen(bufferSize),
+		repository: repository,
+	}
+	szconfigpb.RegisterSzConfigServer(result.grpcServer, &szConfigServer{repository: repository})
+	szconfigmanagerpb.RegisterSzConfigManagerServer(result.grpcServer, &szConfigManagerServer{repository: repository})
+	szdiagnosticpb.RegisterSzDiagnosticServer(result.grpcServer, &szDiagnosticServer{repository: repository})
+	szenginepb.RegisterSzEngineServer(result.grpcServer, &szEngineServer{repository: repository})
+	szproductpb.RegisterSzProductServer(result.grpcServer, &szProductServer{})
+	go func() { _ = result.grpcServer.Serve(result.listener) }()
+	return result, nil
+}
+
+/*
+The Selected function reports whether EnvironmentVariable selects the fake server,
+that is whether test suites should use Shared() instead of a Senzing gRPC server.
+*/
+func Selected() bool {
+	return helper.GetEnv(EnvironmentVariable, "") == EnvironmentValue
+}
+
+/*
+The Shared function returns a Server shared by the whole process, starting it on first use.
+The shared Server is never closed.
+*/
+func Shared() (*Server, error) {
+	sharedOnce.Do(func() {
+		sharedServer, sharedErr = New()
+	})
+	return sharedServer, sharedErr
+}
+
+/*
+The NewSharedClient function creates a gRPC connection to the Server returned by Shared().
+
+Input
+  - dialOptions: Options passed to grpc.NewClient() after those from DialOptions().
+
+Output
+  - A connection for the clients in this module.  The caller closes it.
+*/
+func NewSharedClient(dialOptions ...grpc.DialOption) (*grpc.ClientConn, error) {
+	server, err := Shared()
+	if err != nil {
+		return nil, err
+	}
+	return server.NewClient(dialOptions...)
+}
+
+/*
+The NewTestClient function starts a Server for a test and creates a gRPC connection to it.
+Both are closed when the test finishes; the test fails at once if either cannot be created.
+It returns a connection rather than a client, because the packages of the clients test with this package.
+
+Input
+  - test: The test or benchmark.
+  - options: Options passed to New().
+
+Output
+  - The running Server, for faults, and a connection for the clients in this module, for example szengine.Szengine.
+*/
+func NewTestClient(test testing.TB, options ...Option) (*Server, *grpc.ClientConn) {
+	test.Helper()
+	server, err := New(options...)
+	if err != nil {
+		test.Fatal(err)
+	}
+	test.Cleanup(server.Close)
+	grpcConnection, err := server.NewClient()
+	if err != nil {
+		test.Fatal(err)
+	}
+	test.Cleanup(func() { _ = grpcConnection.Close() })
+	return server, grpcConnection
+}
+
+/*
+The WithDataSources function adds data sources to the default configuration registered by New().
+
+Input
+  - dataSourceCodes: The data source codes, for example "CUSTOMERS".
+*/
+func WithDataSources(dataSourceCodes ...string) Option {
+	return func(options *serverOptions) {
+		options.dataSources = append(options.dataSources, dataSourceCodes...)
+	}
+}
+
+/*
+The WithServerOptions function appends options used to create the grpc.Server,
+for example interceptors that record calls.
+Faults from WithFaults() are injected before these interceptors run.
+
+Input
+  - grpcServerOptions: Options passed to grpc.NewServer().
+*/
+func WithServerOptions(grpcServerOptions ...grpc.ServerOption) Option {
+	return func(options *serverOptions) {
+		options.serverOptions = append(options.serverOptions, grpcServerOptions...)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Server methods
+// ----------------------------------------------------------------------------
+
+// The Close method stops the Server, closing open connections, and discards its data.
+func (server *Server) Close() {
+	server.grpcServer.Stop()
+}
+
+/*
+The DialOptions method returns the options that connect a gRPC client to the Server at Address.
+*/
+func (server *Server) DialOptions() []grpc.DialOption {
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return server.listener.DialContext(ctx)
+	}
+	return []grpc.DialOption{
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+}
+
+/*
+The NewClient method creates a gRPC connection to the Server.
+
+Input
+  - dialOptions: Options passed to grpc.NewClient() after those from DialOptions().
+
+Output
+  - A connection for the clients in this module, for example szengine.Szengine.  The caller closes it.
+*/
+func (server *Server) NewClient(dialOptions ...grpc.DialOption) (*grpc.ClientConn, error) {
+	result, err := grpc.NewClient(Address, append(server.DialOptions(), dialOptions...)...)
+	if err != nil {
+		return nil, fmt.Errorf("grpc.NewClient(%s) error: %w", Address, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szproduct"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNew() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/fakeserver/fakeserver_examples_test.go
+	ctx := context.TODO()
+	server, err := New(WithDataSources("CUSTOMERS"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	grpcConnection, err := server.NewClient()
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = grpcConnection.Close() }()
+	szEngine := &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+	result, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAME_FULL": "Robert Smith", "PHONE_NUMBER": "702-919-1300"}`, senzing.SzWithInfo)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(result)
+	// Output: {"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}
+}
+
+func ExampleWithFaults() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/fakeserver/fakeserver_examples_test.go
+	ctx := context.TODO()
+	server, err := New(WithFaults(
+		Unavailable("/szproduct.SzProduct/GetVersion", 1, 2),
+		DropStream("/szengine.SzEngine/StreamExportJsonEntityReport", 100),
+	))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	grpcConnection, err := server.NewClient()
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = grpcConnection.Close() }()
+	szProduct := &szproduct.Szproduct{GrpcClient: szproductpb.NewSzProductClient(grpcConnection)}
+	for range 3 {
+		_, err = szProduct.GetVersion(ctx)
+		fmt.Println(errors.Is(err, szerror.ErrSzRetryable))
+	}
+	// Output:
+	// true
+	// true
+	// false
+}
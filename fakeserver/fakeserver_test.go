@@ -0,0 +1,172 @@
+package fakeserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigmanager"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szproduct"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	szconfigmanagerpb "github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager"
+	szenginepb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+var testRecords = map[string]string{
+	"1001": `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "RECORD_TYPE": "PERSON", "PRIMARY_NAME_LAST": "Smith", "PRIMARY_NAME_FIRST": "Robert", "DATE_OF_BIRTH": "12/11/1978", "ADDR_TYPE": "MAILING", "ADDR_LINE1": "123 Main Street, Las Vegas NV 89132", "PHONE_TYPE": "HOME", "PHONE_NUMBER": "702-919-1300", "EMAIL_ADDRESS": "bsmith@work.com"}`,
+	"1002": `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002", "RECORD_TYPE": "PERSON", "PRIMARY_NAME_LAST": "Smith", "PRIMARY_NAME_FIRST": "Bob", "DATE_OF_BIRTH": "11/12/1978", "ADDR_TYPE": "HOME", "ADDR_LINE1": "1515 Adela Lane", "ADDR_CITY": "Las Vegas", "ADDR_STATE": "NV", "ADDR_POSTAL_CODE": "89111", "PHONE_TYPE": "MOBILE", "PHONE_NUMBER": "702-919-1300"}`,
+	"1003": `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1003", "RECORD_TYPE": "PERSON", "PRIMARY_NAME_LAST": "Jones", "PRIMARY_NAME_FIRST": "Mary", "DATE_OF_BIRTH": "1/2/1980", "EMAIL_ADDRESS": "bsmith@work.com"}`,
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestFakeserver_New(test *testing.T) {
+	ctx := context.TODO()
+	server, grpcConnection := getTestServer(test)
+	szProduct := &szproduct.Szproduct{GrpcClient: szproductpb.NewSzProductClient(grpcConnection)}
+	actual, err := szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"PRODUCT_NAME":"Senzing API"`)
+	server.Close()
+	_, err = szProduct.GetVersion(ctx)
+	require.Error(test, err)
+}
+
+func TestFakeserver_Selected(test *testing.T) {
+	test.Setenv(EnvironmentVariable, EnvironmentValue)
+	assert.True(test, Selected())
+	test.Setenv(EnvironmentVariable, "")
+	assert.False(test, Selected())
+}
+
+func TestFakeserver_Shared(test *testing.T) {
+	server1, err := Shared()
+	require.NoError(test, err)
+	server2, err := Shared()
+	require.NoError(test, err)
+	assert.Same(test, server1, server2)
+}
+
+func TestFakeserver_WithServerOptions(test *testing.T) {
+	ctx := context.TODO()
+	calls := []string{}
+	interceptor := func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		calls = append(calls, info.FullMethod)
+		return handler(ctx, request)
+	}
+	_, grpcConnection := getTestServer(test, WithServerOptions(grpc.UnaryInterceptor(interceptor)))
+	szProduct := &szproduct.Szproduct{GrpcClient: szproductpb.NewSzProductClient(grpcConnection)}
+	_, err := szProduct.GetLicense(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"/szproduct.SzProduct/GetLicense"}, calls)
+}
+
+func TestSzengine_AddRecord_resolves(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	addRecords(ctx, test, szEngine, "1001", "1002", "1003")
+	entity1001, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzEntityIncludeRecordSummary)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"RESOLVED_ENTITY":{"ENTITY_ID":1,"RECORD_SUMMARY":[{"DATA_SOURCE":"CUSTOMERS","RECORD_COUNT":2}]}}`, entity1001)
+	entity1003, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1003", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"RESOLVED_ENTITY":{"ENTITY_ID":2}}`, entity1003)
+}
+
+func TestSzengine_AddRecord_withInfo(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	addRecords(ctx, test, szEngine, "1001")
+	actual, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1002", testRecords["1002"], senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1002","AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+}
+
+func TestSzengine_AddRecord_badDataSourceCode(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	_, err := szEngine.AddRecord(ctx, "BOB", "1001", testRecords["1001"], senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestSzengine_DeleteRecord_redo(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	addRecords(ctx, test, szEngine, "1001", "1002")
+	_, err := szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	count, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), count)
+	redoRecord, err := szEngine.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	_, err = szEngine.ProcessRedoRecord(ctx, redoRecord, senzing.SzNoFlags)
+	require.NoError(test, err)
+	redoRecord, err = szEngine.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	assert.Empty(test, redoRecord)
+	entity1002, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1002", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"RESOLVED_ENTITY":{"ENTITY_ID":2}}`, entity1002)
+}
+
+func TestSzengine_ExportCsvEntityReport(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	addRecords(ctx, test, szEngine, "1001")
+	actual := []string{}
+	for line := range szEngine.ExportCsvEntityReportIterator(ctx, "RESOLVED_ENTITY_ID,DATA_SOURCE,RECORD_ID", senzing.SzExportDefaultFlags) {
+		require.NoError(test, line.Error)
+		actual = append(actual, line.Value)
+	}
+	assert.Equal(test, []string{"RESOLVED_ENTITY_ID,DATA_SOURCE,RECORD_ID\n", `1,"CUSTOMERS","1001"` + "\n"}, actual)
+}
+
+func TestSzengine_GetEntityByEntityID_badEntityID(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	_, err := szEngine.GetEntityByEntityID(ctx, -1, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestSzconfigmanager_SetDefaultConfigID_badConfigID(test *testing.T) {
+	ctx := context.TODO()
+	_, grpcConnection := getTestServer(test)
+	szConfigManager := &szconfigmanager.Szconfigmanager{GrpcClient: szconfigmanagerpb.NewSzConfigManagerClient(grpcConnection)}
+	configID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	err = szConfigManager.SetDefaultConfigID(ctx, configID+1)
+	require.Error(test, err)
+	err = szConfigManager.ReplaceDefaultConfigID(ctx, configID+1, configID)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getTestEngine(test *testing.T) *szengine.Szengine {
+	test.Helper()
+	_, grpcConnection := getTestServer(test)
+	return &szengine.Szengine{GrpcClient: szenginepb.NewSzEngineClient(grpcConnection)}
+}
+
+func getTestServer(test *testing.T, options ...Option) (*Server, *grpc.ClientConn) {
+	test.Helper()
+	return NewTestClient(test, append([]Option{WithDataSources("CUSTOMERS")}, options...)...)
+}
+
+func addRecords(ctx context.Context, test *testing.T, szEngine *szengine.Szengine, recordIDs ...string) {
+	test.Helper()
+	for _, recordID := range recordIDs {
+		_, err := szEngine.AddRecord(ctx, "CUSTOMERS", recordID, testRecords[recordID], senzing.SzNoFlags)
+		require.NoError(test, err)
+	}
+}
@@ -0,0 +1,264 @@
+package fakeserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The faults of a Server and the number of calls of each method.
+type faultInjector struct {
+	sync.Mutex
+	calls  map[string]int
+	faults []Fault
+}
+
+// The faults that apply to one call, combined.
+type injection struct {
+	delay      time.Duration
+	err        error
+	dropAfter  int
+	truncateTo int
+}
+
+// A server stream that truncates responses and fails after a number of messages.
+type faultStream struct {
+	grpc.ServerStream
+	dropped   bool
+	injection injection
+	sent      int
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The DropStream function returns a Fault that fails a server stream of the method with codes.Unavailable
+after it has sent some messages, as when a connection is lost during an export.
+
+Input
+  - method: The full gRPC method name, such as "/szengine.SzEngine/StreamExportJsonEntityReport".
+  - after: The number of messages sent before the stream fails.
+*/
+func DropStream(method string, after int) Fault {
+	return Fault{Method: method, DropAfter: after}
+}
+
+/*
+The Latency function returns a Fault that delays every call of the method.
+
+Input
+  - method: The full gRPC method name.  Empty matches every method.
+  - delay: The latency added to each call.
+*/
+func Latency(method string, delay time.Duration) Fault {
+	return Fault{Method: method, Delay: delay}
+}
+
+/*
+The MalformedError function returns a Fault that fails every call of the method
+with a status message that is not a well-formed Senzing error description,
+for example truncated JSON or a reason without a SENZnnnnE code.
+
+Input
+  - method: The full gRPC method name.  Empty matches every method.
+  - code: The gRPC status code.
+  - message: The status message, sent as is.
+*/
+func MalformedError(method string, code codes.Code, message string) Fault {
+	return Fault{Method: method, Err: status.Error(code, message)}
+}
+
+/*
+The Truncate function returns a Fault that cuts the string results of every call of the method.
+
+Input
+  - method: The full gRPC method name.  Empty matches every method.
+  - size: The number of bytes kept.
+*/
+func Truncate(method string, size int) Fault {
+	return Fault{Method: method, TruncateTo: size}
+}
+
+/*
+The Unavailable function returns a Fault that fails a burst of consecutive calls of the method
+with codes.Unavailable, as when a server restarts.
+
+Input
+  - method: The full gRPC method name.  Empty matches every method.
+  - from: The first call that fails, counting from 1.
+  - count: The number of calls that fail.
+*/
+func Unavailable(method string, from int, count int) Fault {
+	return Fault{Method: method, From: from, Count: count, Err: status.Error(codes.Unavailable, "fakeserver: injected unavailable")}
+}
+
+/*
+The WithFaults function adds faults to a Server created by New().
+Calls are counted per method from the start of the Server, or from the last Server.SetFaults().
+
+Input
+  - faults: The faults to inject.
+*/
+func WithFaults(faults ...Fault) Option {
+	return func(options *serverOptions) {
+		options.faults = append(options.faults, faults...)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Server methods
+// ----------------------------------------------------------------------------
+
+/*
+The SetFaults method replaces the faults of the Server and restarts the count of calls of each method.
+Calls in progress keep the faults they started with.
+
+Input
+  - faults: The faults to inject.  None removes every fault.
+*/
+func (server *Server) SetFaults(faults ...Fault) {
+	server.faults.set(faults)
+}
+
+// ----------------------------------------------------------------------------
+// faultInjector methods
+// ----------------------------------------------------------------------------
+
+// Count a call of the method and combine the faults that apply to it.
+func (faults *faultInjector) next(method string) injection {
+	faults.Lock()
+	defer faults.Unlock()
+	faults.calls[method]++
+	call := faults.calls[method]
+	result := injection{}
+	for _, fault := range faults.faults {
+		if !fault.matches(method, call) {
+			continue
+		}
+		result.delay += fault.Delay
+		if result.err == nil {
+			result.err = fault.Err
+		}
+		result.dropAfter = minPositive(result.dropAfter, fault.DropAfter)
+		result.truncateTo = minPositive(result.truncateTo, fault.TruncateTo)
+	}
+	return result
+}
+
+func (faults *faultInjector) set(faultList []Fault) {
+	faults.Lock()
+	defer faults.Unlock()
+	faults.calls = map[string]int{}
+	faults.faults = append([]Fault{}, faultList...)
+}
+
+func (faults *faultInjector) streamInterceptor(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	injection := faults.next(info.FullMethod)
+	if err := injection.wait(stream.Context()); err != nil {
+		return err
+	}
+	wrapped := &faultStream{ServerStream: stream, injection: injection}
+	err := handler(server, wrapped)
+	if wrapped.dropped {
+		return status.Error(codes.Unavailable, "fakeserver: injected stream drop")
+	}
+	return err
+}
+
+func (faults *faultInjector) unaryInterceptor(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	injection := faults.next(info.FullMethod)
+	if err := injection.wait(ctx); err != nil {
+		return nil, err
+	}
+	response, err := handler(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	injection.truncate(response)
+	return response, nil
+}
+
+// ----------------------------------------------------------------------------
+// faultStream methods
+// ----------------------------------------------------------------------------
+
+func (stream *faultStream) SendMsg(message any) error {
+	if stream.injection.dropAfter > 0 && stream.sent >= stream.injection.dropAfter {
+		stream.dropped = true
+		return status.Error(codes.Unavailable, "fakeserver: injected stream drop")
+	}
+	stream.injection.truncate(message)
+	stream.sent++
+	return stream.ServerStream.SendMsg(message)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (fault Fault) matches(method string, call int) bool {
+	if len(fault.Method) > 0 && fault.Method != method {
+		return false
+	}
+	from := max(fault.From, 1)
+	if call < from {
+		return false
+	}
+	return fault.Count == 0 || call < from+fault.Count
+}
+
+// Cut the string fields of a response message.
+func (injection injection) truncate(message any) {
+	if injection.truncateTo <= 0 {
+		return
+	}
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return
+	}
+	reflection := protoMessage.ProtoReflect()
+	reflection.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.Kind() == protoreflect.StringKind && !field.IsList() && !field.IsMap() && len(value.String()) > injection.truncateTo {
+			reflection.Set(field, protoreflect.ValueOfString(value.String()[:injection.truncateTo]))
+		}
+		return true
+	})
+}
+
+// Wait for the injected latency, then return the injected error, if any.
+func (injection injection) wait(ctx context.Context) error {
+	if injection.delay > 0 {
+		timer := time.NewTimer(injection.delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+	}
+	return injection.err
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// The smaller of two limits, where zero or less means no limit.
+func minPositive(limit1 int, limit2 int) int {
+	switch {
+	case limit1 <= 0:
+		return limit2
+	case limit2 <= 0:
+		return limit1
+	default:
+		return min(limit1, limit2)
+	}
+}
@@ -0,0 +1,142 @@
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szproduct"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	szenginepb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	getVersionMethod   = "/szproduct.SzProduct/GetVersion"
+	exportJSONMethod   = "/szengine.SzEngine/StreamExportJsonEntityReport"
+	testLatency        = 50 * time.Millisecond
+	truncatedSize      = 10
+	unavailableMessage = "fakeserver: injected unavailable"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestFault_DropStream(test *testing.T) {
+	ctx := context.TODO()
+	server, grpcConnection := getTestServer(test)
+	szEngine := &szengine.Szengine{GrpcClient: szenginepb.NewSzEngineClient(grpcConnection)}
+	addRecords(ctx, test, szEngine, "1001", "1003")
+	server.SetFaults(DropStream(exportJSONMethod, 1))
+	lines := 0
+	var err error
+	for line := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzExportDefaultFlags) {
+		if line.Error != nil {
+			err = line.Error
+			break
+		}
+		lines++
+	}
+	assert.Equal(test, 1, lines)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	assert.Equal(test, codes.Unavailable, status.Code(err))
+}
+
+func TestFault_Latency(test *testing.T) {
+	szProduct := getTestProduct(test, WithFaults(Latency(getVersionMethod, testLatency)))
+	start := time.Now()
+	_, err := szProduct.GetVersion(context.TODO())
+	require.NoError(test, err)
+	assert.GreaterOrEqual(test, time.Since(start), testLatency)
+	ctx, cancel := context.WithTimeout(context.TODO(), testLatency/5)
+	defer cancel()
+	_, err = szProduct.GetVersion(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzRetryTimeoutExceeded)
+}
+
+func TestFault_MalformedError(test *testing.T) {
+	ctx := context.TODO()
+	for _, message := range []string{`{"reason":"SENZ0023E|Conflicting`, `{"reason":"Conflicting DATA_SOURCE values"}`, "not JSON"} {
+		szProduct := getTestProduct(test, WithFaults(MalformedError(getVersionMethod, codes.Unknown, message)))
+		_, err := szProduct.GetVersion(ctx)
+		require.Error(test, err)
+		szGrpcError := &helper.SzGrpcError{}
+		require.True(test, errors.As(err, &szGrpcError), message)
+		assert.Equal(test, codes.Unknown, szGrpcError.GrpcCode)
+		assert.Equal(test, message, szGrpcError.Message)
+		assert.Zero(test, szGrpcError.ReasonCode)
+	}
+}
+
+func TestFault_Truncate(test *testing.T) {
+	szProduct := getTestProduct(test, WithFaults(Truncate(getVersionMethod, truncatedSize)))
+	actual, err := szProduct.GetVersion(context.TODO())
+	require.NoError(test, err)
+	assert.Equal(test, version[:truncatedSize], actual)
+	actual, err = szProduct.GetLicense(context.TODO())
+	require.NoError(test, err)
+	assert.Equal(test, license, actual)
+}
+
+func TestFault_Unavailable(test *testing.T) {
+	ctx := context.TODO()
+	szProduct := getTestProduct(test, WithFaults(Unavailable(getVersionMethod, 2, 2)))
+	for call, fails := range []bool{false, true, true, false} {
+		_, err := szProduct.GetVersion(ctx)
+		if !fails {
+			require.NoError(test, err, call+1)
+			continue
+		}
+		require.ErrorIs(test, err, szerror.ErrSzRetryable, call+1)
+		assert.Contains(test, err.Error(), unavailableMessage)
+	}
+}
+
+func TestFault_combined(test *testing.T) {
+	fault := Unavailable("", 1, 1)
+	fault.Delay = testLatency
+	szProduct := getTestProduct(test, WithFaults(fault))
+	start := time.Now()
+	_, err := szProduct.GetLicense(context.TODO())
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	assert.GreaterOrEqual(test, time.Since(start), testLatency)
+	_, err = szProduct.GetVersion(context.TODO())
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	_, err = szProduct.GetVersion(context.TODO())
+	require.NoError(test, err)
+}
+
+func TestServer_SetFaults(test *testing.T) {
+	ctx := context.TODO()
+	server, grpcConnection := getTestServer(test, WithFaults(Unavailable(getVersionMethod, 1, 1)))
+	szProduct := &szproduct.Szproduct{GrpcClient: szproductpb.NewSzProductClient(grpcConnection)}
+	_, err := szProduct.GetVersion(ctx)
+	require.Error(test, err)
+	server.SetFaults(Unavailable(getVersionMethod, 2, 1))
+	_, err = szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	_, err = szProduct.GetVersion(ctx)
+	require.Error(test, err)
+	server.SetFaults()
+	_, err = szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getTestProduct(test *testing.T, options ...Option) *szproduct.Szproduct {
+	test.Helper()
+	_, grpcConnection := getTestServer(test, options...)
+	return &szproduct.Szproduct{GrpcClient: szproductpb.NewSzProductClient(grpcConnection)}
+}
@@ -0,0 +1,268 @@
+package fakeserver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A feature in the feature library, shared by every record that has it.
+type feature struct {
+	description string
+	elements    []element
+	featureType string
+	id          int64
+	key         string // The value compared when matching.  For NAME, the surname.
+}
+
+type element struct {
+	Code  string `json:"FELEM_CODE"`
+	Value string `json:"FELEM_VALUE"`
+}
+
+// A feature of one record, with the label of the attributes it came from.
+type recordFeature struct {
+	feature   *feature
+	usageType string
+}
+
+// A feature found in a record or search definition, not yet in the feature library.
+type candidateFeature struct {
+	elements    []element
+	featureType string
+	usageType   string
+}
+
+// Words shortened when comparing addresses.
+var addressAbbreviations = map[string]string{
+	"AVENUE":    "AVE",
+	"BOULEVARD": "BLVD",
+	"COURT":     "CT",
+	"DRIVE":     "DR",
+	"EAST":      "E",
+	"LANE":      "LN",
+	"NORTH":     "N",
+	"PLACE":     "PL",
+	"ROAD":      "RD",
+	"SOUTH":     "S",
+	"STREET":    "ST",
+	"WEST":      "W",
+}
+
+var monthNames = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+Find the features in a parsed JSON document.
+Attributes are grouped into one feature per JSON object, feature type and label,
+so "HOME_ADDR_LINE1" and "HOME_ADDR_CITY" make one HOME address.
+*/
+func extractFeatures(document map[string]any) []candidateFeature {
+	result := []candidateFeature{}
+	var walk func(object map[string]any)
+	walk = func(object map[string]any) {
+		groups := map[string]*candidateFeature{}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			switch value := object[key].(type) {
+			case map[string]any:
+				walk(value)
+			case []any:
+				for _, item := range value {
+					if nested, ok := item.(map[string]any); ok {
+						walk(nested)
+					}
+				}
+			default:
+				text := strings.TrimSpace(stringValue(value))
+				featureType, attribute, label := classifyAttribute(key)
+				if len(featureType) == 0 || len(text) == 0 {
+					continue
+				}
+				groupKey := featureType + "|" + label
+				group, ok := groups[groupKey]
+				if !ok {
+					group = &candidateFeature{featureType: featureType, usageType: strings.TrimSuffix(label, "_")}
+					groups[groupKey] = group
+				}
+				group.elements = append(group.elements, element{Code: attribute, Value: text})
+			}
+		}
+		for _, group := range groups {
+			sortElements(group)
+			result = append(result, *group)
+		}
+	}
+	walk(document)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].featureType != result[j].featureType {
+			return featureOrder(result[i].featureType) < featureOrder(result[j].featureType)
+		}
+		return result[i].usageType < result[j].usageType
+	})
+	return result
+}
+
+// Find the feature type of an attribute, such as "PRIMARY_NAME_LAST", and return the feature type, attribute and label.
+func classifyAttribute(key string) (string, string, string) {
+	upperKey := strings.ToUpper(key)
+	for _, featureType := range featureTypes {
+		for _, attribute := range featureAttributes[featureType] {
+			label, found := strings.CutSuffix(upperKey, attribute)
+			if found && (len(label) == 0 || strings.HasSuffix(label, "_")) {
+				return featureType, attribute, label
+			}
+		}
+	}
+	return "", "", ""
+}
+
+func featureOrder(featureType string) int {
+	for index, candidate := range featureTypes {
+		if candidate == featureType {
+			return index
+		}
+	}
+	return len(featureTypes)
+}
+
+// Describe and compare a candidate feature.  The description identifies the feature in the feature library.
+func (candidate candidateFeature) normalize() (string, string) {
+	values := map[string]string{}
+	for _, element := range candidate.elements {
+		values[element.Code] = element.Value
+	}
+	descriptions := make([]string, 0, len(candidate.elements))
+	for _, element := range candidate.elements {
+		descriptions = append(descriptions, element.Value)
+	}
+	description := strings.Join(descriptions, " ")
+	switch candidate.featureType {
+	case featureName:
+		if organization, ok := values["NAME_ORG"]; ok {
+			return organization, normalizeWords(organization)
+		}
+		surname := normalizeWords(values["NAME_LAST"])
+		if len(surname) == 0 {
+			words := strings.Fields(normalizeWords(values["NAME_FULL"]))
+			if len(words) > 0 {
+				surname = words[len(words)-1]
+			}
+		}
+		return description, surname
+	case featureDOB:
+		return description, normalizeDate(description)
+	case featureAddress:
+		street := values["ADDR_LINE1"]
+		if len(street) == 0 {
+			street = values["ADDR_FULL"]
+		}
+		street, _, _ = strings.Cut(street, ",")
+		words := strings.Fields(normalizeWords(street))
+		for index, word := range words {
+			if abbreviation, ok := addressAbbreviations[word]; ok {
+				words[index] = abbreviation
+			}
+		}
+		return strings.Join(descriptions, ", "), strings.Join(words, " ")
+	case featurePhone:
+		digits := onlyDigits(description)
+		return description, digits[max(len(digits)-10, 0):]
+	case featureSSN:
+		return description, onlyDigits(description)
+	case featureDrLic:
+		return description, normalizeWords(values["DRIVERS_LICENSE_NUMBER"])
+	case featurePassport:
+		return description, normalizeWords(values["PASSPORT_NUMBER"])
+	case featureEmail:
+		return description, strings.ToLower(description)
+	}
+	return description, normalizeWords(description)
+}
+
+/*
+Reduce a date to its year and its two other numbers in ascending order,
+so that "12/11/1978", "11/12/1978" and "Dec 11 1978" compare equal.
+*/
+func normalizeDate(date string) string {
+	upperDate := strings.ToUpper(date)
+	for index, monthName := range monthNames {
+		upperDate = strings.ReplaceAll(upperDate, monthName, fmt.Sprintf(" %d ", index+1))
+	}
+	numbers := strings.FieldsFunc(upperDate, func(character rune) bool { return character < '0' || character > '9' })
+	year := ""
+	others := []int{}
+	for _, number := range numbers {
+		value, err := strconv.Atoi(number)
+		if err != nil {
+			continue
+		}
+		if len(number) == 4 && len(year) == 0 {
+			year = number
+			continue
+		}
+		others = append(others, value)
+	}
+	if len(year) == 0 && len(others) == 3 {
+		year = strconv.Itoa(1900 + others[2])
+		others = others[:2]
+	}
+	sort.Ints(others)
+	parts := []string{year}
+	for _, other := range others {
+		parts = append(parts, strconv.Itoa(other))
+	}
+	return strings.Join(parts, "|")
+}
+
+func normalizeWords(text string) string {
+	words := strings.FieldsFunc(strings.ToUpper(text), func(character rune) bool {
+		return !(character >= 'A' && character <= 'Z' || character >= '0' && character <= '9')
+	})
+	return strings.Join(words, " ")
+}
+
+func onlyDigits(text string) string {
+	return strings.Map(func(character rune) rune {
+		if character >= '0' && character <= '9' {
+			return character
+		}
+		return -1
+	}, text)
+}
+
+// Order the elements of a feature as the attributes are listed in featureAttributes.
+func sortElements(candidate *candidateFeature) {
+	attributes := featureAttributes[candidate.featureType]
+	position := func(code string) int {
+		for index, attribute := range attributes {
+			if attribute == code {
+				return index
+			}
+		}
+		return len(attributes)
+	}
+	sort.SliceStable(candidate.elements, func(i, j int) bool {
+		return position(candidate.elements[i].Code) < position(candidate.elements[j].Code)
+	})
+}
+
+func stringValue(value any) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typedValue)
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package fakeserver
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Fault describes a failure injected into calls of a gRPC method.
+The zero value of each field injects nothing, so fields combine:
+for example Delay and Err together make a call fail slowly.
+*/
+type Fault struct {
+	Method     string        // The full gRPC method name, such as "/szengine.SzEngine/AddRecord".  Empty matches every method.
+	From       int           // The first call of Method that fails, counting from 1.  Zero is the same as 1.
+	Count      int           // The number of consecutive calls that fail.  Zero means every call from From on.
+	Delay      time.Duration // Latency added before the call is handled.
+	Err        error         // If not nil, the call returns Err without being handled.  Use a status error to set the gRPC code.
+	DropAfter  int           // If positive, a server stream fails with codes.Unavailable after sending DropAfter messages.
+	TruncateTo int           // If positive, string fields of each response are cut to TruncateTo bytes.
+}
+
+// Option configures a Server created by New.
+type Option func(*serverOptions)
+
+// Server is an in-memory Senzing gRPC server listening on a bufconn listener.
+type Server struct {
+	faults     *faultInjector
+	grpcServer *grpc.Server
+	listener   *bufconn.Listener
+	repository *repository
+}
+
+// A Senzing error reported by the fake server.
+type reason struct {
+	code   codes.Code
+	format string
+	number int
+}
+
+type serverOptions struct {
+	dataSources   []string
+	faults        []Fault
+	serverOptions []grpc.ServerOption
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Address is the gRPC target of every Server.  Use it with the options from Server.DialOptions().
+const Address = "passthrough:///fakeserver"
+
+// EnvironmentVariable names the environment variable that selects the fake server in this module's test suites.
+const EnvironmentVariable = "SENZING_TOOLS_TEST_SERVER"
+
+// EnvironmentValue is the value of EnvironmentVariable that selects the fake server.
+const EnvironmentValue = "fake"
+
+// Feature types, in the order they appear in match keys.
+const (
+	featureName     = "NAME"
+	featureDOB      = "DOB"
+	featureAddress  = "ADDRESS"
+	featurePhone    = "PHONE"
+	featureSSN      = "SSN"
+	featureDrLic    = "DRLIC"
+	featurePassport = "PASSPORT"
+	featureEmail    = "EMAIL"
+)
+
+// Match levels and rule codes reported in match info.
+const (
+	matchLevelNameOnly        = "NAME_ONLY"
+	matchLevelPossiblyRelated = "POSSIBLY_RELATED"
+	matchLevelResolved        = "RESOLVED"
+	ruleNameOnly              = "FAKE_NAME_ONLY"
+	ruleRelate                = "FAKE_SHARED_FEATURE"
+	ruleResolve               = "FAKE_NAME_PLUS_ONE"
+)
+
+const (
+	bufferSize           = 1024 * 1024
+	defaultCsvColumnList = "RESOLVED_ENTITY_ID,RELATED_ENTITY_ID,MATCH_LEVEL_CODE,MATCH_KEY,DATA_SOURCE,RECORD_ID"
+	firstDataSourceID    = 1001
+	templateConfig       = `{"G2_CONFIG":{"CFG_ATTR":[{"ATTR_ID":1001,"ATTR_CODE":"DATA_SOURCE","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1003,"ATTR_CODE":"RECORD_ID","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"}],"CFG_DSRC":[{"DSRC_ID":1,"DSRC_CODE":"TEST"},{"DSRC_ID":2,"DSRC_CODE":"SEARCH"}],"CFG_FTYPE":[{"FTYPE_ID":1,"FTYPE_CODE":"NAME"},{"FTYPE_ID":2,"FTYPE_CODE":"DOB"},{"FTYPE_ID":5,"FTYPE_CODE":"ADDRESS"},{"FTYPE_ID":6,"FTYPE_CODE":"PHONE"},{"FTYPE_ID":7,"FTYPE_CODE":"SSN"},{"FTYPE_ID":8,"FTYPE_CODE":"DRLIC"},{"FTYPE_ID":9,"FTYPE_CODE":"PASSPORT"},{"FTYPE_ID":10,"FTYPE_CODE":"EMAIL"}],"CONFIG_BASE_VERSION":{"VERSION":"4.0.0","BUILD_VERSION":"4.0.0.00000","BUILD_DATE":"2024-06-29","BUILD_NUMBER":"fakeserver","COMPATIBILITY_VERSION":{"CONFIG_VERSION":"11"}}}}`
+	license              = `{"customer":"","contract":"","issueDate":"2024-06-10","licenseType":"EVAL (Solely for non-productive use)","licenseLevel":"","billing":"","expireDate":"2025-06-11","recordLimit":100000}`
+	version              = `{"PRODUCT_NAME":"Senzing API","VERSION":"4.0.0","BUILD_VERSION":"4.0.0.00000","BUILD_DATE":"2024-06-29","BUILD_NUMBER":"fakeserver","COMPATIBILITY_VERSION":{"CONFIG_VERSION":"11"},"SCHEMA_VERSION":{"ENGINE_SCHEMA_VERSION":"4.0","MINIMUM_REQUIRED_SCHEMA_VERSION":"4.0","MAXIMUM_REQUIRED_SCHEMA_VERSION":"4.99"}}`
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The Senzing reasons reported by the fake server.
+var (
+	reasonConflictingDataSource  = reason{codes.InvalidArgument, "Conflicting DATA_SOURCE values '%s' and '%s'", 23}
+	reasonConflictingRecordID    = reason{codes.InvalidArgument, "Conflicting RECORD_ID values '%s' and '%s'", 24}
+	reasonCsvColumn              = reason{codes.InvalidArgument, "Invalid column [%s] requested for CSV export.", 3131}
+	reasonBadDataSourceCode      = reason{codes.InvalidArgument, "Invalid data source code [%q]", 2}
+	reasonDataSourceExists       = reason{codes.AlreadyExists, "Data source code [%s] already exists.", 2209}
+	reasonDataSourceNotFound     = reason{codes.FailedPrecondition, "Data source code [%s] does not exist.", 2207}
+	reasonInvalidHandle          = reason{codes.InvalidArgument, "Invalid Handle", 29}
+	reasonJSONParsing            = reason{codes.InvalidArgument, "JSON Parsing Failure [%s]", 3121}
+	reasonMissingDataSource      = reason{codes.FailedPrecondition, "Observation is missing DSRC_CODE tag which is required", 2047}
+	reasonNoConfigRegistered     = reason{codes.FailedPrecondition, "No engine configuration registered in datastore.", 7220}
+	reasonUnknownConfigID        = reason{codes.NotFound, "No engine configuration registered with data ID [%d].", 7221}
+	reasonUnknownEntity          = reason{codes.NotFound, "Unknown resolved entity value '%d'", 37}
+	reasonUnknownFeature         = reason{codes.NotFound, "Unknown feature ID value '%d'", 57}
+	reasonUnknownRecord          = reason{codes.NotFound, "Unknown record: dsrc[%s], record[%s]", 33}
+	reasonUnknownSearchProfile   = reason{codes.InvalidArgument, "Unknown search profile value '%s'", 88}
+	reasonDefaultConfigIDChanged = reason{codes.FailedPrecondition, "Current configuration ID does not match specified data ID [%d].", 7245}
+)
+
+// Attributes recognized in record definitions, by feature type.
+// An attribute may have a prefix label, such as PRIMARY_NAME_LAST or HOME_ADDR_LINE1.
+var featureAttributes = map[string][]string{
+	featureName:     {"NAME_FULL", "NAME_ORG", "NAME_FIRST", "NAME_MIDDLE", "NAME_LAST"},
+	featureDOB:      {"DATE_OF_BIRTH"},
+	featureAddress:  {"ADDR_FULL", "ADDR_LINE1", "ADDR_CITY", "ADDR_STATE", "ADDR_POSTAL_CODE"},
+	featurePhone:    {"PHONE_NUMBER"},
+	featureSSN:      {"SSN_NUMBER"},
+	featureDrLic:    {"DRIVERS_LICENSE_NUMBER", "DRIVERS_LICENSE_STATE"},
+	featurePassport: {"PASSPORT_NUMBER", "PASSPORT_COUNTRY"},
+	featureEmail:    {"EMAIL_ADDRESS"},
+}
+
+// Feature types in match key order.
+var featureTypes = []string{featureName, featureDOB, featureAddress, featurePhone, featureSSN, featureDrLic, featurePassport, featureEmail}
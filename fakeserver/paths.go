@@ -0,0 +1,259 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+)
+
+// The constraints on a path between entities.
+type pathOptions struct {
+	avoid       map[int64]bool
+	maxDegrees  int64
+	required    map[string]bool // Data sources.  A path must include a record from one of them.
+	strictAvoid bool
+}
+
+// A list of entities, such as {"ENTITIES": [{"ENTITY_ID": 1}]}, or of records, such as {"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]}.
+type entityListDocument struct {
+	Entities []entityIDDocument  `json:"ENTITIES"`
+	Records  []recordKeyDocument `json:"RECORDS"`
+}
+
+type pathDocument struct {
+	StartEntityID int64   `json:"START_ENTITY_ID"`
+	EndEntityID   int64   `json:"END_ENTITY_ID"`
+	Entities      []int64 `json:"ENTITIES"`
+}
+
+type pathsDocument struct {
+	EntityPaths []pathDocument   `json:"ENTITY_PATHS"`
+	Entities    []entityDocument `json:"ENTITIES"`
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Parse a list of data source codes, such as {"DATA_SOURCES": ["CUSTOMERS"]}.  An empty string is an empty list.
+func parseDataSources(dataSources string) (map[string]bool, error) {
+	result := map[string]bool{}
+	if len(dataSources) == 0 {
+		return result, nil
+	}
+	document := struct {
+		DataSources []string `json:"DATA_SOURCES"`
+	}{}
+	if err := json.Unmarshal([]byte(dataSources), &document); err != nil {
+		return nil, newError(reasonJSONParsing, err.Error())
+	}
+	for _, dataSourceCode := range document.DataSources {
+		result[dataSourceCode] = true
+	}
+	return result, nil
+}
+
+// Parse a list of entities or records.  An empty string is an empty list.
+func parseEntityList(entityList string) (entityListDocument, error) {
+	result := entityListDocument{}
+	if len(entityList) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal([]byte(entityList), &result); err != nil {
+		return result, newError(reasonJSONParsing, err.Error())
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// repository methods
+// ----------------------------------------------------------------------------
+
+// Find the entities named in a list of entities or records.  Unknown entities and records are errors.
+func (repository *repository) entitiesInList(entityList string) ([]*entity, error) {
+	document, err := parseEntityList(entityList)
+	if err != nil {
+		return nil, err
+	}
+	result := []*entity{}
+	for _, entityID := range document.Entities {
+		member, err := repository.getEntity(entityID.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, member)
+	}
+	for _, recordKey := range document.Records {
+		member, err := repository.getRecord(recordKey.DataSource, recordKey.RecordID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, member.entity)
+	}
+	return result, nil
+}
+
+// Find the IDs of entities to avoid, named in a list of entities or records.  Unknown entities and records are ignored.
+func (repository *repository) avoidedEntities(entityList string) (map[int64]bool, error) {
+	document, err := parseEntityList(entityList)
+	if err != nil {
+		return nil, err
+	}
+	result := map[int64]bool{}
+	for _, entityID := range document.Entities {
+		result[entityID.EntityID] = true
+	}
+	for _, key := range document.Records {
+		if member, ok := repository.records[recordKey{dataSource: key.DataSource, recordID: key.RecordID}]; ok {
+			result[member.entity.id] = true
+		}
+	}
+	return result, nil
+}
+
+/*
+Find the shortest path between two entities through related entities.
+Paths that avoid entities are preferred, unless strictAvoid is set, when they are required.
+Returns nil if there is no path.
+*/
+func (repository *repository) findPath(start *entity, end *entity, options pathOptions) []*entity {
+	result := repository.searchPath(start, end, options, true)
+	if result == nil && len(options.avoid) > 0 && !options.strictAvoid {
+		result = repository.searchPath(start, end, options, false)
+	}
+	return result
+}
+
+// Find the paths between every pair of entities and add the entities within buildOutDegree of them.
+func (repository *repository) findNetwork(entities []*entity, maxDegrees int64, buildOutDegree int64, buildOutMaxEntities int64, flags int64) string {
+	entities = uniqueEntities(entities)
+	result := pathsDocument{EntityPaths: []pathDocument{}}
+	found := map[int64]*entity{}
+	for _, member := range entities {
+		found[member.id] = member
+	}
+	for i := range entities {
+		for j := i + 1; j < len(entities); j++ {
+			path := repository.findPath(entities[i], entities[j], pathOptions{maxDegrees: maxDegrees})
+			result.EntityPaths = append(result.EntityPaths, newPathDocument(entities[i], entities[j], path))
+			for _, member := range path {
+				found[member.id] = member
+			}
+		}
+	}
+	frontier := slices.Clone(entities)
+	for degree := int64(0); degree < buildOutDegree; degree++ {
+		next := []*entity{}
+		for _, member := range frontier {
+			related, _ := repository.relatedEntities(member)
+			for _, relatedEntity := range related {
+				if _, ok := found[relatedEntity.id]; ok {
+					continue
+				}
+				if buildOutMaxEntities > 0 && int64(len(found)) >= int64(len(entities))+buildOutMaxEntities {
+					break
+				}
+				found[relatedEntity.id] = relatedEntity
+				next = append(next, relatedEntity)
+			}
+		}
+		frontier = next
+	}
+	members := make([]*entity, 0, len(found))
+	for _, member := range found {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].id < members[j].id })
+	result.Entities = repository.newEntityDocuments(members, flags)
+	return string(mustMarshal(result))
+}
+
+// Build the result of FindPathBy*.
+func (repository *repository) marshalPath(start *entity, end *entity, options pathOptions, flags int64) string {
+	path := repository.findPath(start, end, options)
+	members := path
+	if members == nil {
+		members = uniqueEntities([]*entity{start, end})
+	}
+	result := pathsDocument{
+		EntityPaths: []pathDocument{newPathDocument(start, end, path)},
+		Entities:    repository.newEntityDocuments(members, flags),
+	}
+	return string(mustMarshal(result))
+}
+
+// Iterative deepening search for the shortest simple path that meets the options.
+func (repository *repository) searchPath(start *entity, end *entity, options pathOptions, avoid bool) []*entity {
+	allowed := func(member *entity) bool {
+		return member == start || member == end || !avoid || !options.avoid[member.id]
+	}
+	hasRequired := func(path []*entity) bool {
+		if len(options.required) == 0 {
+			return true
+		}
+		for _, member := range path {
+			for _, memberRecord := range member.records {
+				if options.required[memberRecord.dataSource] {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	var search func(path []*entity, depth int64) []*entity
+	search = func(path []*entity, depth int64) []*entity {
+		last := path[len(path)-1]
+		if last == end {
+			if hasRequired(path) {
+				return slices.Clone(path)
+			}
+			return nil
+		}
+		if depth == 0 {
+			return nil
+		}
+		related, _ := repository.relatedEntities(last)
+		for _, next := range related {
+			if slices.Contains(path, next) || !allowed(next) {
+				continue
+			}
+			if found := search(append(path, next), depth-1); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	for depth := int64(0); depth <= options.maxDegrees; depth++ {
+		if found := search([]*entity{start}, depth); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func newPathDocument(start *entity, end *entity, path []*entity) pathDocument {
+	result := pathDocument{
+		StartEntityID: start.id,
+		EndEntityID:   end.id,
+		Entities:      []int64{},
+	}
+	for _, member := range path {
+		result.Entities = append(result.Entities, member.id)
+	}
+	return result
+}
+
+// Remove repeated entities, keeping the first of each.
+func uniqueEntities(entities []*entity) []*entity {
+	result := []*entity{}
+	for _, member := range entities {
+		if !slices.Contains(result, member) {
+			result = append(result, member)
+		}
+	}
+	return result
+}
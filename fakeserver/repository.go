@@ -0,0 +1,552 @@
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/status"
+)
+
+// The state shared by the services of a Server.  Every service method holds the lock.
+type repository struct {
+	sync.Mutex
+	configHandles   map[int64]*configuration
+	configs         map[int64]*registeredConfig
+	defaultConfigID int64
+	entities        map[int64]*entity
+	exports         map[int64]*export
+	featureIndex    map[string]map[*record]bool // Records by feature type and key, except NAME.
+	features        map[int64]*feature
+	featuresByDesc  map[string]*feature
+	nextEntityID    int64
+	nextFeatureID   int64
+	nextHandle      int64
+	nextInternalID  int64
+	pinnedConfigID  int64 // Set by Reinitialize.  Zero follows the default configuration.
+	records         map[recordKey]*record
+	redoRecords     []string
+	statistics      statistics
+}
+
+type entity struct {
+	id      int64
+	records []*record // In order of internal ID.
+}
+
+// A pending export, read a line at a time by SzEngine.FetchNext.
+type export struct {
+	lines []string
+}
+
+// How two records, or a record and an entity, match.
+type matchInfo struct {
+	key   string
+	level string
+	rule  string
+	score int // Orders matches: the level first, then the number of matching feature types.
+}
+
+type record struct {
+	dataSource string
+	definition json.RawMessage
+	entity     *entity
+	features   []recordFeature
+	internalID int64
+	match      matchInfo // How the record joined its entity.
+	recordID   string
+}
+
+type recordKey struct {
+	dataSource string
+	recordID   string
+}
+
+type statistics struct {
+	addedRecords   int64
+	deletedRecords int64
+	reevaluations  int64
+	redoTriggers   int64
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newRepository(dataSourceCodes []string) (*repository, error) {
+	result := &repository{
+		configHandles: map[int64]*configuration{},
+		configs:       map[int64]*registeredConfig{},
+		exports:       map[int64]*export{},
+	}
+	result.purge()
+	configuration, err := parseConfiguration(templateConfig)
+	if err != nil {
+		return nil, err
+	}
+	for _, dataSourceCode := range dataSourceCodes {
+		if _, err := configuration.addDataSource(dataSourceCode); err != nil {
+			return nil, fmt.Errorf("WithDataSources(%s) error: %w", dataSourceCode, err)
+		}
+	}
+	registeredConfig := newRegisteredConfig(configuration.export(), "Default configuration")
+	result.configs[registeredConfig.id] = registeredConfig
+	result.defaultConfigID = registeredConfig.id
+	return result, nil
+}
+
+/*
+Compare the features of two records, or of a record and a search, and classify the match.
+Records resolve when they share a NAME and at least one other feature type.
+*/
+func compareFeatures(features1 []recordFeature, features2 []recordFeature) matchInfo {
+	shared := []string{}
+	for _, featureType := range featureTypes {
+		if sharesFeature(features1, features2, featureType) {
+			shared = append(shared, featureType)
+		}
+	}
+	if len(shared) == 0 {
+		return matchInfo{}
+	}
+	result := matchInfo{}
+	for _, featureType := range shared {
+		result.key += "+" + featureType
+	}
+	switch {
+	case shared[0] == featureName && len(shared) > 1:
+		result.level, result.rule, result.score = matchLevelResolved, ruleResolve, 300
+	case shared[0] == featureName:
+		result.level, result.rule, result.score = matchLevelNameOnly, ruleNameOnly, 100
+	default:
+		result.level, result.rule, result.score = matchLevelPossiblyRelated, ruleRelate, 200
+	}
+	result.score += len(shared)
+	return result
+}
+
+func indexKey(feature *feature) string {
+	return feature.featureType + "|" + feature.key
+}
+
+func mustMarshal(value any) []byte {
+	result, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// Create a gRPC status error whose message carries a Senzing reason, as the Senzing gRPC server does.
+func newError(reason reason, arguments ...any) error {
+	message := map[string]string{
+		"reason": fmt.Sprintf("SENZ%04dE|%s", reason.number, fmt.Sprintf(reason.format, arguments...)),
+	}
+	return status.Error(reason.code, string(mustMarshal(message)))
+}
+
+func sharesFeature(features1 []recordFeature, features2 []recordFeature, featureType string) bool {
+	for _, feature1 := range features1 {
+		if feature1.feature.featureType != featureType || len(feature1.feature.key) == 0 {
+			continue
+		}
+		for _, feature2 := range features2 {
+			if feature2.feature.featureType == featureType && feature2.feature.key == feature1.feature.key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Configuration
+// ----------------------------------------------------------------------------
+
+func (repository *repository) activeConfig() (*registeredConfig, error) {
+	configID := repository.pinnedConfigID
+	if configID == 0 {
+		configID = repository.defaultConfigID
+	}
+	result, ok := repository.configs[configID]
+	if !ok {
+		return nil, newError(reasonNoConfigRegistered)
+	}
+	return result, nil
+}
+
+func (repository *repository) getConfigHandle(configHandle int64) (*configuration, error) {
+	result, ok := repository.configHandles[configHandle]
+	if !ok {
+		return nil, newError(reasonInvalidHandle)
+	}
+	return result, nil
+}
+
+func (repository *repository) newHandle() int64 {
+	repository.nextHandle++
+	return repository.nextHandle
+}
+
+// Use a registered configuration instead of the default configuration.
+func (repository *repository) reinitialize(configID int64) error {
+	if _, ok := repository.configs[configID]; !ok {
+		return newError(reasonUnknownConfigID, configID)
+	}
+	repository.pinnedConfigID = configID
+	return nil
+}
+
+// Check that the active configuration has the data source.
+func (repository *repository) validateDataSource(dataSourceCode string) error {
+	activeConfig, err := repository.activeConfig()
+	if err != nil {
+		return err
+	}
+	if !activeConfig.dataSources[dataSourceCode] {
+		return newError(reasonDataSourceNotFound, dataSourceCode)
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Records and entities
+// ----------------------------------------------------------------------------
+
+/*
+Add or replace a record and resolve it.
+Returns the IDs of the entities created, changed or removed.
+*/
+func (repository *repository) addRecord(dataSourceCode string, recordID string, recordDefinition string) ([]int64, error) {
+	document := map[string]any{}
+	if err := json.Unmarshal([]byte(recordDefinition), &document); err != nil {
+		return nil, newError(reasonJSONParsing, err.Error())
+	}
+	if value, ok := document["DATA_SOURCE"]; ok && stringValue(value) != dataSourceCode {
+		return nil, newError(reasonConflictingDataSource, stringValue(value), dataSourceCode)
+	}
+	if value, ok := document["RECORD_ID"]; ok && stringValue(value) != recordID {
+		return nil, newError(reasonConflictingRecordID, stringValue(value), recordID)
+	}
+	if err := repository.validateDataSource(dataSourceCode); err != nil {
+		return nil, err
+	}
+	affected := []int64{}
+	key := recordKey{dataSource: dataSourceCode, recordID: recordID}
+	internalID := int64(0)
+	if existing, ok := repository.records[key]; ok {
+		internalID = existing.internalID
+		affected = repository.removeRecord(existing)
+	} else {
+		repository.nextInternalID++
+		internalID = repository.nextInternalID
+	}
+	compacted, err := compactJSON(recordDefinition)
+	if err != nil {
+		return nil, err
+	}
+	newRecord := &record{
+		dataSource: dataSourceCode,
+		definition: compacted,
+		internalID: internalID,
+		recordID:   recordID,
+	}
+	for _, candidate := range extractFeatures(document) {
+		newRecord.features = append(newRecord.features, repository.libraryFeature(candidate))
+	}
+	repository.records[key] = newRecord
+	for _, recordFeature := range newRecord.features {
+		if recordFeature.feature.featureType == featureName || len(recordFeature.feature.key) == 0 {
+			continue
+		}
+		indexKey := indexKey(recordFeature.feature)
+		if repository.featureIndex[indexKey] == nil {
+			repository.featureIndex[indexKey] = map[*record]bool{}
+		}
+		repository.featureIndex[indexKey][newRecord] = true
+	}
+	members := []*record{newRecord}
+	for _, candidate := range repository.candidateRecords(newRecord) {
+		if compareFeatures(newRecord.features, candidate.features).level == matchLevelResolved {
+			members = append(members, candidate.entity.records...)
+		}
+	}
+	repository.statistics.addedRecords++
+	return mergeIDs(affected, repository.resolve(members)), nil
+}
+
+// Records that share a feature other than NAME with the record, in other entities.
+func (repository *repository) candidateRecords(focus *record) []*record {
+	found := map[*record]bool{}
+	for _, recordFeature := range focus.features {
+		if recordFeature.feature.featureType == featureName {
+			continue
+		}
+		for candidate := range repository.featureIndex[indexKey(recordFeature.feature)] {
+			if candidate != focus && (focus.entity == nil || candidate.entity != focus.entity) {
+				found[candidate] = true
+			}
+		}
+	}
+	result := make([]*record, 0, len(found))
+	for candidate := range found {
+		result = append(result, candidate)
+	}
+	sortRecords(result)
+	return result
+}
+
+/*
+Delete a record.  Without "with info" processing, re-evaluating the rest of its entity is deferred to a redo record.
+Returns the IDs of the entities changed or removed.
+*/
+func (repository *repository) deleteRecord(dataSourceCode string, recordID string, withInfo bool) ([]int64, error) {
+	if err := repository.validateDataSource(dataSourceCode); err != nil {
+		return nil, err
+	}
+	existing, ok := repository.records[recordKey{dataSource: dataSourceCode, recordID: recordID}]
+	if !ok {
+		return []int64{}, nil
+	}
+	remaining := len(existing.entity.records) - 1
+	result := repository.removeRecord(existing)
+	if remaining > 0 && !withInfo {
+		redoRecord := string(mustMarshal(map[string]string{
+			"REASON":      "deferred delete",
+			"DATA_SOURCE": dataSourceCode,
+			"RECORD_ID":   recordID,
+			"DSRC_ACTION": "X",
+		}))
+		if !slices.Contains(repository.redoRecords, redoRecord) {
+			repository.redoRecords = append(repository.redoRecords, redoRecord)
+		}
+		repository.statistics.redoTriggers++
+	}
+	repository.statistics.deletedRecords++
+	return result, nil
+}
+
+func (repository *repository) getEntity(entityID int64) (*entity, error) {
+	result, ok := repository.entities[entityID]
+	if !ok {
+		return nil, newError(reasonUnknownEntity, entityID)
+	}
+	return result, nil
+}
+
+func (repository *repository) getRecord(dataSourceCode string, recordID string) (*record, error) {
+	if err := repository.validateDataSource(dataSourceCode); err != nil {
+		return nil, err
+	}
+	result, ok := repository.records[recordKey{dataSource: dataSourceCode, recordID: recordID}]
+	if !ok {
+		return nil, newError(reasonUnknownRecord, dataSourceCode, recordID)
+	}
+	return result, nil
+}
+
+// Find or add a feature in the feature library.
+func (repository *repository) libraryFeature(candidate candidateFeature) recordFeature {
+	description, key := candidate.normalize()
+	libraryKey := candidate.featureType + "|" + normalizeWords(description)
+	result, ok := repository.featuresByDesc[libraryKey]
+	if !ok {
+		repository.nextFeatureID++
+		result = &feature{
+			description: description,
+			elements:    candidate.elements,
+			featureType: candidate.featureType,
+			id:          repository.nextFeatureID,
+			key:         key,
+		}
+		repository.features[result.id] = result
+		repository.featuresByDesc[libraryKey] = result
+	}
+	return recordFeature{feature: result, usageType: candidate.usageType}
+}
+
+// Remove all records, entities, features, exports and redo records.
+func (repository *repository) purge() {
+	repository.entities = map[int64]*entity{}
+	repository.exports = map[int64]*export{}
+	repository.featureIndex = map[string]map[*record]bool{}
+	repository.features = map[int64]*feature{}
+	repository.featuresByDesc = map[string]*feature{}
+	repository.nextEntityID = 0
+	repository.nextFeatureID = 0
+	repository.nextInternalID = 0
+	repository.records = map[recordKey]*record{}
+	repository.redoRecords = []string{}
+	repository.statistics = statistics{}
+}
+
+// Resolve the records of an entity again.  Returns the IDs of the entities changed.
+func (repository *repository) reevaluateEntity(entity *entity) []int64 {
+	repository.statistics.reevaluations++
+	return repository.resolve(slices.Clone(entity.records))
+}
+
+// Find the entities related to an entity: those with a record that shares a feature other than NAME.
+func (repository *repository) relatedEntities(focus *entity) ([]*entity, map[*entity]matchInfo) {
+	matches := map[*entity]matchInfo{}
+	for _, focusRecord := range focus.records {
+		for _, candidate := range repository.candidateRecords(focusRecord) {
+			match := compareFeatures(focusRecord.features, candidate.features)
+			if match.score > matches[candidate.entity].score {
+				matches[candidate.entity] = match
+			}
+		}
+	}
+	result := make([]*entity, 0, len(matches))
+	for related := range matches {
+		result = append(result, related)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].id < result[j].id })
+	return result, matches
+}
+
+// Remove a record from its entity.  The rest of the entity is resolved again into new entities.
+func (repository *repository) removeRecord(existing *record) []int64 {
+	delete(repository.records, recordKey{dataSource: existing.dataSource, recordID: existing.recordID})
+	for _, recordFeature := range existing.features {
+		delete(repository.featureIndex[indexKey(recordFeature.feature)], existing)
+	}
+	oldEntity := existing.entity
+	delete(repository.entities, oldEntity.id)
+	remaining := slices.DeleteFunc(slices.Clone(oldEntity.records), func(member *record) bool { return member == existing })
+	if len(remaining) == 0 {
+		return []int64{oldEntity.id}
+	}
+	for _, member := range remaining {
+		member.entity = nil
+	}
+	return mergeIDs([]int64{oldEntity.id}, repository.resolve(remaining))
+}
+
+/*
+Partition records into entities of records connected by resolving matches.
+An entity keeps the smallest entity ID of its records that no earlier entity kept.
+Otherwise it gets a new entity ID.
+Returns the IDs of the entities removed, changed or created.
+*/
+func (repository *repository) resolve(members []*record) []int64 {
+	members = uniqueRecords(members)
+	parents := make([]int, len(members))
+	for index := range parents {
+		parents[index] = index
+	}
+	var find func(int) int
+	find = func(index int) int {
+		if parents[index] != index {
+			parents[index] = find(parents[index])
+		}
+		return parents[index]
+	}
+	for i := range members {
+		for j := i + 1; j < len(members); j++ {
+			if compareFeatures(members[i].features, members[j].features).level == matchLevelResolved {
+				parents[find(j)] = find(i)
+			}
+		}
+	}
+	components := map[int][]*record{}
+	roots := []int{}
+	for index, member := range members {
+		root := find(index)
+		if _, ok := components[root]; !ok {
+			roots = append(roots, root)
+		}
+		components[root] = append(components[root], member)
+	}
+	affected := []int64{}
+	for _, member := range members {
+		if member.entity != nil {
+			affected = append(affected, member.entity.id)
+			delete(repository.entities, member.entity.id)
+		}
+	}
+	claimed := map[int64]bool{}
+	newEntities := make([]*entity, 0, len(roots))
+	for _, root := range roots {
+		component := components[root]
+		sortRecords(component)
+		oldIDs := []int64{}
+		for _, member := range component {
+			if member.entity != nil && !claimed[member.entity.id] {
+				oldIDs = append(oldIDs, member.entity.id)
+			}
+		}
+		newEntity := &entity{records: component}
+		if len(oldIDs) > 0 {
+			newEntity.id = slices.Min(oldIDs)
+		} else {
+			repository.nextEntityID++
+			newEntity.id = repository.nextEntityID
+		}
+		claimed[newEntity.id] = true
+		newEntities = append(newEntities, newEntity)
+	}
+	for _, newEntity := range newEntities {
+		repository.entities[newEntity.id] = newEntity
+		affected = append(affected, newEntity.id)
+		for index, member := range newEntity.records {
+			member.entity = newEntity
+			member.match = matchInfo{}
+			if index > 0 {
+				member.match = bestMatch(member, newEntity.records[:index])
+			}
+			if index > 0 && member.match.level != matchLevelResolved {
+				member.match = bestMatch(member, newEntity.records)
+			}
+		}
+	}
+	return mergeIDs(affected, nil)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// The best match between a record and other records.
+func bestMatch(focus *record, others []*record) matchInfo {
+	result := matchInfo{}
+	for _, other := range others {
+		if other == focus {
+			continue
+		}
+		match := compareFeatures(focus.features, other.features)
+		if match.score > result.score {
+			result = match
+		}
+	}
+	return result
+}
+
+// Compact a JSON document so that it can be embedded in responses.
+func compactJSON(document string) (json.RawMessage, error) {
+	result := &bytes.Buffer{}
+	if err := json.Compact(result, []byte(document)); err != nil {
+		return nil, newError(reasonJSONParsing, err.Error())
+	}
+	return result.Bytes(), nil
+}
+
+// Combine lists of entity IDs, sorted and without duplicates.
+func mergeIDs(entityIDs1 []int64, entityIDs2 []int64) []int64 {
+	result := append(slices.Clone(entityIDs1), entityIDs2...)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+func sortRecords(records []*record) {
+	sort.Slice(records, func(i, j int) bool { return records[i].internalID < records[j].internalID })
+}
+
+func uniqueRecords(records []*record) []*record {
+	result := slices.Clone(records)
+	sortRecords(result)
+	return slices.Compact(result)
+}
@@ -0,0 +1,108 @@
+package fakeserver
+
+import (
+	"context"
+
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szconfig"
+)
+
+// The SzConfig service.  Configurations are held in memory, keyed by handle, until CloseConfig.
+type szConfigServer struct {
+	szpb.UnimplementedSzConfigServer
+	repository *repository
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+func (server *szConfigServer) AddDataSource(ctx context.Context, request *szpb.AddDataSourceRequest) (*szpb.AddDataSourceResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	configuration, err := server.repository.getConfigHandle(request.GetConfigHandle())
+	if err != nil {
+		return nil, err
+	}
+	dataSource, err := configuration.addDataSource(request.GetDataSourceCode())
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		ID int64 `json:"DSRC_ID"`
+	}{
+		ID: dataSource.ID,
+	}
+	return &szpb.AddDataSourceResponse{Result: string(mustMarshal(result))}, nil
+}
+
+func (server *szConfigServer) CloseConfig(ctx context.Context, request *szpb.CloseConfigRequest) (*szpb.CloseConfigResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	delete(server.repository.configHandles, request.GetConfigHandle())
+	return &szpb.CloseConfigResponse{}, nil
+}
+
+func (server *szConfigServer) CreateConfig(ctx context.Context, request *szpb.CreateConfigRequest) (*szpb.CreateConfigResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	configuration, err := parseConfiguration(templateConfig)
+	if err != nil {
+		return nil, err
+	}
+	result := server.repository.newHandle()
+	server.repository.configHandles[result] = configuration
+	return &szpb.CreateConfigResponse{Result: result}, nil
+}
+
+func (server *szConfigServer) DeleteDataSource(ctx context.Context, request *szpb.DeleteDataSourceRequest) (*szpb.DeleteDataSourceResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	configuration, err := server.repository.getConfigHandle(request.GetConfigHandle())
+	if err != nil {
+		return nil, err
+	}
+	if err := configuration.deleteDataSource(request.GetDataSourceCode()); err != nil {
+		return nil, err
+	}
+	return &szpb.DeleteDataSourceResponse{}, nil
+}
+
+func (server *szConfigServer) ExportConfig(ctx context.Context, request *szpb.ExportConfigRequest) (*szpb.ExportConfigResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	configuration, err := server.repository.getConfigHandle(request.GetConfigHandle())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.ExportConfigResponse{Result: configuration.export()}, nil
+}
+
+func (server *szConfigServer) GetDataSources(ctx context.Context, request *szpb.GetDataSourcesRequest) (*szpb.GetDataSourcesResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	configuration, err := server.repository.getConfigHandle(request.GetConfigHandle())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.GetDataSourcesResponse{Result: configuration.getDataSources()}, nil
+}
+
+func (server *szConfigServer) ImportConfig(ctx context.Context, request *szpb.ImportConfigRequest) (*szpb.ImportConfigResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	configuration, err := parseConfiguration(request.GetConfigDefinition())
+	if err != nil {
+		return nil, err
+	}
+	result := server.repository.newHandle()
+	server.repository.configHandles[result] = configuration
+	return &szpb.ImportConfigResponse{Result: result}, nil
+}
@@ -0,0 +1,106 @@
+package fakeserver
+
+import (
+	"context"
+	"sort"
+
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager"
+)
+
+// The SzConfigManager service.  Configuration IDs are a hash of the configuration definition.
+type szConfigManagerServer struct {
+	szpb.UnimplementedSzConfigManagerServer
+	repository *repository
+}
+
+type configListDocument struct {
+	Configs []configListEntry `json:"CONFIGS"`
+}
+
+type configListEntry struct {
+	ConfigID       int64  `json:"CONFIG_ID"`
+	ConfigComments string `json:"CONFIG_COMMENTS"`
+	SysCreateDate  string `json:"SYS_CREATE_DT"`
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+func (server *szConfigManagerServer) AddConfig(ctx context.Context, request *szpb.AddConfigRequest) (*szpb.AddConfigResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	registeredConfig := newRegisteredConfig(request.GetConfigDefinition(), request.GetConfigComment())
+	if existing, ok := server.repository.configs[registeredConfig.id]; ok {
+		return &szpb.AddConfigResponse{Result: existing.id}, nil
+	}
+	server.repository.configs[registeredConfig.id] = registeredConfig
+	return &szpb.AddConfigResponse{Result: registeredConfig.id}, nil
+}
+
+func (server *szConfigManagerServer) GetConfig(ctx context.Context, request *szpb.GetConfigRequest) (*szpb.GetConfigResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	registeredConfig, ok := server.repository.configs[request.GetConfigId()]
+	if !ok {
+		return nil, newError(reasonUnknownConfigID, request.GetConfigId())
+	}
+	return &szpb.GetConfigResponse{Result: registeredConfig.definition}, nil
+}
+
+func (server *szConfigManagerServer) GetConfigs(ctx context.Context, request *szpb.GetConfigsRequest) (*szpb.GetConfigsResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	result := configListDocument{Configs: []configListEntry{}}
+	for _, registeredConfig := range server.repository.configs {
+		result.Configs = append(result.Configs, configListEntry{
+			ConfigID:       registeredConfig.id,
+			ConfigComments: registeredConfig.comments,
+			SysCreateDate:  registeredConfig.createDate,
+		})
+	}
+	sort.Slice(result.Configs, func(i, j int) bool {
+		if result.Configs[i].SysCreateDate != result.Configs[j].SysCreateDate {
+			return result.Configs[i].SysCreateDate < result.Configs[j].SysCreateDate
+		}
+		return result.Configs[i].ConfigID < result.Configs[j].ConfigID
+	})
+	return &szpb.GetConfigsResponse{Result: string(mustMarshal(result))}, nil
+}
+
+func (server *szConfigManagerServer) GetDefaultConfigId(ctx context.Context, request *szpb.GetDefaultConfigIdRequest) (*szpb.GetDefaultConfigIdResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	return &szpb.GetDefaultConfigIdResponse{Result: server.repository.defaultConfigID}, nil
+}
+
+func (server *szConfigManagerServer) ReplaceDefaultConfigId(ctx context.Context, request *szpb.ReplaceDefaultConfigIdRequest) (*szpb.ReplaceDefaultConfigIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if request.GetCurrentDefaultConfigId() != server.repository.defaultConfigID {
+		return nil, newError(reasonDefaultConfigIDChanged, request.GetCurrentDefaultConfigId())
+	}
+	if _, ok := server.repository.configs[request.GetNewDefaultConfigId()]; !ok {
+		return nil, newError(reasonUnknownConfigID, request.GetNewDefaultConfigId())
+	}
+	server.repository.defaultConfigID = request.GetNewDefaultConfigId()
+	return &szpb.ReplaceDefaultConfigIdResponse{}, nil
+}
+
+func (server *szConfigManagerServer) SetDefaultConfigId(ctx context.Context, request *szpb.SetDefaultConfigIdRequest) (*szpb.SetDefaultConfigIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if _, ok := server.repository.configs[request.GetConfigId()]; !ok {
+		return nil, newError(reasonUnknownConfigID, request.GetConfigId())
+	}
+	server.repository.defaultConfigID = request.GetConfigId()
+	return &szpb.SetDefaultConfigIdResponse{}, nil
+}
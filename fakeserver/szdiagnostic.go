@@ -0,0 +1,73 @@
+package fakeserver
+
+import (
+	"context"
+
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szdiagnostic"
+)
+
+// The SzDiagnostic service.
+type szDiagnosticServer struct {
+	szpb.UnimplementedSzDiagnosticServer
+	repository *repository
+}
+
+const (
+	datastoreInfo        = `{"dataStores":[{"id":"CORE","type":"memory","location":"fakeserver"}]}`
+	datastorePerformance = `{"numRecordsInserted":0,"insertTime":0}`
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+func (server *szDiagnosticServer) CheckDatastorePerformance(ctx context.Context, request *szpb.CheckDatastorePerformanceRequest) (*szpb.CheckDatastorePerformanceResponse, error) {
+	_ = ctx
+	_ = request
+	return &szpb.CheckDatastorePerformanceResponse{Result: datastorePerformance}, nil
+}
+
+func (server *szDiagnosticServer) GetDatastoreInfo(ctx context.Context, request *szpb.GetDatastoreInfoRequest) (*szpb.GetDatastoreInfoResponse, error) {
+	_ = ctx
+	_ = request
+	return &szpb.GetDatastoreInfoResponse{Result: datastoreInfo}, nil
+}
+
+func (server *szDiagnosticServer) GetFeature(ctx context.Context, request *szpb.GetFeatureRequest) (*szpb.GetFeatureResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	feature, ok := server.repository.features[request.GetFeatureId()]
+	if !ok {
+		return nil, newError(reasonUnknownFeature, request.GetFeatureId())
+	}
+	result := struct {
+		LibFeatID int64     `json:"LIB_FEAT_ID"`
+		FtypeCode string    `json:"FTYPE_CODE"`
+		Elements  []element `json:"ELEMENTS"`
+	}{
+		LibFeatID: feature.id,
+		FtypeCode: feature.featureType,
+		Elements:  feature.elements,
+	}
+	return &szpb.GetFeatureResponse{Result: string(mustMarshal(result))}, nil
+}
+
+func (server *szDiagnosticServer) PurgeRepository(ctx context.Context, request *szpb.PurgeRepositoryRequest) (*szpb.PurgeRepositoryResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	server.repository.purge()
+	return &szpb.PurgeRepositoryResponse{}, nil
+}
+
+func (server *szDiagnosticServer) Reinitialize(ctx context.Context, request *szpb.ReinitializeRequest) (*szpb.ReinitializeResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if err := server.repository.reinitialize(request.GetConfigId()); err != nil {
+		return nil, err
+	}
+	return &szpb.ReinitializeResponse{}, nil
+}
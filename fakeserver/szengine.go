@@ -0,0 +1,536 @@
+package fakeserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+)
+
+// The SzEngine service.
+type szEngineServer struct {
+	szpb.UnimplementedSzEngineServer
+	repository *repository
+}
+
+type searchDocument struct {
+	ResolvedEntities []searchResultDocument `json:"RESOLVED_ENTITIES"`
+}
+
+type searchMatchInfoDocument struct {
+	ErruleCode     string `json:"ERRULE_CODE"`
+	MatchKey       string `json:"MATCH_KEY"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+}
+
+type searchResultDocument struct {
+	Entity    entityDocument          `json:"ENTITY"`
+	MatchInfo searchMatchInfoDocument `json:"MATCH_INFO"`
+}
+
+const (
+	interestingEntities = `{"INTERESTING_ENTITIES":{"ENTITIES":[]}}`
+	withoutInfo         = "{}"
+)
+
+// Search profiles accepted by SearchByAttributes.  The empty string is the default profile.
+var searchProfiles = map[string]bool{"": true, "INGEST": true, "SEARCH": true}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+func (server *szEngineServer) AddRecord(ctx context.Context, request *szpb.AddRecordRequest) (*szpb.AddRecordResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	affected, err := server.repository.addRecord(request.GetDataSourceCode(), request.GetRecordId(), request.GetRecordDefinition())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.AddRecordResponse{Result: info(request.GetFlags(), request.GetDataSourceCode(), request.GetRecordId(), affected)}, nil
+}
+
+func (server *szEngineServer) CloseExport(ctx context.Context, request *szpb.CloseExportRequest) (*szpb.CloseExportResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if _, ok := server.repository.exports[request.GetExportHandle()]; !ok {
+		return nil, newError(reasonInvalidHandle)
+	}
+	delete(server.repository.exports, request.GetExportHandle())
+	return &szpb.CloseExportResponse{}, nil
+}
+
+func (server *szEngineServer) CountRedoRecords(ctx context.Context, request *szpb.CountRedoRecordsRequest) (*szpb.CountRedoRecordsResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	return &szpb.CountRedoRecordsResponse{Result: int64(len(server.repository.redoRecords))}, nil
+}
+
+func (server *szEngineServer) DeleteRecord(ctx context.Context, request *szpb.DeleteRecordRequest) (*szpb.DeleteRecordResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	withInfo := hasFlag(request.GetFlags(), senzing.SzWithInfo)
+	affected, err := server.repository.deleteRecord(request.GetDataSourceCode(), request.GetRecordId(), withInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.DeleteRecordResponse{Result: info(request.GetFlags(), request.GetDataSourceCode(), request.GetRecordId(), affected)}, nil
+}
+
+func (server *szEngineServer) ExportCsvEntityReport(ctx context.Context, request *szpb.ExportCsvEntityReportRequest) (*szpb.ExportCsvEntityReportResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	lines, err := server.repository.exportCsv(request.GetCsvColumnList(), request.GetFlags())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.ExportCsvEntityReportResponse{Result: server.repository.newExport(lines)}, nil
+}
+
+func (server *szEngineServer) ExportJsonEntityReport(ctx context.Context, request *szpb.ExportJsonEntityReportRequest) (*szpb.ExportJsonEntityReportResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	lines := server.repository.exportJSON(request.GetFlags())
+	return &szpb.ExportJsonEntityReportResponse{Result: server.repository.newExport(lines)}, nil
+}
+
+func (server *szEngineServer) FetchNext(ctx context.Context, request *szpb.FetchNextRequest) (*szpb.FetchNextResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	export, ok := server.repository.exports[request.GetExportHandle()]
+	if !ok {
+		return nil, newError(reasonInvalidHandle)
+	}
+	if len(export.lines) == 0 {
+		return &szpb.FetchNextResponse{}, nil
+	}
+	result := export.lines[0] + "\n"
+	export.lines = export.lines[1:]
+	return &szpb.FetchNextResponse{Result: result}, nil
+}
+
+func (server *szEngineServer) FindInterestingEntitiesByEntityId(ctx context.Context, request *szpb.FindInterestingEntitiesByEntityIdRequest) (*szpb.FindInterestingEntitiesByEntityIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if _, err := server.repository.getEntity(request.GetEntityId()); err != nil {
+		return nil, err
+	}
+	return &szpb.FindInterestingEntitiesByEntityIdResponse{Result: interestingEntities}, nil
+}
+
+func (server *szEngineServer) FindInterestingEntitiesByRecordId(ctx context.Context, request *szpb.FindInterestingEntitiesByRecordIdRequest) (*szpb.FindInterestingEntitiesByRecordIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if _, err := server.repository.getRecord(request.GetDataSourceCode(), request.GetRecordId()); err != nil {
+		return nil, err
+	}
+	return &szpb.FindInterestingEntitiesByRecordIdResponse{Result: interestingEntities}, nil
+}
+
+func (server *szEngineServer) FindNetworkByEntityId(ctx context.Context, request *szpb.FindNetworkByEntityIdRequest) (*szpb.FindNetworkByEntityIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	entities, err := server.repository.entitiesInList(request.GetEntityIds())
+	if err != nil {
+		return nil, err
+	}
+	result := server.repository.findNetwork(entities, request.GetMaxDegrees(), request.GetBuildOutDegree(), request.GetBuildOutMaxEntities(), request.GetFlags())
+	return &szpb.FindNetworkByEntityIdResponse{Result: result}, nil
+}
+
+func (server *szEngineServer) FindNetworkByRecordId(ctx context.Context, request *szpb.FindNetworkByRecordIdRequest) (*szpb.FindNetworkByRecordIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	entities, err := server.repository.entitiesInList(request.GetRecordKeys())
+	if err != nil {
+		return nil, err
+	}
+	result := server.repository.findNetwork(entities, request.GetMaxDegrees(), request.GetBuildOutDegree(), request.GetBuildOutMaxEntities(), request.GetFlags())
+	return &szpb.FindNetworkByRecordIdResponse{Result: result}, nil
+}
+
+func (server *szEngineServer) FindPathByEntityId(ctx context.Context, request *szpb.FindPathByEntityIdRequest) (*szpb.FindPathByEntityIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	start, err := server.repository.getEntity(request.GetStartEntityId())
+	if err != nil {
+		return nil, err
+	}
+	end, err := server.repository.getEntity(request.GetEndEntityId())
+	if err != nil {
+		return nil, err
+	}
+	options, err := server.repository.newPathOptions(request.GetMaxDegrees(), request.GetAvoidEntityIds(), request.GetRequiredDataSources(), request.GetFlags())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.FindPathByEntityIdResponse{Result: server.repository.marshalPath(start, end, options, request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) FindPathByRecordId(ctx context.Context, request *szpb.FindPathByRecordIdRequest) (*szpb.FindPathByRecordIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	start, err := server.repository.getRecord(request.GetStartDataSourceCode(), request.GetStartRecordId())
+	if err != nil {
+		return nil, err
+	}
+	end, err := server.repository.getRecord(request.GetEndDataSourceCode(), request.GetEndRecordId())
+	if err != nil {
+		return nil, err
+	}
+	options, err := server.repository.newPathOptions(request.GetMaxDegrees(), request.GetAvoidRecordKeys(), request.GetRequiredDataSources(), request.GetFlags())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.FindPathByRecordIdResponse{Result: server.repository.marshalPath(start.entity, end.entity, options, request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) GetActiveConfigId(ctx context.Context, request *szpb.GetActiveConfigIdRequest) (*szpb.GetActiveConfigIdResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	activeConfig, err := server.repository.activeConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.GetActiveConfigIdResponse{Result: activeConfig.id}, nil
+}
+
+func (server *szEngineServer) GetEntityByEntityId(ctx context.Context, request *szpb.GetEntityByEntityIdRequest) (*szpb.GetEntityByEntityIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	entity, err := server.repository.getEntity(request.GetEntityId())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.GetEntityByEntityIdResponse{Result: server.repository.marshalEntity(entity, request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) GetEntityByRecordId(ctx context.Context, request *szpb.GetEntityByRecordIdRequest) (*szpb.GetEntityByRecordIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	record, err := server.repository.getRecord(request.GetDataSourceCode(), request.GetRecordId())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.GetEntityByRecordIdResponse{Result: server.repository.marshalEntity(record.entity, request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) GetRecord(ctx context.Context, request *szpb.GetRecordRequest) (*szpb.GetRecordResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	record, err := server.repository.getRecord(request.GetDataSourceCode(), request.GetRecordId())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.GetRecordResponse{Result: string(mustMarshal(newRecordDocument(record, request.GetFlags())))}, nil
+}
+
+func (server *szEngineServer) GetRedoRecord(ctx context.Context, request *szpb.GetRedoRecordRequest) (*szpb.GetRedoRecordResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if len(server.repository.redoRecords) == 0 {
+		return &szpb.GetRedoRecordResponse{}, nil
+	}
+	result := server.repository.redoRecords[0]
+	server.repository.redoRecords = server.repository.redoRecords[1:]
+	return &szpb.GetRedoRecordResponse{Result: result}, nil
+}
+
+func (server *szEngineServer) GetStats(ctx context.Context, request *szpb.GetStatsRequest) (*szpb.GetStatsResponse, error) {
+	_ = ctx
+	_ = request
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	workload := server.repository.statistics
+	server.repository.statistics = statistics{}
+	result := fmt.Sprintf(`{ "workload": { "addedRecords": %d, "deletedRecords": %d, "reevaluations": %d, "redoTriggers": %d } }`,
+		workload.addedRecords, workload.deletedRecords, workload.reevaluations, workload.redoTriggers)
+	return &szpb.GetStatsResponse{Result: result}, nil
+}
+
+func (server *szEngineServer) GetVirtualEntityByRecordId(ctx context.Context, request *szpb.GetVirtualEntityByRecordIdRequest) (*szpb.GetVirtualEntityByRecordIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	document, err := parseEntityList(request.GetRecordKeys())
+	if err != nil {
+		return nil, err
+	}
+	records := []*record{}
+	for _, key := range document.Records {
+		member, err := server.repository.getRecord(key.DataSource, key.RecordID)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, member)
+	}
+	if len(records) == 0 {
+		return nil, newError(reasonJSONParsing, "no records")
+	}
+	records = uniqueRecords(records)
+	virtualEntity := &entity{id: records[0].entity.id, records: records}
+	return &szpb.GetVirtualEntityByRecordIdResponse{Result: server.repository.marshalEntity(virtualEntity, request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) HowEntityByEntityId(ctx context.Context, request *szpb.HowEntityByEntityIdRequest) (*szpb.HowEntityByEntityIdResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	entity, err := server.repository.getEntity(request.GetEntityId())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.HowEntityByEntityIdResponse{Result: server.repository.howEntity(entity)}, nil
+}
+
+func (server *szEngineServer) PrimeEngine(ctx context.Context, request *szpb.PrimeEngineRequest) (*szpb.PrimeEngineResponse, error) {
+	_ = ctx
+	_ = request
+	return &szpb.PrimeEngineResponse{}, nil
+}
+
+func (server *szEngineServer) ProcessRedoRecord(ctx context.Context, request *szpb.ProcessRedoRecordRequest) (*szpb.ProcessRedoRecordResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	redoRecord := struct {
+		DataSource string `json:"DATA_SOURCE"`
+		RecordID   string `json:"RECORD_ID"`
+	}{}
+	if err := json.Unmarshal([]byte(request.GetRedoRecord()), &redoRecord); err != nil {
+		return nil, newError(reasonJSONParsing, err.Error())
+	}
+	if len(redoRecord.DataSource) == 0 {
+		return nil, newError(reasonMissingDataSource)
+	}
+	if err := server.repository.validateDataSource(redoRecord.DataSource); err != nil {
+		return nil, err
+	}
+	affected := []int64{}
+	if member, ok := server.repository.records[recordKey{dataSource: redoRecord.DataSource, recordID: redoRecord.RecordID}]; ok {
+		affected = server.repository.reevaluateEntity(member.entity)
+	}
+	return &szpb.ProcessRedoRecordResponse{Result: info(request.GetFlags(), redoRecord.DataSource, redoRecord.RecordID, affected)}, nil
+}
+
+func (server *szEngineServer) ReevaluateEntity(ctx context.Context, request *szpb.ReevaluateEntityRequest) (*szpb.ReevaluateEntityResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	entity, ok := server.repository.entities[request.GetEntityId()]
+	if !ok {
+		return &szpb.ReevaluateEntityResponse{Result: info(request.GetFlags(), "", "", []int64{})}, nil
+	}
+	first := entity.records[0]
+	affected := server.repository.reevaluateEntity(entity)
+	return &szpb.ReevaluateEntityResponse{Result: info(request.GetFlags(), first.dataSource, first.recordID, affected)}, nil
+}
+
+func (server *szEngineServer) ReevaluateRecord(ctx context.Context, request *szpb.ReevaluateRecordRequest) (*szpb.ReevaluateRecordResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if err := server.repository.validateDataSource(request.GetDataSourceCode()); err != nil {
+		return nil, err
+	}
+	affected := []int64{}
+	if member, ok := server.repository.records[recordKey{dataSource: request.GetDataSourceCode(), recordID: request.GetRecordId()}]; ok {
+		affected = server.repository.reevaluateEntity(member.entity)
+	}
+	return &szpb.ReevaluateRecordResponse{Result: info(request.GetFlags(), request.GetDataSourceCode(), request.GetRecordId(), affected)}, nil
+}
+
+func (server *szEngineServer) Reinitialize(ctx context.Context, request *szpb.ReinitializeRequest) (*szpb.ReinitializeResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if err := server.repository.reinitialize(request.GetConfigId()); err != nil {
+		return nil, err
+	}
+	return &szpb.ReinitializeResponse{}, nil
+}
+
+func (server *szEngineServer) SearchByAttributes(ctx context.Context, request *szpb.SearchByAttributesRequest) (*szpb.SearchByAttributesResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	if !searchProfiles[request.GetSearchProfile()] {
+		return nil, newError(reasonUnknownSearchProfile, request.GetSearchProfile())
+	}
+	return &szpb.SearchByAttributesResponse{Result: server.repository.search(request.GetAttributes(), request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) StreamExportCsvEntityReport(request *szpb.StreamExportCsvEntityReportRequest, stream szpb.SzEngine_StreamExportCsvEntityReportServer) error {
+	server.repository.Lock()
+	lines, err := server.repository.exportCsv(request.GetCsvColumnList(), request.GetFlags())
+	server.repository.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if err := stream.Send(&szpb.StreamExportCsvEntityReportResponse{Result: line + "\n"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (server *szEngineServer) StreamExportJsonEntityReport(request *szpb.StreamExportJsonEntityReportRequest, stream szpb.SzEngine_StreamExportJsonEntityReportServer) error {
+	server.repository.Lock()
+	lines := server.repository.exportJSON(request.GetFlags())
+	server.repository.Unlock()
+	for _, line := range lines {
+		if err := stream.Send(&szpb.StreamExportJsonEntityReportResponse{Result: line + "\n"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (server *szEngineServer) WhyEntities(ctx context.Context, request *szpb.WhyEntitiesRequest) (*szpb.WhyEntitiesResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	entity1, err := server.repository.getEntity(request.GetEntityId1())
+	if err != nil {
+		return nil, err
+	}
+	entity2, err := server.repository.getEntity(request.GetEntityId2())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.WhyEntitiesResponse{Result: server.repository.whyEntities(entity1, entity2, request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) WhyRecordInEntity(ctx context.Context, request *szpb.WhyRecordInEntityRequest) (*szpb.WhyRecordInEntityResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	record, err := server.repository.getRecord(request.GetDataSourceCode(), request.GetRecordId())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.WhyRecordInEntityResponse{Result: server.repository.whyRecordInEntity(record, request.GetFlags())}, nil
+}
+
+func (server *szEngineServer) WhyRecords(ctx context.Context, request *szpb.WhyRecordsRequest) (*szpb.WhyRecordsResponse, error) {
+	_ = ctx
+	server.repository.Lock()
+	defer server.repository.Unlock()
+	record1, err := server.repository.getRecord(request.GetDataSourceCode1(), request.GetRecordId1())
+	if err != nil {
+		return nil, err
+	}
+	record2, err := server.repository.getRecord(request.GetDataSourceCode2(), request.GetRecordId2())
+	if err != nil {
+		return nil, err
+	}
+	return &szpb.WhyRecordsResponse{Result: server.repository.whyRecords(record1, record2, request.GetFlags())}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The result of a method that can return "with info" information.
+func info(flags int64, dataSourceCode string, recordID string, affected []int64) string {
+	if !hasFlag(flags, senzing.SzWithInfo) {
+		return withoutInfo
+	}
+	return newInfoDocument(dataSourceCode, recordID, affected)
+}
+
+// ----------------------------------------------------------------------------
+// repository methods
+// ----------------------------------------------------------------------------
+
+func (repository *repository) newPathOptions(maxDegrees int64, avoid string, requiredDataSources string, flags int64) (pathOptions, error) {
+	avoided, err := repository.avoidedEntities(avoid)
+	if err != nil {
+		return pathOptions{}, err
+	}
+	required, err := parseDataSources(requiredDataSources)
+	if err != nil {
+		return pathOptions{}, err
+	}
+	return pathOptions{
+		avoid:       avoided,
+		maxDegrees:  maxDegrees,
+		required:    required,
+		strictAvoid: hasFlag(flags, senzing.SzFindPathStrictAvoid),
+	}, nil
+}
+
+/*
+Find the entities that match search attributes, best match first.
+Attributes that cannot be parsed match nothing.
+*/
+func (repository *repository) search(attributes string, flags int64) string {
+	result := searchDocument{ResolvedEntities: []searchResultDocument{}}
+	document := map[string]any{}
+	if err := json.Unmarshal([]byte(attributes), &document); err != nil {
+		return string(mustMarshal(result))
+	}
+	searchFeatures := []recordFeature{}
+	for _, candidate := range extractFeatures(document) {
+		description, key := candidate.normalize()
+		searchFeatures = append(searchFeatures, recordFeature{
+			feature:   &feature{description: description, featureType: candidate.featureType, key: key},
+			usageType: candidate.usageType,
+		})
+	}
+	matches := map[*entity]matchInfo{}
+	for _, member := range repository.entities {
+		for _, memberRecord := range member.records {
+			if match := compareFeatures(searchFeatures, memberRecord.features); match.score > matches[member].score {
+				matches[member] = match
+			}
+		}
+	}
+	entities := make([]*entity, 0, len(matches))
+	for member := range matches {
+		entities = append(entities, member)
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		if matches[entities[i]].score != matches[entities[j]].score {
+			return matches[entities[i]].score > matches[entities[j]].score
+		}
+		return entities[i].id < entities[j].id
+	})
+	for _, member := range entities {
+		match := matches[member]
+		result.ResolvedEntities = append(result.ResolvedEntities, searchResultDocument{
+			Entity: repository.newEntityDocument(member, flags),
+			MatchInfo: searchMatchInfoDocument{
+				ErruleCode:     match.rule,
+				MatchKey:       match.key,
+				MatchLevelCode: match.level,
+			},
+		})
+	}
+	return string(mustMarshal(result))
+}
@@ -0,0 +1,28 @@
+package fakeserver
+
+import (
+	"context"
+
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+)
+
+// The SzProduct service.  It reports a fixed license and version.
+type szProductServer struct {
+	szpb.UnimplementedSzProductServer
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+func (server *szProductServer) GetLicense(ctx context.Context, request *szpb.GetLicenseRequest) (*szpb.GetLicenseResponse, error) {
+	_ = ctx
+	_ = request
+	return &szpb.GetLicenseResponse{Result: license}, nil
+}
+
+func (server *szProductServer) GetVersion(ctx context.Context, request *szpb.GetVersionRequest) (*szpb.GetVersionResponse, error) {
+	_ = ctx
+	_ = request
+	return &szpb.GetVersionResponse{Result: version}, nil
+}
@@ -1,14 +1,18 @@
 package helper
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/senzing-garage/go-messaging/parser"
 	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ----------------------------------------------------------------------------
@@ -24,6 +28,40 @@ func isJSON(unknownString string) bool {
 	return json.Unmarshal([]byte(unknownStringUnescaped), &jsonString) == nil
 }
 
+// Extract the Senzing reason from a JSON message produced by the Senzing gRPC server.
+func parseMessageReason(senzingErrorMessage string) (string, error) {
+	if !isJSON(senzingErrorMessage) {
+		return "", fmt.Errorf("message is not JSON: %q", senzingErrorMessage)
+	}
+	if unquoted, err := strconv.Unquote(senzingErrorMessage); err == nil {
+		senzingErrorMessage = unquoted
+	}
+	parsedMessage, err := parser.Parse(senzingErrorMessage)
+	if err != nil {
+		return "", fmt.Errorf("parse(%s) error: %w", senzingErrorMessage, err)
+	}
+	return parsedMessage.Reason, nil
+}
+
+/*
+Extract the reason code from a Senzing reason.
+The reason has the form "SENZnnnn[E]|text", where nnnn is the reason code.
+The "SENZ" prefix and the "|text" suffix are optional.
+*/
+func parseReasonCode(reason string) (int, error) {
+	reasonCode, _, _ := strings.Cut(reason, "|")
+	reasonCode = strings.TrimPrefix(strings.TrimSpace(reasonCode), "SENZ")
+	digits := len(reasonCode) - len(strings.TrimLeft(reasonCode, "0123456789"))
+	if digits == 0 {
+		return 0, fmt.Errorf("no reason code in reason %q", reason)
+	}
+	result, err := strconv.Atoi(reasonCode[:digits])
+	if err != nil {
+		return 0, fmt.Errorf("strconv.Atoi(%s) error: %w", reason, err)
+	}
+	return result, nil
+}
+
 // ----------------------------------------------------------------------------
 // Public functions
 // ----------------------------------------------------------------------------
@@ -32,6 +70,16 @@ func isJSON(unknownString string) bool {
 The ConvertGrpcError method transforms an error produced by google.golang.org/grpc/status
 into a Senzing nested error.
 
+The Senzing reason code is taken from an errdetails.ErrorInfo detail, if the server sent one,
+otherwise from the "reason" field of the JSON status message.
+The result is an *SzGrpcError that matches the szerror types for the reason code with errors.Is(),
+and still reports the gRPC code through status.Code().
+If there is no Senzing reason code, for example when the server cannot be reached,
+the szerror types are taken from GrpcCodeErrorTypes instead.
+Context cancellation and deadline errors are treated as codes.Canceled and codes.DeadlineExceeded,
+and an error with codes.Canceled matches context.Canceled with errors.Is().
+Other errors that do not carry a gRPC status are returned unchanged.
+
 Input
   - originalError: The error received from the gRPC call.
 
@@ -39,47 +87,119 @@ Output
   - A Senzing nested error.
 */
 func ConvertGrpcError(originalError error) error {
-
 	if originalError == nil {
 		return nil
 	}
 
-	result := originalError
-
-	// Determine if error is an RPC error.
+	// Use the status itself rather than status.FromError(), which replaces the message of a wrapped status.
+
+	var grpcStatus *status.Status
+	var grpcStatusError interface{ GRPCStatus() *status.Status }
+	switch {
+	case errors.As(originalError, &grpcStatusError):
+		grpcStatus = grpcStatusError.GRPCStatus()
+	case errors.Is(originalError, context.Canceled), errors.Is(originalError, context.DeadlineExceeded):
+		grpcStatus = status.FromContextError(originalError)
+	default:
+		return originalError
+	}
+	result := &SzGrpcError{
+		GrpcCode:   grpcStatus.Code(),
+		Message:    grpcStatus.Message(),
+		grpcStatus: grpcStatus,
+		original:   originalError,
+	}
+	if !result.setFromDetails() {
+		if err := result.setFromMessage(); err != nil {
+			result.setFromCode()
+			return result
+		}
+	}
+	result.szError = szerror.New(result.ReasonCode, result.Message)
+	return result
+}
 
-	if reflect.TypeOf(originalError).String() == "*status.Error" {
-		errorMessage := originalError.Error()
-		if strings.HasPrefix(errorMessage, "rpc error:") {
+// ----------------------------------------------------------------------------
+// SzGrpcError methods
+// ----------------------------------------------------------------------------
 
-			// TODO: Improve the fragile method of pulling out the Senzing JSON error.
+// Error returns the Senzing error message, or the gRPC error message if there is no Senzing reason.
+func (szGrpcError *SzGrpcError) Error() string {
+	if szGrpcError.szError != nil {
+		return szGrpcError.szError.Error()
+	}
+	return szGrpcError.original.Error()
+}
 
-			indexOfDesc := strings.Index(errorMessage, " desc = ")
-			senzingErrorMessage := errorMessage[indexOfDesc+8:] // Implicitly safe from "0+8" because of "rpc error:" prefix.
+// GRPCStatus returns the gRPC status so that status.Code() and status.FromError() work on the converted error.
+func (szGrpcError *SzGrpcError) GRPCStatus() *status.Status {
+	return szGrpcError.grpcStatus
+}
 
-			if isJSON(senzingErrorMessage) {
+// Unwrap returns the szerror types for the reason code or gRPC code, followed by the original error.
+func (szGrpcError *SzGrpcError) Unwrap() []error {
+	result := []error{}
+	if szGrpcError.szError != nil {
+		result = append(result, szGrpcError.szError)
+	}
+	result = append(result, szGrpcError.grpcTypes...)
+	return append(result, szGrpcError.original)
+}
 
-				// TODO: Add information about any gRPC error.
-				// Status: https://pkg.go.dev/google.golang.org/grpc/status
-				// Codes: https://pkg.go.dev/google.golang.org/grpc/codes
+// ----------------------------------------------------------------------------
+// SzGrpcError private methods
+// ----------------------------------------------------------------------------
 
-				// Create a new Senzing nested error.
+// Classify an error without a Senzing reason code by its gRPC code.
+func (szGrpcError *SzGrpcError) setFromCode() {
+	for _, errorTypeID := range GrpcCodeErrorTypes[szGrpcError.GrpcCode] {
+		szGrpcError.grpcTypes = append(szGrpcError.grpcTypes, szerror.SzErrorMap[errorTypeID])
+	}
+	if szGrpcError.GrpcCode == codes.Canceled {
+		szGrpcError.grpcTypes = append(szGrpcError.grpcTypes, context.Canceled)
+	}
+}
 
-				parsedMessage, err := parser.Parse(senzingErrorMessage)
-				if err != nil {
-					return fmt.Errorf("parse(%s) error: %w; Original Error: %w", senzingErrorMessage, err, originalError)
-				}
-				reason := parsedMessage.Reason
-				if len(reason) < 10 {
-					return fmt.Errorf("len(%s) error: %w; Original Error: %w", reason, err, originalError)
-				}
-				senzingErrorCode, err := strconv.Atoi(reason[4:8])
-				if err != nil {
-					return fmt.Errorf("strconv.Atoi(%s) error %w; Original Error: %w", reason, err, originalError)
-				}
-				result = szerror.New(senzingErrorCode, senzingErrorMessage)
-			}
+// Populate Reason, ReasonCode and Message from errdetails.ErrorInfo details.
+// Returns true if a detail supplied a reason code.
+func (szGrpcError *SzGrpcError) setFromDetails() bool {
+	for _, detail := range szGrpcError.grpcStatus.Details() {
+		errorInfo, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
 		}
+		reason := errorInfo.GetMetadata()[ErrorInfoReasonKey]
+		if len(reason) == 0 {
+			reason = errorInfo.GetReason()
+		}
+		reasonCode, err := parseReasonCode(errorInfo.GetReason())
+		if err != nil {
+			reasonCode, err = parseReasonCode(reason)
+		}
+		if err != nil {
+			continue
+		}
+		szGrpcError.Reason = reason
+		szGrpcError.ReasonCode = reasonCode
+		if message, ok := errorInfo.GetMetadata()[ErrorInfoMessageKey]; ok {
+			szGrpcError.Message = message
+		}
+		return true
 	}
-	return result
+	return false
+}
+
+// Populate Reason and ReasonCode from a JSON status message.
+func (szGrpcError *SzGrpcError) setFromMessage() error {
+	reason, err := parseMessageReason(szGrpcError.Message)
+	if err != nil {
+		return err
+	}
+	reasonCode, err := parseReasonCode(reason)
+	if err != nil {
+		return err
+	}
+	szGrpcError.Reason = reason
+	szGrpcError.ReasonCode = reasonCode
+	return nil
 }
@@ -33,3 +33,25 @@ func ExampleConvertGrpcError() {
 	// Is an ErrSzNotFound
 	// Is an ErrSzBadInput
 }
+
+func ExampleSzGrpcError() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/helper/helper_test.go
+	senzingErrorMessage := `{"reason": "SENZ0033E|Unknown record"}`
+	grpcStatusError := status.Error(codes.NotFound, senzingErrorMessage)
+	err := ConvertGrpcError(grpcStatusError)
+	var szGrpcError *SzGrpcError
+	if errors.As(err, &szGrpcError) {
+		fmt.Println(szGrpcError.GrpcCode, szGrpcError.ReasonCode)
+	}
+	// Output: NotFound 33
+}
+
+func ExampleConvertGrpcError_unavailable() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/helper/helper_test.go
+	grpcStatusError := status.Error(codes.Unavailable, "connection refused") // Server cannot be reached.
+	err := ConvertGrpcError(grpcStatusError)
+	if errors.Is(err, szerror.ErrSzRetryable) {
+		fmt.Println("Is an ErrSzRetryable")
+	}
+	// Output: Is an ErrSzRetryable
+}
@@ -1,11 +1,17 @@
 package helper
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -81,3 +87,204 @@ func TestConvertGrpcError_badReasonCode(test *testing.T) {
 	actual := ConvertGrpcError(gRPCError)
 	require.Error(test, actual)
 }
+
+func TestConvertGrpcError_keepsCodes(test *testing.T) {
+	jsonMessage := `{"reason": "SENZ0033E|Unknown record"}`
+	actual := ConvertGrpcError(status.Error(codes.NotFound, jsonMessage))
+	var szGrpcError *SzGrpcError
+	require.ErrorAs(test, actual, &szGrpcError)
+	assert.Equal(test, codes.NotFound, szGrpcError.GrpcCode)
+	assert.Equal(test, 33, szGrpcError.ReasonCode)
+	assert.Equal(test, "SENZ0033E|Unknown record", szGrpcError.Reason)
+	assert.Equal(test, jsonMessage, szGrpcError.Message)
+	assert.Equal(test, codes.NotFound, status.Code(actual))
+	require.ErrorIs(test, actual, szerror.ErrSzNotFound)
+}
+
+func TestConvertGrpcError_quotedMessage(test *testing.T) {
+	jsonMessage := strconv.Quote(`{"reason": "SENZ0023E|Conflicting DATA_SOURCE values"}`)
+	actual := ConvertGrpcError(status.Error(codes.Unknown, jsonMessage))
+	require.ErrorIs(test, actual, szerror.ErrSzBadInput)
+}
+
+func TestConvertGrpcError_errorInfo(test *testing.T) {
+	grpcStatus, err := status.New(codes.InvalidArgument, "not JSON").WithDetails(&errdetails.ErrorInfo{
+		Reason: "SENZ0023",
+		Domain: "senzing.com",
+		Metadata: map[string]string{
+			ErrorInfoReasonKey:  "SENZ0023E|Conflicting DATA_SOURCE values",
+			ErrorInfoMessageKey: "Conflicting DATA_SOURCE values 'CUSTOMERS' and 'BOB'",
+		},
+	})
+	require.NoError(test, err)
+	actual := ConvertGrpcError(grpcStatus.Err())
+	var szGrpcError *SzGrpcError
+	require.ErrorAs(test, actual, &szGrpcError)
+	assert.Equal(test, codes.InvalidArgument, szGrpcError.GrpcCode)
+	assert.Equal(test, 23, szGrpcError.ReasonCode)
+	assert.Equal(test, "SENZ0023E|Conflicting DATA_SOURCE values", szGrpcError.Reason)
+	assert.Equal(test, "Conflicting DATA_SOURCE values 'CUSTOMERS' and 'BOB'", szGrpcError.Message)
+	require.ErrorIs(test, actual, szerror.ErrSzBadInput)
+}
+
+func TestConvertGrpcError_errorInfoWithoutReasonCode(test *testing.T) {
+	grpcStatus, err := status.New(codes.Unknown, `{"reason": "SENZ0033E|Unknown record"}`).WithDetails(&errdetails.ErrorInfo{
+		Reason: "QUOTA_EXCEEDED",
+	})
+	require.NoError(test, err)
+	actual := ConvertGrpcError(grpcStatus.Err())
+	require.ErrorIs(test, actual, szerror.ErrSzNotFound)
+}
+
+func TestConvertGrpcError_noReason(test *testing.T) {
+	gRPCError := status.Error(codes.Unavailable, "connection refused")
+	actual := ConvertGrpcError(gRPCError)
+	var szGrpcError *SzGrpcError
+	require.ErrorAs(test, actual, &szGrpcError)
+	assert.Equal(test, codes.Unavailable, szGrpcError.GrpcCode)
+	assert.Zero(test, szGrpcError.ReasonCode)
+	assert.Equal(test, gRPCError.Error(), actual.Error())
+	assert.NotErrorIs(test, actual, szerror.ErrSzBase)
+}
+
+func TestConvertGrpcError_notGrpc(test *testing.T) {
+	originalError := errors.New("not a gRPC error")
+	actual := ConvertGrpcError(originalError)
+	assert.Equal(test, originalError, actual)
+}
+
+func TestConvertGrpcError_wrapped(test *testing.T) {
+	gRPCError := status.Error(codes.Unknown, `{"reason": "SENZ0033E|Unknown record"}`)
+	originalError := fmt.Errorf("wrapped: %w", gRPCError)
+	actual := ConvertGrpcError(originalError)
+	require.ErrorIs(test, actual, szerror.ErrSzNotFound)
+	require.ErrorIs(test, actual, originalError)
+}
+
+func TestConvertGrpcError_transportCodes(test *testing.T) {
+	for grpcCode, errorTypeIDs := range GrpcCodeErrorTypes {
+		test.Run(grpcCode.String(), func(test *testing.T) {
+			actual := ConvertGrpcError(status.Error(grpcCode, "transport failure"))
+			for _, errorTypeID := range errorTypeIDs {
+				require.ErrorIs(test, actual, szerror.SzErrorMap[errorTypeID])
+			}
+			assert.Equal(test, grpcCode, status.Code(actual))
+		})
+	}
+}
+
+func TestConvertGrpcError_transportCodes_retryable(test *testing.T) {
+	for _, grpcCode := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted} {
+		actual := ConvertGrpcError(status.Error(grpcCode, "transport failure"))
+		require.ErrorIs(test, actual, szerror.ErrSzRetryable, grpcCode.String())
+		assert.NotErrorIs(test, actual, szerror.ErrSzUnrecoverable, grpcCode.String())
+	}
+	for _, grpcCode := range []codes.Code{codes.Unauthenticated, codes.PermissionDenied, codes.Unimplemented, codes.Internal} {
+		actual := ConvertGrpcError(status.Error(grpcCode, "transport failure"))
+		require.ErrorIs(test, actual, szerror.ErrSzUnrecoverable, grpcCode.String())
+		assert.NotErrorIs(test, actual, szerror.ErrSzRetryable, grpcCode.String())
+	}
+}
+
+func TestConvertGrpcError_transportCodes_unknown(test *testing.T) {
+	actual := ConvertGrpcError(status.Error(codes.Unknown, "transport failure"))
+	for _, szError := range szerror.SzErrorMap {
+		assert.NotErrorIs(test, actual, szError)
+	}
+}
+
+func TestConvertGrpcError_reasonCodeOverridesTransportCode(test *testing.T) {
+	actual := ConvertGrpcError(status.Error(codes.Unavailable, `{"reason": "SENZ0033E|Unknown record"}`))
+	require.ErrorIs(test, actual, szerror.ErrSzNotFound)
+	assert.NotErrorIs(test, actual, szerror.ErrSzRetryable)
+}
+
+func TestConvertGrpcError_contextErrors(test *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	actual := ConvertGrpcError(ctx.Err())
+	require.ErrorIs(test, actual, szerror.ErrSzRetryTimeoutExceeded)
+	require.ErrorIs(test, actual, szerror.ErrSzRetryable)
+	require.ErrorIs(test, actual, context.DeadlineExceeded)
+	assert.Equal(test, codes.DeadlineExceeded, status.Code(actual))
+
+	actual = ConvertGrpcError(fmt.Errorf("call abandoned: %w", context.Canceled))
+	assert.NotErrorIs(test, actual, szerror.ErrSzUnrecoverable)
+	require.ErrorIs(test, actual, context.Canceled)
+	assert.Equal(test, codes.Canceled, status.Code(actual))
+}
+
+func TestConvertGrpcError_canceled(test *testing.T) {
+	actual := ConvertGrpcError(status.Error(codes.Canceled, "context canceled"))
+	require.ErrorIs(test, actual, context.Canceled)
+	for _, szError := range szerror.SzErrorMap {
+		assert.NotErrorIs(test, actual, szError)
+	}
+}
+
+func TestParseReasonCode(test *testing.T) {
+	for reason, expected := range map[string]int{
+		"SENZ0023E|Conflicting DATA_SOURCE values": 23,
+		"SENZ7245|Default config changed":          7245,
+		"0037E|Unknown resolved entity value '-4'": 37,
+		"33":                                       33,
+	} {
+		actual, err := parseReasonCode(reason)
+		require.NoError(test, err, reason)
+		assert.Equal(test, expected, actual, reason)
+	}
+	for _, reason := range []string{"", "bad", "SENZ", "SENZabcd|bad text", "|0023"} {
+		_, err := parseReasonCode(reason)
+		require.Error(test, err, reason)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Fuzz tests
+// ----------------------------------------------------------------------------
+
+func FuzzConvertGrpcError(fuzz *testing.F) {
+	for _, testCase := range testCases {
+		fuzz.Add(uint32(testCase.gRPCCode), testCase.senzingErrorMessage)
+	}
+	for _, message := range []string{
+		"",
+		" desc = ",
+		"rpc error: code = Unknown desc = ",
+		`{"reason": "SENZ"}`,
+		`{"reason": "SENZ12"}`,
+		`{"reason": "SENZabcd | bad text"}`,
+		`{"reason": "SENZ99999999999999999999999|overflow"}`,
+		`{"reason": 23}`,
+		`{"time": 12345}`,
+		`{"reason": "SENZ0023E|ok"`,
+		`"{\"reason\": \"SENZ0023E|quoted\"}"`,
+		`[1, 2, 3]`,
+		`null`,
+		"not JSON at all",
+		"\xff\xfe",
+	} {
+		fuzz.Add(uint32(codes.Unknown), message)
+		fuzz.Add(uint32(codes.Unavailable), message)
+	}
+	fuzz.Fuzz(func(test *testing.T, code uint32, message string) {
+		grpcCode := codes.Code(code % 17)
+		if grpcCode == codes.OK {
+			grpcCode = codes.Unknown
+		}
+		originalError := status.Error(grpcCode, message)
+		actual := ConvertGrpcError(originalError)
+		require.Error(test, actual)
+		require.ErrorIs(test, actual, originalError)
+		assert.Equal(test, grpcCode, status.Code(actual))
+		assert.NotEmpty(test, actual.Error())
+		var szGrpcError *SzGrpcError
+		require.ErrorAs(test, actual, &szGrpcError)
+		assert.Equal(test, grpcCode, szGrpcError.GrpcCode)
+		assert.GreaterOrEqual(test, szGrpcError.ReasonCode, 0)
+		if len(szGrpcError.Reason) == 0 {
+			assert.Equal(test, originalError.Error(), actual.Error())
+		}
+	})
+}
@@ -1,5 +1,31 @@
 package helper
 
+import (
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+SzGrpcError is the error returned by ConvertGrpcError for a gRPC status error.
+It keeps the gRPC status code alongside the Senzing reason code, if any.
+Use errors.As() to retrieve it, and errors.Is() with szerror values to classify it.
+*/
+type SzGrpcError struct {
+	GrpcCode   codes.Code // The gRPC status code, such as codes.Unknown or codes.Unavailable.
+	Message    string     // The gRPC status message.  For Senzing errors this is a JSON document.
+	Reason     string     // The Senzing reason, such as "SENZ0023E|Conflicting DATA_SOURCE values". Empty if none.
+	ReasonCode int        // The Senzing reason code, such as 23. Zero if the error has no Senzing reason.
+	grpcStatus *status.Status
+	grpcTypes  []error
+	original   error
+	szError    error
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -7,3 +33,36 @@ package helper
 const (
 	MessageIDPrefix = "SZSDK"
 )
+
+// Keys in the Metadata of an errdetails.ErrorInfo detail recognized by ConvertGrpcError.
+const (
+	ErrorInfoMessageKey = "message"
+	ErrorInfoReasonKey  = "reason"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+/*
+GrpcCodeErrorTypes maps gRPC status codes to szerror types.
+It classifies errors that have no Senzing reason code, such as an unreachable server or an expired deadline,
+so that errors.Is(err, szerror.ErrSzRetryable) covers transport failures as well as engine failures.
+Codes not in the map, such as codes.Unknown, are not classified.
+codes.Canceled is not in the map, as a call cancelled by the client is not an engine failure;
+ConvertGrpcError makes it match context.Canceled instead.
+*/
+var GrpcCodeErrorTypes = map[codes.Code][]szerror.TypeIDs{
+	codes.Aborted:           {szerror.SzRetryable},
+	codes.DataLoss:          {szerror.SzUnhandled, szerror.SzUnrecoverable},
+	codes.DeadlineExceeded:  {szerror.SzRetryTimeoutExceeded, szerror.SzRetryable},
+	codes.Internal:          {szerror.SzUnhandled, szerror.SzUnrecoverable},
+	codes.InvalidArgument:   {szerror.SzBadInput},
+	codes.NotFound:          {szerror.SzNotFound, szerror.SzBadInput},
+	codes.OutOfRange:        {szerror.SzBadInput},
+	codes.PermissionDenied:  {szerror.SzUnrecoverable},
+	codes.ResourceExhausted: {szerror.SzRetryable},
+	codes.Unauthenticated:   {szerror.SzUnrecoverable},
+	codes.Unavailable:       {szerror.SzRetryable},
+	codes.Unimplemented:     {szerror.SzUnrecoverable},
+}
@@ -6,3 +6,6 @@ package szabstractfactory
 
 // Identfier of the szabstractfactory package found messages having the format "senzing-6020xxxx".
 const ComponentID = 6020
+
+// The address of the Senzing gRPC server used when WithGrpcAddress() is not specified.
+const DefaultGrpcAddress = "localhost:8261"
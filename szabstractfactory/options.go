@@ -0,0 +1,184 @@
+package szabstractfactory
+
+import (
+	"errors"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/tlscredentials"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Option configures the gRPC connection created by NewSzAbstractFactory.
+type Option func(*factoryOptions) error
+
+type clientInterceptors interface {
+	StreamClientInterceptor() grpc.StreamClientInterceptor
+	UnaryClientInterceptor() grpc.UnaryClientInterceptor
+}
+
+type factoryOptions struct {
+	dialOptions          []grpc.DialOption
+	grpcAddress          string
+	perRPCCredentials    credentials.PerRPCCredentials
+	streamInterceptors   []grpc.StreamClientInterceptor
+	transportCredentials credentials.TransportCredentials
+	unaryInterceptors    []grpc.UnaryClientInterceptor
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The WithDialOptions function appends gRPC dial options to those used to create the connection.
+
+Input
+  - dialOptions: Options passed to grpc.NewClient() after the options derived from other Option values.
+*/
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(options *factoryOptions) error {
+		options.dialOptions = append(options.dialOptions, dialOptions...)
+		return nil
+	}
+}
+
+/*
+The WithGrpcAddress function sets the address of the Senzing gRPC server.
+
+Input
+  - grpcAddress: A gRPC target such as "localhost:8261" or "dns:///senzing.example.com:8261".
+*/
+func WithGrpcAddress(grpcAddress string) Option {
+	return func(options *factoryOptions) error {
+		if len(grpcAddress) == 0 {
+			return errors.New("gRPC address cannot be empty")
+		}
+		options.grpcAddress = grpcAddress
+		return nil
+	}
+}
+
+/*
+The WithPerRPCCredentials function attaches credentials, such as a bearer token, to every call.
+If the credentials also provide client interceptors, as *tokencredentials.Credentials does,
+those interceptors are added so that token failures are returned as typed errors.
+
+Input
+  - perRPCCredentials: The per-RPC credentials, for example from tokencredentials.New().
+*/
+func WithPerRPCCredentials(perRPCCredentials credentials.PerRPCCredentials) Option {
+	return func(options *factoryOptions) error {
+		if perRPCCredentials == nil {
+			return errors.New("per-RPC credentials cannot be nil")
+		}
+		options.perRPCCredentials = perRPCCredentials
+		if interceptors, ok := perRPCCredentials.(clientInterceptors); ok {
+			options.unaryInterceptors = append(options.unaryInterceptors, interceptors.UnaryClientInterceptor())
+			options.streamInterceptors = append(options.streamInterceptors, interceptors.StreamClientInterceptor())
+		}
+		return nil
+	}
+}
+
+/*
+The WithStreamInterceptors function appends interceptors applied to streaming calls,
+such as those made by ExportJSONEntityReportIterator().
+Interceptors are chained in the order given.
+
+Input
+  - interceptors: The stream interceptors to add.
+*/
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(options *factoryOptions) error {
+		options.streamInterceptors = append(options.streamInterceptors, interceptors...)
+		return nil
+	}
+}
+
+/*
+The WithTLS function secures the connection with TLS, or mutual TLS if a client certificate is given.
+Certificate files are re-read when they change, so rotated certificates are used for new connections.
+All Sz objects created by the factory share the connection and therefore these credentials.
+
+Input
+  - config: The CA bundle, client certificate and key, and server name override.
+*/
+func WithTLS(config tlscredentials.Config) Option {
+	return func(options *factoryOptions) error {
+		transportCredentials, err := tlscredentials.New(config)
+		if err != nil {
+			return err
+		}
+		options.transportCredentials = transportCredentials
+		return nil
+	}
+}
+
+/*
+The WithTransportCredentials function sets the credentials used to secure the connection.
+The default is insecure.NewCredentials().
+
+Input
+  - transportCredentials: The transport credentials for the connection.
+*/
+func WithTransportCredentials(transportCredentials credentials.TransportCredentials) Option {
+	return func(options *factoryOptions) error {
+		if transportCredentials == nil {
+			return errors.New("transport credentials cannot be nil")
+		}
+		options.transportCredentials = transportCredentials
+		return nil
+	}
+}
+
+/*
+The WithUnaryInterceptors function appends interceptors applied to unary calls.
+Interceptors are chained in the order given.
+
+Input
+  - interceptors: The unary interceptors to add.
+*/
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(options *factoryOptions) error {
+		options.unaryInterceptors = append(options.unaryInterceptors, interceptors...)
+		return nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newFactoryOptions(options ...Option) (*factoryOptions, error) {
+	result := &factoryOptions{
+		grpcAddress:          DefaultGrpcAddress,
+		transportCredentials: insecure.NewCredentials(),
+	}
+	for _, option := range options {
+		if err := option(result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (options *factoryOptions) getDialOptions() []grpc.DialOption {
+	result := []grpc.DialOption{
+		grpc.WithTransportCredentials(options.transportCredentials),
+	}
+	if options.perRPCCredentials != nil {
+		result = append(result, grpc.WithPerRPCCredentials(options.perRPCCredentials))
+	}
+	if len(options.unaryInterceptors) > 0 {
+		result = append(result, grpc.WithChainUnaryInterceptor(options.unaryInterceptors...))
+	}
+	if len(options.streamInterceptors) > 0 {
+		result = append(result, grpc.WithChainStreamInterceptor(options.streamInterceptors...))
+	}
+	return append(result, options.dialOptions...)
+}
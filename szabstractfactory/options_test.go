@@ -0,0 +1,98 @@
+package szabstractfactory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/tlscredentials"
+	"github.com/senzing-garage/sz-sdk-go-grpc/tokencredentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestOptions_default(test *testing.T) {
+	options, err := newFactoryOptions()
+	require.NoError(test, err)
+	assert.Equal(test, DefaultGrpcAddress, options.grpcAddress)
+	assert.Equal(test, "insecure", options.transportCredentials.Info().SecurityProtocol)
+	assert.Len(test, options.getDialOptions(), 1)
+}
+
+func TestOptions_WithDialOptions(test *testing.T) {
+	options, err := newFactoryOptions(WithDialOptions(grpc.WithUserAgent("test"), grpc.WithAuthority("test")))
+	require.NoError(test, err)
+	assert.Len(test, options.getDialOptions(), 3)
+}
+
+func TestOptions_WithGrpcAddress(test *testing.T) {
+	options, err := newFactoryOptions(WithGrpcAddress("dns:///senzing.example.com:8261"))
+	require.NoError(test, err)
+	assert.Equal(test, "dns:///senzing.example.com:8261", options.grpcAddress)
+}
+
+func TestOptions_WithGrpcAddress_empty(test *testing.T) {
+	_, err := newFactoryOptions(WithGrpcAddress(""))
+	require.Error(test, err)
+}
+
+func TestOptions_WithInterceptors(test *testing.T) {
+	unaryInterceptor := func(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+		return invoker(ctx, method, request, reply, grpcConnection, options...)
+	}
+	streamInterceptor := func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, streamer grpc.Streamer, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(ctx, streamDesc, grpcConnection, method, options...)
+	}
+	options, err := newFactoryOptions(
+		WithUnaryInterceptors(unaryInterceptor, unaryInterceptor),
+		WithStreamInterceptors(streamInterceptor),
+	)
+	require.NoError(test, err)
+	assert.Len(test, options.unaryInterceptors, 2)
+	assert.Len(test, options.streamInterceptors, 1)
+	assert.Len(test, options.getDialOptions(), 3)
+}
+
+func TestOptions_WithPerRPCCredentials(test *testing.T) {
+	perRPCCredentials := tokencredentials.NewInsecure(tokencredentials.NewStaticToken("token"))
+	options, err := newFactoryOptions(WithPerRPCCredentials(perRPCCredentials))
+	require.NoError(test, err)
+	assert.Equal(test, perRPCCredentials, options.perRPCCredentials)
+	assert.Len(test, options.unaryInterceptors, 1)
+	assert.Len(test, options.streamInterceptors, 1)
+	assert.Len(test, options.getDialOptions(), 4)
+}
+
+func TestOptions_WithPerRPCCredentials_nil(test *testing.T) {
+	_, err := newFactoryOptions(WithPerRPCCredentials(nil))
+	require.Error(test, err)
+}
+
+func TestOptions_WithTLS(test *testing.T) {
+	options, err := newFactoryOptions(WithTLS(tlscredentials.Config{ServerName: "senzing.example.com"}))
+	require.NoError(test, err)
+	assert.Equal(test, "tls", options.transportCredentials.Info().SecurityProtocol)
+	assert.Equal(test, "senzing.example.com", options.transportCredentials.Info().ServerName)
+}
+
+func TestOptions_WithTLS_badConfig(test *testing.T) {
+	_, err := newFactoryOptions(WithTLS(tlscredentials.Config{CACertFile: "/no/such/ca.pem"}))
+	require.Error(test, err)
+}
+
+func TestOptions_WithTransportCredentials(test *testing.T) {
+	transportCredentials := insecure.NewCredentials()
+	options, err := newFactoryOptions(WithTransportCredentials(transportCredentials))
+	require.NoError(test, err)
+	assert.Equal(test, transportCredentials, options.transportCredentials)
+}
+
+func TestOptions_WithTransportCredentials_nil(test *testing.T) {
+	_, err := newFactoryOptions(WithTransportCredentials(nil))
+	require.Error(test, err)
+}
@@ -2,6 +2,7 @@ package szabstractfactory
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/senzing-garage/sz-sdk-go-grpc/szconfig"
 	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigmanager"
@@ -20,6 +21,40 @@ import (
 // Szabstractfactory is an implementation of the senzing.SzAbstractFactory interface.
 type Szabstractfactory struct {
 	GrpcConnection *grpc.ClientConn
+	ownsConnection bool
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+The NewSzAbstractFactory function creates a Szabstractfactory with its own gRPC connection.
+The connection is established lazily on the first call and is released by Close().
+
+Input
+  - ctx: A context to control lifecycle.
+  - options: Options such as WithGrpcAddress() and WithTransportCredentials().
+    Without options, an insecure connection is made to DefaultGrpcAddress.
+
+Output
+  - A Szabstractfactory that owns its gRPC connection.
+*/
+func NewSzAbstractFactory(ctx context.Context, options ...Option) (*Szabstractfactory, error) {
+	_ = ctx
+	factoryOptions, err := newFactoryOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+	grpcConnection, err := grpc.NewClient(factoryOptions.grpcAddress, factoryOptions.getDialOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("grpc.NewClient(%s) error: %w", factoryOptions.grpcAddress, err)
+	}
+	result := &Szabstractfactory{
+		GrpcConnection: grpcConnection,
+		ownsConnection: true,
+	}
+	return result, nil
 }
 
 // ----------------------------------------------------------------------------
@@ -120,3 +155,24 @@ func (factory *Szabstractfactory) CreateSzProduct(ctx context.Context) (senzing.
 	}
 	return result, nil
 }
+
+// ----------------------------------------------------------------------------
+// Public non-interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Close method closes the gRPC connection created by NewSzAbstractFactory().
+Sz objects created by the factory cannot be used after Close().
+A connection supplied by the caller in the GrpcConnection field is left open,
+as the caller remains responsible for it.
+Calling Close more than once is safe.
+*/
+func (factory *Szabstractfactory) Close() error {
+	if !factory.ownsConnection || factory.GrpcConnection == nil {
+		return nil
+	}
+	err := factory.GrpcConnection.Close()
+	factory.GrpcConnection = nil
+	factory.ownsConnection = false
+	return err
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/senzing-garage/sz-sdk-go-grpc/tlscredentials"
+	"github.com/senzing-garage/sz-sdk-go-grpc/tokencredentials"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
 )
 
@@ -73,6 +75,59 @@ func ExampleSzAbstractFactory_CreateSzProduct() {
 	// Output:
 }
 
+// ----------------------------------------------------------------------------
+// Constructor and Close - Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNewSzAbstractFactory() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szabstractfactory/szabstractfactory_examples_test.go
+	ctx := context.TODO()
+	szAbstractFactory, err := NewSzAbstractFactory(ctx, WithGrpcAddress("localhost:8261"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { handleError(szAbstractFactory.Close()) }()
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { handleError(szProduct.Destroy(ctx)) }()
+	// Output:
+}
+
+func ExampleWithPerRPCCredentials() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szabstractfactory/szabstractfactory_examples_test.go
+	ctx := context.TODO()
+	szAbstractFactory, err := NewSzAbstractFactory(ctx,
+		WithGrpcAddress("senzing.example.com:8261"),
+		WithTLS(tlscredentials.Config{
+			ServerName: "senzing.example.com",
+		}),
+		WithPerRPCCredentials(tokencredentials.New(tokencredentials.NewFileToken("/run/secrets/senzing-token"))),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { handleError(szAbstractFactory.Close()) }()
+	// Output:
+}
+
+func ExampleWithTLS() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szabstractfactory/szabstractfactory_examples_test.go
+	ctx := context.TODO()
+	szAbstractFactory, err := NewSzAbstractFactory(ctx,
+		WithGrpcAddress("senzing.example.com:8261"),
+		WithTLS(tlscredentials.Config{
+			ServerName: "senzing.example.com",
+		}),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { handleError(szAbstractFactory.Close()) }()
+	// Output:
+}
+
 // ----------------------------------------------------------------------------
 // Helper functions
 // ----------------------------------------------------------------------------
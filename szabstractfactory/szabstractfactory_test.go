@@ -3,17 +3,28 @@ package szabstractfactory
 import (
 	"context"
 	"fmt"
+	"net"
 	"testing"
 
 	truncator "github.com/aquilax/truncate"
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/tokencredentials"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 const (
 	baseCallerSkip    = 4
+	bufconnAddress    = "passthrough:///bufnet"
+	bufconnBufferSize = 1024 * 1024
 	defaultTruncation = 76
 	instanceName      = "SzAbstractFactory Test"
 	printResults      = false
@@ -86,21 +97,145 @@ func TestSzAbstractFactory_CreateSzProduct(test *testing.T) {
 }
 
 // ----------------------------------------------------------------------------
-// Internal functions
+// Constructor and Close - test
 // ----------------------------------------------------------------------------
 
-func getSzAbstractFactory(ctx context.Context) (senzing.SzAbstractFactory, error) {
-	var err error
-	var result senzing.SzAbstractFactory
-	_ = ctx
-	grpcConnection, err := grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return result, err
+func TestSzAbstractFactory_NewSzAbstractFactory(test *testing.T) {
+	ctx := context.TODO()
+	szAbstractFactory, err := NewSzAbstractFactory(ctx)
+	require.NoError(test, err)
+	defer func() { handleError(szAbstractFactory.Close()) }()
+	assert.Equal(test, DefaultGrpcAddress, szAbstractFactory.GrpcConnection.Target())
+}
+
+func TestSzAbstractFactory_NewSzAbstractFactory_badOption(test *testing.T) {
+	ctx := context.TODO()
+	_, err := NewSzAbstractFactory(ctx, WithGrpcAddress(""))
+	require.Error(test, err)
+}
+
+func TestSzAbstractFactory_NewSzAbstractFactory_withInterceptors(test *testing.T) {
+	ctx := context.TODO()
+	var unaryCalls []string
+	unaryInterceptor := func(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+		unaryCalls = append(unaryCalls, method)
+		return invoker(ctx, method, request, reply, grpcConnection, options...)
 	}
-	result = &Szabstractfactory{
+	szAbstractFactory := getBufconnSzAbstractFactory(ctx, test, WithUnaryInterceptors(unaryInterceptor))
+	defer func() { handleError(szAbstractFactory.Close()) }()
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	require.NoError(test, err)
+	actual, err := szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+	assert.Equal(test, []string{"/szproduct.SzProduct/GetVersion"}, unaryCalls)
+}
+
+func TestSzAbstractFactory_NewSzAbstractFactory_withPerRPCCredentials(test *testing.T) {
+	ctx := context.TODO()
+	szProductServer := &testSzProductServer{bearerToken: "good-token"}
+	perRPCCredentials := tokencredentials.NewInsecure(tokencredentials.NewStaticToken("good-token"))
+	szAbstractFactory := getBufconnSzAbstractFactoryWithServer(ctx, test, szProductServer, WithPerRPCCredentials(perRPCCredentials))
+	defer func() { handleError(szAbstractFactory.Close()) }()
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	require.NoError(test, err)
+	actual, err := szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+}
+
+func TestSzAbstractFactory_NewSzAbstractFactory_withPerRPCCredentials_rejected(test *testing.T) {
+	ctx := context.TODO()
+	szProductServer := &testSzProductServer{bearerToken: "good-token"}
+	perRPCCredentials := tokencredentials.NewInsecure(tokencredentials.NewStaticToken("bad-token"))
+	szAbstractFactory := getBufconnSzAbstractFactoryWithServer(ctx, test, szProductServer, WithPerRPCCredentials(perRPCCredentials))
+	defer func() { handleError(szAbstractFactory.Close()) }()
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	require.NoError(test, err)
+	_, err = szProduct.GetVersion(ctx)
+	var tokenError *tokencredentials.TokenError
+	require.ErrorAs(test, err, &tokenError)
+	require.ErrorIs(test, err, tokencredentials.ErrTokenRejected)
+}
+
+func TestSzAbstractFactory_Close(test *testing.T) {
+	ctx := context.TODO()
+	szAbstractFactory := getBufconnSzAbstractFactory(ctx, test)
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szAbstractFactory.Close())
+	assert.Nil(test, szAbstractFactory.GrpcConnection)
+	_, err = szProduct.GetVersion(ctx)
+	require.Error(test, err)
+	require.NoError(test, szAbstractFactory.Close())
+}
+
+func TestSzAbstractFactory_Close_callerOwnedConnection(test *testing.T) {
+	grpcConnection, err := grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(test, err)
+	defer func() { handleError(grpcConnection.Close()) }()
+	szAbstractFactory := &Szabstractfactory{
 		GrpcConnection: grpcConnection,
 	}
-	return result, err
+	require.NoError(test, szAbstractFactory.Close())
+	assert.Equal(test, grpcConnection, szAbstractFactory.GrpcConnection)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+const testVersion = `{"PRODUCT_NAME":"Senzing API","VERSION":"test"}`
+
+type testSzProductServer struct {
+	szproductpb.UnimplementedSzProductServer
+	bearerToken string
+}
+
+func (server *testSzProductServer) GetVersion(ctx context.Context, request *szproductpb.GetVersionRequest) (*szproductpb.GetVersionResponse, error) {
+	_ = request
+	if len(server.bearerToken) > 0 {
+		incomingMetadata, _ := metadata.FromIncomingContext(ctx)
+		authorization := incomingMetadata.Get("authorization")
+		if len(authorization) != 1 || authorization[0] != "Bearer "+server.bearerToken {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+	}
+	return &szproductpb.GetVersionResponse{Result: testVersion}, nil
+}
+
+func getBufconnSzAbstractFactory(ctx context.Context, test *testing.T, options ...Option) *Szabstractfactory {
+	return getBufconnSzAbstractFactoryWithServer(ctx, test, &testSzProductServer{}, options...)
+}
+
+func getBufconnSzAbstractFactoryWithServer(ctx context.Context, test *testing.T, szProductServer szproductpb.SzProductServer, options ...Option) *Szabstractfactory {
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := grpc.NewServer()
+	szproductpb.RegisterSzProductServer(grpcServer, szProductServer)
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+	options = append([]Option{
+		WithGrpcAddress(bufconnAddress),
+		WithDialOptions(grpc.WithContextDialer(dialer)),
+	}, options...)
+	result, err := NewSzAbstractFactory(ctx, options...)
+	require.NoError(test, err)
+	return result
+}
+
+func getSzAbstractFactory(ctx context.Context) (senzing.SzAbstractFactory, error) {
+	if fakeserver.Selected() {
+		server, err := fakeserver.Shared()
+		if err != nil {
+			return nil, err
+		}
+		return NewSzAbstractFactory(ctx, WithGrpcAddress(fakeserver.Address), WithDialOptions(server.DialOptions()...))
+	}
+	return NewSzAbstractFactory(ctx, WithGrpcAddress(grpcAddress))
 }
 
 func getTestObject(ctx context.Context, test *testing.T) senzing.SzAbstractFactory {
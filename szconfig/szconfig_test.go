@@ -7,6 +7,7 @@ import (
 
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
 	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szerror"
@@ -346,7 +347,11 @@ func TestSzconfig_Destroy_withObserver(test *testing.T) {
 func getGrpcConnection() *grpc.ClientConn {
 	var err error
 	if grpcConnection == nil {
-		grpcConnection, err = grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if fakeserver.Selected() {
+			grpcConnection, err = fakeserver.NewSharedClient()
+		} else {
+			grpcConnection, err = grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		}
 		if err != nil {
 			fmt.Printf("Did not connect: %v\n", err)
 		}
@@ -0,0 +1,15 @@
+/*
+The szconfigcomment package writes and parses structured comments of Senzing configurations.
+
+SzConfigManager.AddConfig() takes a free-text comment.
+A Comment records who registered a configuration and why: the author, the tool, the revision,
+such as the git commit of a deployment repository, a summary of the change, and the parent configuration.
+Its String() method encodes it as a JSON object, the comment passed to AddConfig,
+and Parse() decodes the comments listed by SzConfigManager.GetConfigs():
+
+	{"author":"jane","tool":"sz-grpc 0.8.0","revision":"3f2a9c1","summary":"Added data sources CUSTOMERS","parentConfigId":4015529876}
+
+Comments written otherwise, such as "Default configuration", are not structured; Parse returns ErrNotStructured for them.
+szconfighistory.Query() filters the history of configurations by the fields of their comments.
+*/
+package szconfigcomment
@@ -0,0 +1,25 @@
+package szconfigcomment
+
+import (
+	"errors"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Comment is a structured comment of a configuration.
+type Comment struct {
+	Author         string `json:"author,omitempty"`         // Who made the change.
+	Tool           string `json:"tool,omitempty"`           // What made the change, with its version, such as "sz-grpc 0.8.0".
+	Revision       string `json:"revision,omitempty"`       // The revision the change came from, such as a git commit hash.
+	Summary        string `json:"summary"`                  // What changed, and why.
+	ParentConfigID int64  `json:"parentConfigId,omitempty"` // The configuration the change was made to.
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrNotStructured is returned by Parse for comments that are not structured comments.
+var ErrNotStructured = errors.New("not a structured configuration comment")
@@ -0,0 +1,55 @@
+package szconfigcomment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Parse function decodes a structured comment.
+
+Input
+  - configComment: The comment of a configuration, such as the CONFIG_COMMENTS listed by SzConfigManager.GetConfigs().
+
+Output
+  - The comment.  An error matching ErrNotStructured if it is not a JSON object of Comment fields with a summary.
+*/
+func Parse(configComment string) (Comment, error) {
+	result := Comment{}
+	if !strings.HasPrefix(strings.TrimSpace(configComment), "{") {
+		return result, ErrNotStructured
+	}
+	decoder := json.NewDecoder(strings.NewReader(configComment))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return Comment{}, fmt.Errorf("%w: %w", ErrNotStructured, err)
+	}
+	if decoder.More() {
+		return Comment{}, fmt.Errorf("%w: text after the comment", ErrNotStructured)
+	}
+	if len(strings.TrimSpace(result.Summary)) == 0 {
+		return Comment{}, fmt.Errorf("%w: no summary", ErrNotStructured)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Comment methods
+// ----------------------------------------------------------------------------
+
+// The String method encodes the comment as a JSON object, to be passed to SzConfigManager.AddConfig().
+func (comment Comment) String() string {
+	result := &bytes.Buffer{}
+	encoder := json.NewEncoder(result)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(comment); err != nil {
+		panic(err) // Strings and integers always encode.
+	}
+	return strings.TrimSuffix(result.String(), "\n")
+}
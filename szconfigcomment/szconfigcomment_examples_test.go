@@ -0,0 +1,36 @@
+package szconfigcomment
+
+import (
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleComment_String() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szconfigcomment/szconfigcomment_examples_test.go
+	comment := Comment{
+		Author:         "jane",
+		Tool:           "deploy-job 1.4.0",
+		Revision:       "3f2a9c1",
+		Summary:        "Added data sources CUSTOMERS",
+		ParentConfigID: 4015529876,
+	}
+	fmt.Println(comment)
+	// Output: {"author":"jane","tool":"deploy-job 1.4.0","revision":"3f2a9c1","summary":"Added data sources CUSTOMERS","parentConfigId":4015529876}
+}
+
+func ExampleParse() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szconfigcomment/szconfigcomment_examples_test.go
+	comment, err := Parse(`{"author":"jane","summary":"Added data sources CUSTOMERS","parentConfigId":4015529876}`)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(comment.Author, comment.ParentConfigID)
+	_, err = Parse("Default configuration")
+	fmt.Println(err)
+	// Output:
+	// jane 4015529876
+	// not a structured configuration comment
+}
@@ -0,0 +1,56 @@
+package szconfigcomment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestComment_String(test *testing.T) {
+	comment := Comment{
+		Author:         "jane",
+		Tool:           "sz-grpc 0.8.0",
+		Revision:       "3f2a9c1",
+		Summary:        "Added data sources CUSTOMERS & WATCHLIST",
+		ParentConfigID: 4015529876,
+	}
+	expected := `{"author":"jane","tool":"sz-grpc 0.8.0","revision":"3f2a9c1","summary":"Added data sources CUSTOMERS & WATCHLIST","parentConfigId":4015529876}`
+	assert.Equal(test, expected, comment.String())
+	assert.Equal(test, `{"summary":"Rollback"}`, Comment{Summary: "Rollback"}.String())
+}
+
+func TestParse(test *testing.T) {
+	comment := Comment{Author: "jane", Summary: "Added data sources CUSTOMERS", ParentConfigID: 42}
+	actual, err := Parse(comment.String())
+	require.NoError(test, err)
+	assert.Equal(test, comment, actual)
+	actual, err = Parse(` {"summary": "Indented"} `)
+	require.NoError(test, err)
+	assert.Equal(test, Comment{Summary: "Indented"}, actual)
+}
+
+func TestParse_notStructured(test *testing.T) {
+	testCases := []struct {
+		name          string
+		configComment string
+	}{
+		{name: "empty", configComment: ""},
+		{name: "noSummary", configComment: `{"author": "jane"}`},
+		{name: "text", configComment: "Default configuration"},
+		{name: "textAfter", configComment: `{"summary": "Added"} and more`},
+		{name: "truncated", configComment: `{"summary": "Added`},
+		{name: "unknownField", configComment: `{"summary": "Added", "ticket": "OPS-1"}`},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			actual, err := Parse(testCase.configComment)
+			require.ErrorIs(test, err, ErrNotStructured)
+			assert.Equal(test, Comment{}, actual)
+		})
+	}
+}
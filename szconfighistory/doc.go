@@ -0,0 +1,15 @@
+/*
+The szconfighistory package browses the configurations registered with SzConfigManager,
+compares them, and rolls the default configuration back to an earlier one.
+
+History() lists the registered configurations, oldest first, as szmodel.ConfigEntry values,
+marking the default configuration.
+Query() lists the configurations selected by a Filter on the fields of their structured comments,
+see the szconfigcomment package, and on their creation dates, to answer who changed what, and when.
+Diff() compares two registered configurations by the codes of their data sources (CFG_DSRC),
+feature types (CFG_FTYPE) and attributes (CFG_ATTR): the codes added, deleted, and changed.
+Rollback() registers a copy of an earlier configuration with a structured comment giving the reason,
+and makes it the default with SzConfigManager.ReplaceDefaultConfigID(),
+so the rollback fails rather than overwrite a default configuration changed meanwhile.
+*/
+package szconfighistory
@@ -0,0 +1,64 @@
+package szconfighistory
+
+import (
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// ConfigDiff lists the differences between two configurations.
+type ConfigDiff struct {
+	FromConfigID int64       `json:"FROM_CONFIG_ID"`
+	ToConfigID   int64       `json:"TO_CONFIG_ID"`
+	DataSources  SectionDiff `json:"DATA_SOURCES"` // CFG_DSRC, by DSRC_CODE.
+	Features     SectionDiff `json:"FEATURES"`     // CFG_FTYPE, by FTYPE_CODE.
+	Attributes   SectionDiff `json:"ATTRIBUTES"`   // CFG_ATTR, by ATTR_CODE.
+}
+
+// Entry is a registered configuration.
+type Entry struct {
+	szmodel.ConfigEntry
+	IsDefault bool `json:"IS_DEFAULT"`
+}
+
+/*
+Filter selects configurations by their structured comments and creation dates, for Query.
+Zero fields select every configuration.
+A comment that is not structured has only a summary, its text.
+*/
+type Filter struct {
+	Author         string    // The author, ignoring case.
+	Tool           string    // The start of the tool, such as "sz-grpc" for "sz-grpc 0.8.0".
+	Revision       string    // The start of the revision, such as an abbreviated git commit hash.
+	Summary        string    // Text in the summary, ignoring case.
+	ParentConfigID int64     // The parent configuration.
+	Since          time.Time // The earliest creation date.
+	Until          time.Time // The creation date that configurations were created before.
+}
+
+// SectionDiff lists the codes of a configuration section that differ, in alphabetical order.
+type SectionDiff struct {
+	Added   []string `json:"ADDED"`
+	Deleted []string `json:"DELETED"`
+	Changed []string `json:"CHANGED"` // Codes in both configurations, with different definitions.
+}
+
+// A configuration section compared by Diff, and the field that identifies its rows.
+type section struct {
+	name      string
+	codeField string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	attributeSection  = section{name: "CFG_ATTR", codeField: "ATTR_CODE"}
+	dataSourceSection = section{name: "CFG_DSRC", codeField: "DSRC_CODE"}
+	featureSection    = section{name: "CFG_FTYPE", codeField: "FTYPE_CODE"}
+)
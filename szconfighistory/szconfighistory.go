@@ -0,0 +1,343 @@
+package szconfighistory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigcomment"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Diff function compares two registered configurations.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: Reads the configurations.
+  - fromConfigID: The configuration compared from, usually the earlier one.
+  - toConfigID: The configuration compared to.
+
+Output
+  - What was added, deleted and changed from the first configuration to the second.
+*/
+func Diff(ctx context.Context, szConfigManager senzing.SzConfigManager, fromConfigID int64, toConfigID int64) (*ConfigDiff, error) {
+	fromDefinition, err := szConfigManager.GetConfig(ctx, fromConfigID)
+	if err != nil {
+		return nil, err
+	}
+	toDefinition, err := szConfigManager.GetConfig(ctx, toConfigID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := diffDefinitions(fromDefinition, toDefinition)
+	if err != nil {
+		return nil, err
+	}
+	result.FromConfigID = fromConfigID
+	result.ToConfigID = toConfigID
+	return result, nil
+}
+
+/*
+The History function lists the registered configurations, oldest first.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: Lists the configurations.
+
+Output
+  - The configurations.  The default configuration is marked IsDefault.
+*/
+func History(ctx context.Context, szConfigManager senzing.SzConfigManager) ([]Entry, error) {
+	configs, err := getConfigs(ctx, szConfigManager)
+	if err != nil {
+		return nil, err
+	}
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Entry, 0, len(configs.Configs))
+	for _, configEntry := range configs.Configs {
+		result = append(result, Entry{
+			ConfigEntry: configEntry,
+			IsDefault:   configEntry.ConfigID == defaultConfigID,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].SysCreateDate < result[j].SysCreateDate
+	})
+	return result, nil
+}
+
+/*
+The Query function lists the registered configurations selected by a filter, oldest first.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: Lists the configurations.
+  - filter: Selects configurations by the fields of their structured comments and their creation dates.
+
+Output
+  - The configurations selected.  The default configuration is marked IsDefault.
+*/
+func Query(ctx context.Context, szConfigManager senzing.SzConfigManager, filter Filter) ([]Entry, error) {
+	history, err := History(ctx, szConfigManager)
+	if err != nil {
+		return nil, err
+	}
+	result := []Entry{}
+	for _, entry := range history {
+		selected, err := filter.selects(entry)
+		if err != nil {
+			return nil, err
+		}
+		if selected {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+/*
+The Rollback function makes an earlier configuration the default again.
+It registers a copy of the configuration with a structured comment,
+whose summary gives the reason, such as "Rollback to configuration 42: bad matching",
+and whose parent is the default configuration being replaced.
+Then it replaces the default configuration with the copy.
+
+The server identifies configurations by their definition, so the copy records the configuration rolled back to
+and the one replaced in a SZ_GRPC_ROLLBACK object beside G2_CONFIG.
+A second rollback to the same configuration from the same default reuses the first copy, with its comment.
+
+Input
+  - ctx: A context to control lifecycle.
+  - szConfigManager: Registers the copy and replaces the default.
+  - configID: The configuration rolled back to.
+  - comment: Who rolled back, and why, as the Summary.
+
+Output
+  - The ID of the copy, now the default configuration.
+    Fails if the default configuration changed during the rollback.
+*/
+func Rollback(ctx context.Context, szConfigManager senzing.SzConfigManager, configID int64, comment szconfigcomment.Comment) (int64, error) {
+	reason := strings.TrimSpace(comment.Summary)
+	if len(reason) == 0 {
+		return 0, errors.New("the summary of the comment, the reason, cannot be empty")
+	}
+	currentConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	configDefinition, err := szConfigManager.GetConfig(ctx, configID)
+	if err != nil {
+		return 0, err
+	}
+	copyDefinition, err := markRollback(configDefinition, configID, currentConfigID)
+	if err != nil {
+		return 0, err
+	}
+	comment.Summary = fmt.Sprintf("Rollback to configuration %d: %s", configID, reason)
+	comment.ParentConfigID = currentConfigID
+	newConfigID, err := szConfigManager.AddConfig(ctx, copyDefinition, comment.String())
+	if err != nil {
+		return 0, err
+	}
+	return newConfigID, szConfigManager.ReplaceDefaultConfigID(ctx, currentConfigID, newConfigID)
+}
+
+// ----------------------------------------------------------------------------
+// ConfigDiff methods
+// ----------------------------------------------------------------------------
+
+// The IsEmpty method reports whether the configurations have the same data sources, feature types and attributes.
+func (diff *ConfigDiff) IsEmpty() bool {
+	return diff.DataSources.IsEmpty() && diff.Features.IsEmpty() && diff.Attributes.IsEmpty()
+}
+
+// ----------------------------------------------------------------------------
+// Entry methods
+// ----------------------------------------------------------------------------
+
+/*
+The Comment method parses the comment of the configuration.
+A comment that is not structured is returned as the Summary, and false.
+*/
+func (entry Entry) Comment() (szconfigcomment.Comment, bool) {
+	result, err := szconfigcomment.Parse(entry.ConfigComments)
+	if err != nil {
+		return szconfigcomment.Comment{Summary: entry.ConfigComments}, false
+	}
+	return result, true
+}
+
+// ----------------------------------------------------------------------------
+// SectionDiff methods
+// ----------------------------------------------------------------------------
+
+// The IsEmpty method reports whether nothing was added, deleted or changed.
+func (sectionDiff SectionDiff) IsEmpty() bool {
+	return len(sectionDiff.Added) == 0 && len(sectionDiff.Deleted) == 0 && len(sectionDiff.Changed) == 0
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Whether the filter selects a configuration.  Fails if a date is filtered and the creation date cannot be parsed.
+func (filter Filter) selects(entry Entry) (bool, error) {
+	comment, _ := entry.Comment()
+	switch {
+	case len(filter.Author) > 0 && !strings.EqualFold(comment.Author, filter.Author),
+		!strings.HasPrefix(comment.Tool, filter.Tool),
+		!strings.HasPrefix(comment.Revision, filter.Revision),
+		!strings.Contains(strings.ToLower(comment.Summary), strings.ToLower(filter.Summary)),
+		filter.ParentConfigID != 0 && comment.ParentConfigID != filter.ParentConfigID:
+		return false, nil
+	case filter.Since.IsZero() && filter.Until.IsZero():
+		return true, nil
+	}
+	createTime, err := entry.CreateTime()
+	if err != nil {
+		return false, err
+	}
+	return !createTime.Before(filter.Since) && (filter.Until.IsZero() || createTime.Before(filter.Until)), nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Compare two configuration definitions, as returned by SzConfigManager.GetConfig.
+func diffDefinitions(fromDefinition string, toDefinition string) (*ConfigDiff, error) {
+	from, err := parseSections(fromDefinition)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseSections(toDefinition)
+	if err != nil {
+		return nil, err
+	}
+	result := &ConfigDiff{}
+	for _, item := range []struct {
+		section section
+		diff    *SectionDiff
+	}{
+		{section: dataSourceSection, diff: &result.DataSources},
+		{section: featureSection, diff: &result.Features},
+		{section: attributeSection, diff: &result.Attributes},
+	} {
+		*item.diff, err = diffSection(item.section, from[item.section.name], to[item.section.name])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Compare the rows of a section, by code.
+func diffSection(section section, fromRows json.RawMessage, toRows json.RawMessage) (SectionDiff, error) {
+	result := SectionDiff{Added: []string{}, Deleted: []string{}, Changed: []string{}}
+	from, err := rowsByCode(section, fromRows)
+	if err != nil {
+		return result, err
+	}
+	to, err := rowsByCode(section, toRows)
+	if err != nil {
+		return result, err
+	}
+	for code, toRow := range to {
+		fromRow, ok := from[code]
+		switch {
+		case !ok:
+			result.Added = append(result.Added, code)
+		case fromRow != toRow:
+			result.Changed = append(result.Changed, code)
+		}
+	}
+	for code := range from {
+		if _, ok := to[code]; !ok {
+			result.Deleted = append(result.Deleted, code)
+		}
+	}
+	sort.Strings(result.Added)
+	sort.Strings(result.Deleted)
+	sort.Strings(result.Changed)
+	return result, nil
+}
+
+func getConfigs(ctx context.Context, szConfigManager senzing.SzConfigManager) (*szmodel.ConfigsResponse, error) {
+	configs, err := szConfigManager.GetConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.ConfigsResponse](configs)
+}
+
+/*
+The object added to a configuration definition by Rollback, so that the copy differs from the configuration
+rolled back to.  It is outside G2_CONFIG, which holds the configuration itself.
+*/
+const rollbackKey = "SZ_GRPC_ROLLBACK"
+
+// A copy of a configuration definition marked as a rollback to configID from parentConfigID.
+func markRollback(configDefinition string, configID int64, parentConfigID int64) (string, error) {
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(configDefinition), &document); err != nil {
+		return "", fmt.Errorf("cannot parse configuration: %w", err)
+	}
+	marker, err := json.Marshal(map[string]int64{"CONFIG_ID": configID, "PARENT_CONFIG_ID": parentConfigID})
+	if err != nil {
+		return "", err
+	}
+	document[rollbackKey] = marker
+	result, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// The sections of the G2_CONFIG object of a configuration definition.
+func parseSections(configDefinition string) (map[string]json.RawMessage, error) {
+	document := struct {
+		G2Config map[string]json.RawMessage `json:"G2_CONFIG"`
+	}{}
+	if err := json.Unmarshal([]byte(configDefinition), &document); err != nil {
+		return nil, fmt.Errorf("cannot parse configuration: %w", err)
+	}
+	return document.G2Config, nil
+}
+
+// The rows of a section as canonical JSON, by code.  A missing section has no rows.
+func rowsByCode(section section, rows json.RawMessage) (map[string]string, error) {
+	result := map[string]string{}
+	if len(rows) == 0 {
+		return result, nil
+	}
+	decoded := []map[string]any{}
+	if err := json.Unmarshal(rows, &decoded); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", section.name, err)
+	}
+	for _, row := range decoded {
+		code, ok := row[section.codeField].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s row without %s: %v", section.name, section.codeField, row)
+		}
+		canonical, err := json.Marshal(row) // Keys are sorted.
+		if err != nil {
+			return nil, err
+		}
+		result[code] = string(canonical)
+	}
+	return result, nil
+}
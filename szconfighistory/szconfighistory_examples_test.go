@@ -0,0 +1,72 @@
+package szconfighistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleDiff() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szconfighistory/szconfighistory_examples_test.go
+	ctx := context.TODO()
+	server, err := fakeserver.New()
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	factory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithGrpcAddress(fakeserver.Address),
+		szabstractfactory.WithDialOptions(server.DialOptions()...),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = factory.Close() }()
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	szConfig, err := factory.CreateSzConfig(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	configID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	configDefinition, err := szConfigManager.GetConfig(ctx, configID)
+	if err != nil {
+		fmt.Println(err)
+	}
+	configHandle, err := szConfig.ImportConfig(ctx, configDefinition)
+	if err != nil {
+		fmt.Println(err)
+	}
+	_, _ = szConfig.AddDataSource(ctx, configHandle, "CUSTOMERS")
+	_ = szConfig.DeleteDataSource(ctx, configHandle, "TEST")
+	newConfigDefinition, err := szConfig.ExportConfig(ctx, configHandle)
+	if err != nil {
+		fmt.Println(err)
+	}
+	newConfigID, err := szConfigManager.AddConfig(ctx, newConfigDefinition, "Replace TEST with CUSTOMERS")
+	if err != nil {
+		fmt.Println(err)
+	}
+	diff, err := Diff(ctx, szConfigManager, configID, newConfigID)
+	if err != nil {
+		fmt.Println(err)
+	}
+	dataSources, err := json.Marshal(diff.DataSources)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(string(dataSources))
+	// Output: {"ADDED":["CUSTOMERS"],"DELETED":["TEST"],"CHANGED":[]}
+}
@@ -0,0 +1,261 @@
+package szconfighistory
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigcomment"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestDiff(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager, szConfig := getTestObjects(ctx, test)
+	baseConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	configID := addConfig(ctx, test, szConfigManager, szConfig, baseConfigID, "CUSTOMERS")
+	actual, err := Diff(ctx, szConfigManager, baseConfigID, configID)
+	require.NoError(test, err)
+	expected := &ConfigDiff{
+		FromConfigID: baseConfigID,
+		ToConfigID:   configID,
+		DataSources:  SectionDiff{Added: []string{"CUSTOMERS"}, Deleted: []string{}, Changed: []string{}},
+		Features:     SectionDiff{Added: []string{}, Deleted: []string{}, Changed: []string{}},
+		Attributes:   SectionDiff{Added: []string{}, Deleted: []string{}, Changed: []string{}},
+	}
+	assert.Equal(test, expected, actual)
+	assert.False(test, actual.IsEmpty())
+	_, err = Diff(ctx, szConfigManager, baseConfigID, 1)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+}
+
+func TestDiffDefinitions(test *testing.T) {
+	from := `{"G2_CONFIG": {
+		"CFG_ATTR": [{"ATTR_ID": 1001, "ATTR_CODE": "DATA_SOURCE"}, {"ATTR_ID": 1003, "ATTR_CODE": "RECORD_ID"}],
+		"CFG_DSRC": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}],
+		"CFG_FTYPE": [{"FTYPE_ID": 1, "FTYPE_CODE": "NAME", "FTYPE_FREQ": "NAME"}, {"FTYPE_ID": 2, "FTYPE_CODE": "DOB"}]
+	}}`
+	to := `{"G2_CONFIG": {
+		"CFG_ATTR": [{"ATTR_CODE": "DATA_SOURCE", "ATTR_ID": 1001}],
+		"CFG_DSRC": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 1001, "DSRC_CODE": "CUSTOMERS"}],
+		"CFG_FTYPE": [{"FTYPE_ID": 1, "FTYPE_CODE": "NAME", "FTYPE_FREQ": "FF"}, {"FTYPE_ID": 2, "FTYPE_CODE": "DOB"}, {"FTYPE_ID": 3, "FTYPE_CODE": "EMAIL"}]
+	}}`
+	actual, err := diffDefinitions(from, to)
+	require.NoError(test, err)
+	assert.Equal(test, SectionDiff{Added: []string{"CUSTOMERS"}, Deleted: []string{}, Changed: []string{}}, actual.DataSources)
+	assert.Equal(test, SectionDiff{Added: []string{"EMAIL"}, Deleted: []string{}, Changed: []string{"NAME"}}, actual.Features)
+	assert.Equal(test, SectionDiff{Added: []string{}, Deleted: []string{"RECORD_ID"}, Changed: []string{}}, actual.Attributes)
+	_, err = diffDefinitions(from, `{"G2_CONFIG": {"CFG_DSRC": [{"DSRC_ID": 1}]}}`)
+	require.ErrorContains(test, err, "DSRC_CODE")
+	_, err = diffDefinitions(from, `{`)
+	require.Error(test, err)
+}
+
+func TestHistory(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager, szConfig := getTestObjects(ctx, test)
+	baseConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	configID := addConfig(ctx, test, szConfigManager, szConfig, baseConfigID, "CUSTOMERS")
+	actual, err := History(ctx, szConfigManager)
+	require.NoError(test, err)
+	require.Len(test, actual, 2)
+	entries := map[int64]Entry{}
+	for _, entry := range actual {
+		entries[entry.ConfigID] = entry
+	}
+	assert.True(test, entries[baseConfigID].IsDefault)
+	assert.False(test, entries[configID].IsDefault)
+	assert.Equal(test, "Add CUSTOMERS", entries[configID].ConfigComments)
+	assert.LessOrEqual(test, actual[0].SysCreateDate, actual[1].SysCreateDate)
+}
+
+func TestEntry_Comment(test *testing.T) {
+	actual, structured := Entry{ConfigEntry: szmodel.ConfigEntry{ConfigComments: `{"author":"tester","summary":"Add CUSTOMERS"}`}}.Comment()
+	assert.True(test, structured)
+	assert.Equal(test, szconfigcomment.Comment{Author: "tester", Summary: "Add CUSTOMERS"}, actual)
+	actual, structured = Entry{ConfigEntry: szmodel.ConfigEntry{ConfigComments: "Add CUSTOMERS"}}.Comment()
+	assert.False(test, structured)
+	assert.Equal(test, szconfigcomment.Comment{Summary: "Add CUSTOMERS"}, actual)
+}
+
+func TestQuery(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager, szConfig := getTestObjects(ctx, test)
+	baseConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	customersConfigID := addConfig(ctx, test, szConfigManager, szConfig, baseConfigID, "CUSTOMERS")
+	comment := szconfigcomment.Comment{
+		Author:         "tester",
+		Tool:           "loader 1.2.0",
+		Revision:       "0123456789abcdef",
+		Summary:        "Add WATCHLIST for screening",
+		ParentConfigID: customersConfigID,
+	}
+	watchlistConfigID := addCommentedConfig(ctx, test, szConfigManager, szConfig, customersConfigID, comment.String(), "WATCHLIST")
+	testCases := []struct {
+		name     string
+		filter   Filter
+		expected []int64
+	}{
+		{name: "all", filter: Filter{}, expected: []int64{baseConfigID, customersConfigID, watchlistConfigID}},
+		{name: "author", filter: Filter{Author: "TESTER"}, expected: []int64{watchlistConfigID}},
+		{name: "author-unknown", filter: Filter{Author: "test"}, expected: []int64{}},
+		{name: "tool", filter: Filter{Tool: "loader"}, expected: []int64{watchlistConfigID}},
+		{name: "revision", filter: Filter{Revision: "0123456"}, expected: []int64{watchlistConfigID}},
+		{name: "summary", filter: Filter{Summary: "customers"}, expected: []int64{customersConfigID}},
+		{name: "summary-add", filter: Filter{Summary: "add"}, expected: []int64{customersConfigID, watchlistConfigID}},
+		{name: "parent", filter: Filter{ParentConfigID: customersConfigID}, expected: []int64{watchlistConfigID}},
+		{name: "since", filter: Filter{Since: time.Now().Add(-time.Hour)}, expected: []int64{baseConfigID, customersConfigID, watchlistConfigID}},
+		{name: "until", filter: Filter{Until: time.Now().Add(-time.Hour)}, expected: []int64{}},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			actual, err := Query(ctx, szConfigManager, testCase.filter)
+			require.NoError(test, err)
+			configIDs := []int64{}
+			for _, entry := range actual {
+				configIDs = append(configIDs, entry.ConfigID)
+			}
+			assert.ElementsMatch(test, testCase.expected, configIDs)
+		})
+	}
+}
+
+func TestRollback(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager, szConfig := getTestObjects(ctx, test)
+	baseConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	configID := addConfig(ctx, test, szConfigManager, szConfig, baseConfigID, "CUSTOMERS")
+	require.NoError(test, szConfigManager.SetDefaultConfigID(ctx, configID))
+	rollbackConfigID, err := Rollback(ctx, szConfigManager, baseConfigID, szconfigcomment.Comment{Author: "tester", Summary: "CUSTOMERS is not ready"})
+	require.NoError(test, err)
+	assert.NotEqual(test, baseConfigID, rollbackConfigID)
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, rollbackConfigID, defaultConfigID)
+	diff, err := Diff(ctx, szConfigManager, baseConfigID, rollbackConfigID)
+	require.NoError(test, err)
+	assert.True(test, diff.IsEmpty())
+	history, err := History(ctx, szConfigManager)
+	require.NoError(test, err)
+	comments := []szconfigcomment.Comment{}
+	for _, entry := range history {
+		comment, _ := entry.Comment()
+		comments = append(comments, comment)
+	}
+	expected := szconfigcomment.Comment{
+		Author:         "tester",
+		Summary:        "Rollback to configuration " + formatID(baseConfigID) + ": CUSTOMERS is not ready",
+		ParentConfigID: configID,
+	}
+	assert.Contains(test, comments, expected)
+	againConfigID, err := Rollback(ctx, szConfigManager, baseConfigID, szconfigcomment.Comment{Summary: "again"})
+	require.NoError(test, err)
+	assert.NotEqual(test, rollbackConfigID, againConfigID)
+}
+
+func TestRollback_sameDefault(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager, szConfig := getTestObjects(ctx, test)
+	baseConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	configID := addConfig(ctx, test, szConfigManager, szConfig, baseConfigID, "CUSTOMERS")
+	require.NoError(test, szConfigManager.SetDefaultConfigID(ctx, configID))
+	rollbackConfigID, err := Rollback(ctx, szConfigManager, baseConfigID, szconfigcomment.Comment{Summary: "first"})
+	require.NoError(test, err)
+	require.NoError(test, szConfigManager.SetDefaultConfigID(ctx, configID))
+	againConfigID, err := Rollback(ctx, szConfigManager, baseConfigID, szconfigcomment.Comment{Summary: "second"})
+	require.NoError(test, err)
+	assert.Equal(test, rollbackConfigID, againConfigID)
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, rollbackConfigID, defaultConfigID)
+}
+
+func TestRollback_badReason(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager, _ := getTestObjects(ctx, test)
+	_, err := Rollback(ctx, szConfigManager, 1, szconfigcomment.Comment{Author: "tester", Summary: " "})
+	require.Error(test, err)
+}
+
+func TestMarkRollback(test *testing.T) {
+	configDefinition := `{"G2_CONFIG": {"CFG_DSRC": []}}`
+	actual, err := markRollback(configDefinition, 1, 2)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"G2_CONFIG": {"CFG_DSRC": []}, "SZ_GRPC_ROLLBACK": {"CONFIG_ID": 1, "PARENT_CONFIG_ID": 2}}`, actual)
+	again, err := markRollback(actual, 1, 3)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"G2_CONFIG": {"CFG_DSRC": []}, "SZ_GRPC_ROLLBACK": {"CONFIG_ID": 1, "PARENT_CONFIG_ID": 3}}`, again)
+	_, err = markRollback("{", 1, 2)
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Register a copy of a configuration with data sources added.  Returns its ID.
+func addConfig(ctx context.Context, test *testing.T, szConfigManager senzing.SzConfigManager, szConfig senzing.SzConfig, configID int64, dataSourceCodes ...string) int64 {
+	test.Helper()
+	comment := "Add"
+	for _, dataSourceCode := range dataSourceCodes {
+		comment += " " + dataSourceCode
+	}
+	return addCommentedConfig(ctx, test, szConfigManager, szConfig, configID, comment, dataSourceCodes...)
+}
+
+// Register a copy of a configuration with data sources added and a comment.  Returns its ID.
+func addCommentedConfig(ctx context.Context, test *testing.T, szConfigManager senzing.SzConfigManager, szConfig senzing.SzConfig, configID int64, comment string, dataSourceCodes ...string) int64 {
+	test.Helper()
+	configDefinition, err := szConfigManager.GetConfig(ctx, configID)
+	require.NoError(test, err)
+	configHandle, err := szConfig.ImportConfig(ctx, configDefinition)
+	require.NoError(test, err)
+	defer func() { _ = szConfig.CloseConfig(ctx, configHandle) }()
+	for _, dataSourceCode := range dataSourceCodes {
+		_, err = szConfig.AddDataSource(ctx, configHandle, dataSourceCode)
+		require.NoError(test, err)
+	}
+	configDefinition, err = szConfig.ExportConfig(ctx, configHandle)
+	require.NoError(test, err)
+	result, err := szConfigManager.AddConfig(ctx, configDefinition, comment)
+	require.NoError(test, err)
+	return result
+}
+
+func formatID(configID int64) string {
+	return strconv.FormatInt(configID, 10)
+}
+
+func getTestObjects(ctx context.Context, test *testing.T) (senzing.SzConfigManager, senzing.SzConfig) {
+	test.Helper()
+	server, err := fakeserver.New()
+	require.NoError(test, err)
+	test.Cleanup(server.Close)
+	factory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithGrpcAddress(fakeserver.Address),
+		szabstractfactory.WithDialOptions(server.DialOptions()...),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = factory.Close() })
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	require.NoError(test, err)
+	szConfig, err := factory.CreateSzConfig(ctx)
+	require.NoError(test, err)
+	return szConfigManager, szConfig
+}
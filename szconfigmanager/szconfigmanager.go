@@ -14,6 +14,7 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
 	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
 	"github.com/senzing-garage/sz-sdk-go/szconfigmanager"
 	szpb "github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager"
 )
@@ -380,6 +381,27 @@ func (client *Szconfigmanager) UnregisterObserver(ctx context.Context, observer
 	return err
 }
 
+// ----------------------------------------------------------------------------
+// Typed methods
+// ----------------------------------------------------------------------------
+
+/*
+The GetConfigsTyped method retrieves the list of Senzing configurations, as GetConfigs() does, decoded.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szconfigmanager) GetConfigsTyped(ctx context.Context) (*szmodel.ConfigsResponse, error) {
+	result, err := client.GetConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.ConfigsResponse](result)
+}
+
 // ----------------------------------------------------------------------------
 // Private methods for gRPC request/response
 // ----------------------------------------------------------------------------
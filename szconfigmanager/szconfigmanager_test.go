@@ -11,6 +11,7 @@ import (
 
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
 	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
 	"github.com/senzing-garage/sz-sdk-go-grpc/szconfig"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
@@ -111,6 +112,20 @@ func TestSzconfigmanager_GetConfigs(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzconfigmanager_GetConfigsTyped(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := getTestObject(ctx, test)
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	actual, err := szConfigManager.GetConfigsTyped(ctx)
+	require.NoError(test, err)
+	configIDs := []int64{}
+	for _, configEntry := range actual.Configs {
+		configIDs = append(configIDs, configEntry.ConfigID)
+	}
+	assert.Contains(test, configIDs, defaultConfigID)
+}
+
 // TODO: Implement TestSzconfigmanager_GetConfigs_error
 // func TestSzconfigmanager_GetConfigs_error(test *testing.T) {}
 
@@ -272,7 +287,11 @@ func TestSzconfigmanager_Destroy_withObserver(test *testing.T) {
 func getGrpcConnection() *grpc.ClientConn {
 	var err error
 	if grpcConnection == nil {
-		grpcConnection, err = grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if fakeserver.Selected() {
+			grpcConnection, err = fakeserver.NewSharedClient()
+		} else {
+			grpcConnection, err = grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		}
 		if err != nil {
 			fmt.Printf("Did not connect: %v\n", err)
 		}
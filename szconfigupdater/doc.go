@@ -0,0 +1,17 @@
+/*
+The szconfigupdater package changes the default Senzing configuration without losing concurrent changes.
+
+An Updater reads the default configuration, applies a Mutation to a copy of it through SzConfig,
+registers the copy with SzConfigManager.AddConfig(), and makes it the default with
+SzConfigManager.ReplaceDefaultConfigID(), which fails if the default changed meanwhile.
+When it did, because another process updated the configuration first, the Updater waits,
+re-reads the new default and applies the Mutation to it again,
+so the other change is kept and this one is made on top of it.
+Retries follow a szretry.Policy; when every attempt conflicts, the error matches ErrConflict.
+
+A structured comment, a szconfigcomment.Comment, gets the configuration the Mutation was applied to as its parent.
+
+A Mutation may run several times, each time on a fresh copy of the current default configuration,
+so it should only change the configuration through the handle it is given.
+*/
+package szconfigupdater
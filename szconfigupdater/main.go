@@ -0,0 +1,39 @@
+package szconfigupdater
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szretry"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Mutation changes a copy of the default configuration, loaded in szConfig with configHandle.
+type Mutation func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error
+
+// Result describes a successful update.
+type Result struct {
+	ConfigID     int64 // The new default configuration.
+	BaseConfigID int64 // The default configuration the Mutation was applied to.
+	Attempts     int   // The attempts made, including the successful one.
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// DefaultPolicy is how updates that conflict are retried, unless changed with WithPolicy().
+var DefaultPolicy = szretry.Policy{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxAttempts:    5,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// ErrConflict is returned by Updater.Update when the default configuration changed during every attempt.
+var ErrConflict = errors.New("default configuration changed by another update")
@@ -0,0 +1,53 @@
+package szconfigupdater
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szretry"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Option configures an Updater created by New.
+type Option func(*Updater) error
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The WithOrigin function names the program making updates in the default comment of new configurations,
+such as "Updated by deploy-job from configuration 42".
+
+Input
+  - origin: The name of the program.
+*/
+func WithOrigin(origin string) Option {
+	return func(updater *Updater) error {
+		if len(origin) == 0 {
+			return errors.New("origin cannot be empty")
+		}
+		updater.origin = origin
+		return nil
+	}
+}
+
+/*
+The WithPolicy function sets how updates that conflict are retried.
+The default is DefaultPolicy.
+
+Input
+  - policy: The retry policy.  MaxAttempts of 1 disables retries.
+*/
+func WithPolicy(policy szretry.Policy) Option {
+	return func(updater *Updater) error {
+		if policy.MaxAttempts < 1 || policy.MaxAttempts > 1 && (policy.InitialBackoff < 0 || policy.MaxBackoff < policy.InitialBackoff || policy.Multiplier < 1) {
+			return fmt.Errorf("invalid retry policy: %+v", policy)
+		}
+		updater.policy = policy
+		return nil
+	}
+}
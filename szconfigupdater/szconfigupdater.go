@@ -0,0 +1,186 @@
+package szconfigupdater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigcomment"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szretry"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// Updater changes the default configuration, retrying when another update changed it first.
+type Updater struct {
+	origin          string
+	policy          szretry.Policy
+	random          func() float64
+	sleep           func(ctx context.Context, duration time.Duration) error
+	szConfig        senzing.SzConfig
+	szConfigManager senzing.SzConfigManager
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function creates an Updater.
+
+Input
+  - szConfigManager: Reads, registers and replaces the default configuration.
+  - szConfig: Applies mutations to copies of the default configuration.
+  - options: Options that set the retry policy and the origin.
+*/
+func New(szConfigManager senzing.SzConfigManager, szConfig senzing.SzConfig, options ...Option) (*Updater, error) {
+	if szConfigManager == nil {
+		return nil, errors.New("szConfigManager cannot be nil")
+	}
+	if szConfig == nil {
+		return nil, errors.New("szConfig cannot be nil")
+	}
+	result := &Updater{
+		policy:          DefaultPolicy,
+		random:          rand.Float64,
+		sleep:           sleep,
+		szConfig:        szConfig,
+		szConfigManager: szConfigManager,
+	}
+	for _, option := range options {
+		if err := option(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Updater methods
+// ----------------------------------------------------------------------------
+
+/*
+The Update method applies a Mutation to a copy of the default configuration, registers the copy,
+and makes it the default.
+If the default configuration changed meanwhile, the Mutation is applied again to the new default,
+after a wait, as set by WithPolicy().
+
+Input
+  - ctx: A context to control lifecycle.
+  - comment: The comment of the new configuration.
+    If empty, it names the configuration the Mutation was applied to, such as "Updated from configuration 42".
+    If it is a szconfigcomment.Comment without a parent configuration, that configuration becomes its parent.
+  - mutation: Changes the configuration.  Its errors end the update.
+
+Output
+  - The new default configuration.
+  - An error matching ErrConflict if every attempt conflicted, or the first other error.
+*/
+func (updater *Updater) Update(ctx context.Context, comment string, mutation Mutation) (Result, error) {
+	if mutation == nil {
+		return Result{}, errors.New("mutation cannot be nil")
+	}
+	for attempt := 1; ; attempt++ {
+		baseConfigID, err := updater.szConfigManager.GetDefaultConfigID(ctx)
+		if err != nil {
+			return Result{}, err
+		}
+		configID, conflict, err := updater.update(ctx, baseConfigID, comment, mutation)
+		if err == nil {
+			return Result{ConfigID: configID, BaseConfigID: baseConfigID, Attempts: attempt}, nil
+		}
+		if !conflict {
+			return Result{}, err
+		}
+		if attempt >= updater.policy.MaxAttempts {
+			return Result{}, fmt.Errorf("%w after %d attempts: %w", ErrConflict, attempt, err)
+		}
+		if err := updater.sleep(ctx, updater.getBackoff(attempt)); err != nil {
+			return Result{}, err
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The comment of a configuration made from baseConfigID: a default comment if none was given,
+and a structured comment with its parent configuration set, if it has none.
+*/
+func (updater *Updater) getComment(baseConfigID int64, comment string) string {
+	if len(comment) == 0 {
+		if len(updater.origin) == 0 {
+			return fmt.Sprintf("Updated from configuration %d", baseConfigID)
+		}
+		return fmt.Sprintf("Updated by %s from configuration %d", updater.origin, baseConfigID)
+	}
+	structured, err := szconfigcomment.Parse(comment)
+	if err != nil || structured.ParentConfigID != 0 {
+		return comment
+	}
+	structured.ParentConfigID = baseConfigID
+	return structured.String()
+}
+
+// Full jitter: a random delay between zero and the exponentially growing upper bound.
+func (updater *Updater) getBackoff(attempt int) time.Duration {
+	upperBound := float64(updater.policy.InitialBackoff) * math.Pow(updater.policy.Multiplier, float64(attempt-1))
+	upperBound = math.Min(upperBound, float64(updater.policy.MaxBackoff))
+	return time.Duration(updater.random() * upperBound)
+}
+
+// Whether a failed attempt lost to another update: the default configuration is no longer the one it started from.
+func (updater *Updater) isConflict(ctx context.Context, baseConfigID int64) bool {
+	configID, err := updater.szConfigManager.GetDefaultConfigID(ctx)
+	return err == nil && configID != baseConfigID
+}
+
+/*
+Apply the mutation to a copy of a configuration, register it, and replace that configuration as the default.
+Reports whether replacing failed because another update changed the default first.
+*/
+func (updater *Updater) update(ctx context.Context, baseConfigID int64, comment string, mutation Mutation) (int64, bool, error) {
+	configDefinition, err := updater.szConfigManager.GetConfig(ctx, baseConfigID)
+	if err != nil {
+		return 0, false, err
+	}
+	configHandle, err := updater.szConfig.ImportConfig(ctx, configDefinition)
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() { _ = updater.szConfig.CloseConfig(ctx, configHandle) }()
+	if err := mutation(ctx, updater.szConfig, configHandle); err != nil {
+		return 0, false, err
+	}
+	newConfigDefinition, err := updater.szConfig.ExportConfig(ctx, configHandle)
+	if err != nil {
+		return 0, false, err
+	}
+	configID, err := updater.szConfigManager.AddConfig(ctx, newConfigDefinition, updater.getComment(baseConfigID, comment))
+	if err != nil {
+		return 0, false, err
+	}
+	if err := updater.szConfigManager.ReplaceDefaultConfigID(ctx, baseConfigID, configID); err != nil {
+		return 0, updater.isConflict(ctx, baseConfigID), err
+	}
+	return configID, false, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
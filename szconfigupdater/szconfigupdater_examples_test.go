@@ -0,0 +1,57 @@
+package szconfigupdater
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleUpdater_Update() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szconfigupdater/szconfigupdater_examples_test.go
+	ctx := context.TODO()
+	server, err := fakeserver.New()
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	factory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithGrpcAddress(fakeserver.Address),
+		szabstractfactory.WithDialOptions(server.DialOptions()...),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = factory.Close() }()
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	szConfig, err := factory.CreateSzConfig(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	updater, err := New(szConfigManager, szConfig, WithOrigin("deploy-job"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	result, err := updater.Update(ctx, "", func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error {
+		_, err := szConfig.AddDataSource(ctx, configHandle, "CUSTOMERS")
+		return err
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(result.Attempts, defaultConfigID == result.ConfigID)
+	// Output: 1 true
+}
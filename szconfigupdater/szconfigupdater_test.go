@@ -0,0 +1,246 @@
+package szconfigupdater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigcomment"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szretry"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = szretry.Policy{
+	InitialBackoff: time.Millisecond,
+	MaxAttempts:    3,
+	MaxBackoff:     time.Millisecond,
+	Multiplier:     1,
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestNew_badOptions(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	szConfigManager, szConfig := createConfigObjects(ctx, test, factory)
+	_, err := New(nil, szConfig)
+	require.Error(test, err)
+	_, err = New(szConfigManager, nil)
+	require.Error(test, err)
+	_, err = New(szConfigManager, szConfig, WithOrigin(""))
+	require.Error(test, err)
+	_, err = New(szConfigManager, szConfig, WithPolicy(szretry.Policy{}))
+	require.Error(test, err)
+	_, err = New(szConfigManager, szConfig, WithPolicy(szretry.Policy{MaxAttempts: 2, InitialBackoff: time.Second}))
+	require.Error(test, err)
+}
+
+func TestUpdater_Update(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	updater := getTestUpdater(ctx, test, factory, WithOrigin("test"))
+	baseConfigID := getDefaultConfigID(ctx, test, factory)
+	result, err := updater.Update(ctx, "", addDataSource("CUSTOMERS"))
+	require.NoError(test, err)
+	assert.Equal(test, baseConfigID, result.BaseConfigID)
+	assert.Equal(test, 1, result.Attempts)
+	assert.Equal(test, result.ConfigID, getDefaultConfigID(ctx, test, factory))
+	assert.Contains(test, getDataSources(ctx, test, factory), `"CUSTOMERS"`)
+	szConfigManager, _ := createConfigObjects(ctx, test, factory)
+	configs, err := szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	assert.Contains(test, configs, fmt.Sprintf("Updated by test from configuration %d", baseConfigID))
+}
+
+func TestUpdater_Update_structuredComment(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	updater := getTestUpdater(ctx, test, factory, WithPolicy(testPolicy))
+	competitor := getTestUpdater(ctx, test, factory)
+	comment := szconfigcomment.Comment{Author: "jane", Summary: "Added data sources CUSTOMERS"}
+	calls := 0
+	result, err := updater.Update(ctx, comment.String(), func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error {
+		calls++
+		if calls == 1 {
+			if _, err := competitor.Update(ctx, "", addDataSource("WATCHLIST")); err != nil {
+				return err
+			}
+		}
+		return addDataSource("CUSTOMERS")(ctx, szConfig, configHandle)
+	})
+	require.NoError(test, err)
+	assert.Equal(test, 2, result.Attempts)
+	szConfigManager, _ := createConfigObjects(ctx, test, factory)
+	document, err := szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	configs, err := szmodel.Unmarshal[szmodel.ConfigsResponse](document)
+	require.NoError(test, err)
+	comments := map[int64]string{}
+	for _, configEntry := range configs.Configs {
+		comments[configEntry.ConfigID] = configEntry.ConfigComments
+	}
+	comment.ParentConfigID = result.BaseConfigID
+	assert.Equal(test, comment.String(), comments[result.ConfigID])
+}
+
+func TestUpdater_Update_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	policy := szretry.Policy{InitialBackoff: time.Millisecond, MaxAttempts: 100, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}
+	const updates = 8
+	var waitGroup sync.WaitGroup
+	errs := make(chan error, updates)
+	for i := range updates {
+		updater := getTestUpdater(ctx, test, factory, WithPolicy(policy))
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			_, err := updater.Update(ctx, "", addDataSource(fmt.Sprintf("SOURCE_%d", i)))
+			errs <- err
+		}()
+	}
+	waitGroup.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(test, err)
+	}
+	dataSources := getDataSources(ctx, test, factory)
+	for i := range updates {
+		assert.Contains(test, dataSources, fmt.Sprintf(`"SOURCE_%d"`, i))
+	}
+}
+
+func TestUpdater_Update_conflict(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	updater := getTestUpdater(ctx, test, factory, WithPolicy(testPolicy))
+	competitor := getTestUpdater(ctx, test, factory, WithPolicy(szretry.Policy{MaxAttempts: 1}))
+	calls := 0
+	_, err := updater.Update(ctx, "", func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error {
+		calls++
+		if _, err := competitor.Update(ctx, "", addDataSource(fmt.Sprintf("OTHER_%d", calls))); err != nil {
+			return err
+		}
+		return addDataSource("CUSTOMERS")(ctx, szConfig, configHandle)
+	})
+	require.ErrorIs(test, err, ErrConflict)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+	assert.Equal(test, testPolicy.MaxAttempts, calls)
+	assert.NotContains(test, getDataSources(ctx, test, factory), `"CUSTOMERS"`)
+}
+
+func TestUpdater_Update_mutationError(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	updater := getTestUpdater(ctx, test, factory, WithPolicy(testPolicy))
+	baseConfigID := getDefaultConfigID(ctx, test, factory)
+	errMutation := errors.New("mutation failed")
+	calls := 0
+	_, err := updater.Update(ctx, "", func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error {
+		_ = ctx
+		_ = szConfig
+		_ = configHandle
+		calls++
+		return errMutation
+	})
+	require.ErrorIs(test, err, errMutation)
+	assert.Equal(test, 1, calls)
+	assert.Equal(test, baseConfigID, getDefaultConfigID(ctx, test, factory))
+}
+
+func TestUpdater_Update_retry(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	updater := getTestUpdater(ctx, test, factory, WithPolicy(testPolicy))
+	competitor := getTestUpdater(ctx, test, factory)
+	calls := 0
+	result, err := updater.Update(ctx, "Add CUSTOMERS", func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error {
+		calls++
+		if calls == 1 {
+			if _, err := competitor.Update(ctx, "Add WATCHLIST", addDataSource("WATCHLIST")); err != nil {
+				return err
+			}
+		}
+		return addDataSource("CUSTOMERS")(ctx, szConfig, configHandle)
+	})
+	require.NoError(test, err)
+	assert.Equal(test, 2, result.Attempts)
+	dataSources := getDataSources(ctx, test, factory)
+	assert.Contains(test, dataSources, `"CUSTOMERS"`)
+	assert.Contains(test, dataSources, `"WATCHLIST"`)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func addDataSource(dataSourceCode string) Mutation {
+	return func(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) error {
+		_, err := szConfig.AddDataSource(ctx, configHandle, dataSourceCode)
+		return err
+	}
+}
+
+func createConfigObjects(ctx context.Context, test *testing.T, factory *szabstractfactory.Szabstractfactory) (senzing.SzConfigManager, senzing.SzConfig) {
+	test.Helper()
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	require.NoError(test, err)
+	szConfig, err := factory.CreateSzConfig(ctx)
+	require.NoError(test, err)
+	return szConfigManager, szConfig
+}
+
+// The data sources of the default configuration.
+func getDataSources(ctx context.Context, test *testing.T, factory *szabstractfactory.Szabstractfactory) string {
+	test.Helper()
+	szConfigManager, szConfig := createConfigObjects(ctx, test, factory)
+	configDefinition, err := szConfigManager.GetConfig(ctx, getDefaultConfigID(ctx, test, factory))
+	require.NoError(test, err)
+	configHandle, err := szConfig.ImportConfig(ctx, configDefinition)
+	require.NoError(test, err)
+	defer func() { _ = szConfig.CloseConfig(ctx, configHandle) }()
+	result, err := szConfig.GetDataSources(ctx, configHandle)
+	require.NoError(test, err)
+	return result
+}
+
+func getDefaultConfigID(ctx context.Context, test *testing.T, factory *szabstractfactory.Szabstractfactory) int64 {
+	test.Helper()
+	szConfigManager, _ := createConfigObjects(ctx, test, factory)
+	result, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	return result
+}
+
+func getTestFactory(ctx context.Context, test *testing.T) *szabstractfactory.Szabstractfactory {
+	test.Helper()
+	server, err := fakeserver.New()
+	require.NoError(test, err)
+	test.Cleanup(server.Close)
+	result, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithGrpcAddress(fakeserver.Address),
+		szabstractfactory.WithDialOptions(server.DialOptions()...),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = result.Close() })
+	return result
+}
+
+func getTestUpdater(ctx context.Context, test *testing.T, factory *szabstractfactory.Szabstractfactory, options ...Option) *Updater {
+	test.Helper()
+	szConfigManager, szConfig := createConfigObjects(ctx, test, factory)
+	result, err := New(szConfigManager, szConfig, options...)
+	require.NoError(test, err)
+	return result
+}
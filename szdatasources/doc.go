@@ -0,0 +1,22 @@
+/*
+The szdatasources package manages the data sources of the default Senzing configuration declaratively.
+
+A Desired value, read from a YAML or JSON file with ReadFile(), lists the data sources the configuration should have:
+
+	dataSources:
+	  - CUSTOMERS
+	  - WATCHLIST
+	prune: false
+
+NewPlan() compares it with the data sources of the current default configuration, fetched with
+SzConfigManager.GetConfig(), and returns a Plan of the data sources to add and, with prune, to delete.
+Prune keeps the built-in TEST and SEARCH data sources; to delete them, list them under delete:
+
+	delete:
+	  - TEST
+
+Plan.Apply() registers a changed copy of that configuration with a comment describing the change,
+then makes it the default with SzConfigManager.ReplaceDefaultConfigID(),
+so applying fails if the default configuration changed after the plan was made.
+*/
+package szdatasources
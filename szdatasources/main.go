@@ -0,0 +1,34 @@
+package szdatasources
+
+import (
+	"errors"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Desired is the set of data sources the default configuration should have.
+type Desired struct {
+	DataSources []string `json:"dataSources"      yaml:"dataSources"`      // Data source codes, added in this order.
+	Delete      []string `json:"delete,omitempty" yaml:"delete,omitempty"` // Data source codes deleted, with or without Prune.
+	Prune       bool     `json:"prune"            yaml:"prune"`            // Whether data sources that are not listed are deleted, except BuiltInDataSources.
+}
+
+// Plan is the changes that give a configuration the desired data sources.
+type Plan struct {
+	ConfigID  int64    `json:"CONFIG_ID"` // The default configuration the plan was made from.
+	Add       []string `json:"ADD"`       // Data sources to add, in the order they are listed.
+	Delete    []string `json:"DELETE"`    // Data sources to delete, in the order of the configuration.
+	Unchanged []string `json:"UNCHANGED"` // Data sources kept, in the order of the configuration.
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// BuiltInDataSources are the data sources of a new Senzing configuration.  Prune keeps them; Delete can list them.
+var BuiltInDataSources = []string{"TEST", "SEARCH"}
+
+// ErrInvalidDesired is returned by Read and ReadFile when the desired data sources are malformed.
+var ErrInvalidDesired = errors.New("invalid desired data sources")
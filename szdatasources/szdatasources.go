@@ -0,0 +1,312 @@
+package szdatasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigcomment"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"gopkg.in/yaml.v3"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewPlan function compares the desired data sources with those of the default configuration.
+
+Input
+  - ctx: A context to control lifecycle.
+  - factory: Creates the SzConfigManager and SzConfig used to read the default configuration.
+  - desired: The data sources the default configuration should have.
+
+Output
+  - The changes that give the default configuration the desired data sources.
+*/
+func NewPlan(ctx context.Context, factory senzing.SzAbstractFactory, desired *Desired) (*Plan, error) {
+	if desired == nil {
+		return nil, errors.New("desired cannot be nil")
+	}
+	szConfigManager, szConfig, err := createConfigObjects(ctx, factory)
+	if err != nil {
+		return nil, err
+	}
+	configID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configHandle, err := importConfig(ctx, szConfigManager, szConfig, configID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = szConfig.CloseConfig(ctx, configHandle) }()
+	current, err := getDataSourceCodes(ctx, szConfig, configHandle)
+	if err != nil {
+		return nil, err
+	}
+	result := &Plan{
+		ConfigID:  configID,
+		Add:       []string{},
+		Delete:    []string{},
+		Unchanged: []string{},
+	}
+	for _, dataSourceCode := range desired.DataSources {
+		if !slices.Contains(current, dataSourceCode) {
+			result.Add = append(result.Add, dataSourceCode)
+		}
+	}
+	for _, dataSourceCode := range current {
+		switch {
+		case slices.Contains(desired.DataSources, dataSourceCode):
+			result.Unchanged = append(result.Unchanged, dataSourceCode)
+		case slices.Contains(desired.Delete, dataSourceCode):
+			result.Delete = append(result.Delete, dataSourceCode)
+		case desired.Prune && !slices.Contains(BuiltInDataSources, dataSourceCode):
+			result.Delete = append(result.Delete, dataSourceCode)
+		default:
+			result.Unchanged = append(result.Unchanged, dataSourceCode)
+		}
+	}
+	return result, nil
+}
+
+/*
+The Read function reads desired data sources in YAML or JSON.
+Data source codes are trimmed and converted to upper case.
+A data source cannot be both listed and deleted.
+
+Input
+  - reader: The YAML or JSON document.
+
+Output
+  - The desired data sources.  Errors for malformed documents match ErrInvalidDesired.
+*/
+func Read(reader io.Reader) (*Desired, error) {
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true)
+	result := &Desired{}
+	err := decoder.Decode(result)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: empty document", ErrInvalidDesired)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDesired, err)
+	}
+	if result.DataSources, err = normalizeDataSourceCodes(result.DataSources, nil); err != nil {
+		return nil, err
+	}
+	if len(result.Delete) > 0 {
+		if result.Delete, err = normalizeDataSourceCodes(result.Delete, result.DataSources); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+/*
+The ReadFile function reads desired data sources from a YAML or JSON file.
+
+Input
+  - fileName: The file to read.
+
+Output
+  - The desired data sources.  Errors for malformed files match ErrInvalidDesired.
+*/
+func ReadFile(fileName string) (*Desired, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	result, err := Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Plan methods
+// ----------------------------------------------------------------------------
+
+/*
+The Apply method registers a copy of the configuration the plan was made from, with the data sources
+added and deleted, and makes it the default configuration.
+The default is replaced with SzConfigManager.ReplaceDefaultConfigID(),
+so Apply fails if the default configuration changed after the plan was made.
+A plan without changes registers nothing.
+
+Input
+  - ctx: A context to control lifecycle.
+  - factory: Creates the SzConfigManager and SzConfig used to change the configuration.
+  - comment: The comment of the new configuration.  If empty, the result of Comment().
+    If it is a szconfigcomment.Comment without a parent configuration, the configuration of the plan becomes its parent.
+
+Output
+  - The ID of the new default configuration, or of the unchanged one.
+*/
+func (plan *Plan) Apply(ctx context.Context, factory senzing.SzAbstractFactory, comment string) (int64, error) {
+	if plan.IsEmpty() {
+		return plan.ConfigID, nil
+	}
+	szConfigManager, szConfig, err := createConfigObjects(ctx, factory)
+	if err != nil {
+		return 0, err
+	}
+	configHandle, err := importConfig(ctx, szConfigManager, szConfig, plan.ConfigID)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = szConfig.CloseConfig(ctx, configHandle) }()
+	for _, dataSourceCode := range plan.Add {
+		if _, err := szConfig.AddDataSource(ctx, configHandle, dataSourceCode); err != nil {
+			return 0, err
+		}
+	}
+	for _, dataSourceCode := range plan.Delete {
+		if err := szConfig.DeleteDataSource(ctx, configHandle, dataSourceCode); err != nil {
+			return 0, err
+		}
+	}
+	configDefinition, err := szConfig.ExportConfig(ctx, configHandle)
+	if err != nil {
+		return 0, err
+	}
+	configID, err := szConfigManager.AddConfig(ctx, configDefinition, plan.getComment(comment))
+	if err != nil {
+		return 0, err
+	}
+	return configID, szConfigManager.ReplaceDefaultConfigID(ctx, plan.ConfigID, configID)
+}
+
+// The Comment method describes the plan, such as "Data sources: added CUSTOMERS; deleted TEST (from configuration 42)".
+func (plan *Plan) Comment() string {
+	changes := []string{}
+	if len(plan.Add) > 0 {
+		changes = append(changes, "added "+strings.Join(plan.Add, ", "))
+	}
+	if len(plan.Delete) > 0 {
+		changes = append(changes, "deleted "+strings.Join(plan.Delete, ", "))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "unchanged")
+	}
+	return fmt.Sprintf("Data sources: %s (from configuration %d)", strings.Join(changes, "; "), plan.ConfigID)
+}
+
+// The IsEmpty method reports whether the plan has no data sources to add or delete.
+func (plan *Plan) IsEmpty() bool {
+	return len(plan.Add) == 0 && len(plan.Delete) == 0
+}
+
+/*
+The String method describes the plan for people, a line per data source:
+"+" for data sources to add, "-" for those to delete, and a space for those kept,
+then a summary line.
+*/
+func (plan *Plan) String() string {
+	result := &strings.Builder{}
+	fmt.Fprintf(result, "Configuration %d:\n", plan.ConfigID)
+	for _, dataSourceCode := range plan.Add {
+		fmt.Fprintf(result, "  + %s\n", dataSourceCode)
+	}
+	for _, dataSourceCode := range plan.Delete {
+		fmt.Fprintf(result, "  - %s\n", dataSourceCode)
+	}
+	for _, dataSourceCode := range plan.Unchanged {
+		fmt.Fprintf(result, "    %s\n", dataSourceCode)
+	}
+	fmt.Fprintf(result, "%d to add, %d to delete, %d unchanged.\n", len(plan.Add), len(plan.Delete), len(plan.Unchanged))
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (plan *Plan) getComment(comment string) string {
+	if len(comment) == 0 {
+		return plan.Comment()
+	}
+	structured, err := szconfigcomment.Parse(comment)
+	if err != nil || structured.ParentConfigID != 0 {
+		return comment
+	}
+	structured.ParentConfigID = plan.ConfigID
+	return structured.String()
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func createConfigObjects(ctx context.Context, factory senzing.SzAbstractFactory) (senzing.SzConfigManager, senzing.SzConfig, error) {
+	if factory == nil {
+		return nil, nil, errors.New("factory cannot be nil")
+	}
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	szConfig, err := factory.CreateSzConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return szConfigManager, szConfig, nil
+}
+
+// The data source codes of a configuration, in the order of the configuration.
+func getDataSourceCodes(ctx context.Context, szConfig senzing.SzConfig, configHandle uintptr) ([]string, error) {
+	dataSources, err := szConfig.GetDataSources(ctx, configHandle)
+	if err != nil {
+		return nil, err
+	}
+	document := struct {
+		DataSources []struct {
+			Code string `json:"DSRC_CODE"`
+		} `json:"DATA_SOURCES"`
+	}{}
+	if err := json.Unmarshal([]byte(dataSources), &document); err != nil {
+		return nil, fmt.Errorf("cannot parse data sources: %w", err)
+	}
+	result := make([]string, 0, len(document.DataSources))
+	for _, dataSource := range document.DataSources {
+		result = append(result, dataSource.Code)
+	}
+	return result, nil
+}
+
+// Load a registered configuration into SzConfig.  Close the returned handle.
+func importConfig(ctx context.Context, szConfigManager senzing.SzConfigManager, szConfig senzing.SzConfig, configID int64) (uintptr, error) {
+	configDefinition, err := szConfigManager.GetConfig(ctx, configID)
+	if err != nil {
+		return 0, err
+	}
+	return szConfig.ImportConfig(ctx, configDefinition)
+}
+
+// Trim data source codes and convert them to upper case.  Empty codes, repeated codes and codes in others are errors.
+func normalizeDataSourceCodes(dataSourceCodes []string, others []string) ([]string, error) {
+	result := make([]string, 0, len(dataSourceCodes))
+	for _, dataSourceCode := range dataSourceCodes {
+		dataSourceCode = strings.ToUpper(strings.TrimSpace(dataSourceCode))
+		if len(dataSourceCode) == 0 {
+			return nil, fmt.Errorf("%w: empty data source code", ErrInvalidDesired)
+		}
+		if slices.Contains(result, dataSourceCode) {
+			return nil, fmt.Errorf("%w: data source %s is listed more than once", ErrInvalidDesired, dataSourceCode)
+		}
+		if slices.Contains(others, dataSourceCode) {
+			return nil, fmt.Errorf("%w: data source %s is both listed and deleted", ErrInvalidDesired, dataSourceCode)
+		}
+		result = append(result, dataSourceCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package szdatasources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNewPlan() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szdatasources/szdatasources_examples_test.go
+	ctx := context.TODO()
+	server, err := fakeserver.New(fakeserver.WithDataSources("CUSTOMERS"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	factory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithGrpcAddress(fakeserver.Address),
+		szabstractfactory.WithDialOptions(server.DialOptions()...),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = factory.Close() }()
+	desired, err := Read(strings.NewReader("dataSources: [CUSTOMERS, WATCHLIST]\nprune: true\n"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	plan, err := NewPlan(ctx, factory, desired)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(plan)
+	_, err = plan.Apply(ctx, factory, "")
+	if err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// Configuration 831594791:
+	//   + WATCHLIST
+	//     TEST
+	//     SEARCH
+	//     CUSTOMERS
+	// 1 to add, 0 to delete, 3 unchanged.
+}
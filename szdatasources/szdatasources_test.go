@@ -0,0 +1,192 @@
+package szdatasources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigcomment"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestNewPlan(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test, "CUSTOMERS")
+	plan, err := NewPlan(ctx, factory, &Desired{DataSources: []string{"WATCHLIST", "CUSTOMERS"}})
+	require.NoError(test, err)
+	assert.Equal(test, []string{"WATCHLIST"}, plan.Add)
+	assert.Empty(test, plan.Delete)
+	assert.Equal(test, []string{"TEST", "SEARCH", "CUSTOMERS"}, plan.Unchanged)
+	assert.Equal(test, getDefaultConfigID(ctx, test, factory), plan.ConfigID)
+}
+
+func TestNewPlan_prune(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test, "CUSTOMERS", "WATCHLIST")
+	plan, err := NewPlan(ctx, factory, &Desired{DataSources: []string{"CUSTOMERS"}, Prune: true})
+	require.NoError(test, err)
+	assert.Empty(test, plan.Add)
+	assert.Equal(test, []string{"WATCHLIST"}, plan.Delete)
+	assert.Equal(test, []string{"TEST", "SEARCH", "CUSTOMERS"}, plan.Unchanged)
+}
+
+func TestNewPlan_delete(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test, "CUSTOMERS", "WATCHLIST")
+	plan, err := NewPlan(ctx, factory, &Desired{DataSources: []string{"CUSTOMERS"}, Delete: []string{"TEST", "WATCHLIST"}})
+	require.NoError(test, err)
+	assert.Empty(test, plan.Add)
+	assert.Equal(test, []string{"TEST", "WATCHLIST"}, plan.Delete)
+	assert.Equal(test, []string{"SEARCH", "CUSTOMERS"}, plan.Unchanged)
+}
+
+func TestPlan_Apply(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	plan, err := NewPlan(ctx, factory, &Desired{DataSources: []string{"CUSTOMERS", "WATCHLIST"}})
+	require.NoError(test, err)
+	configID, err := plan.Apply(ctx, factory, "")
+	require.NoError(test, err)
+	assert.NotEqual(test, plan.ConfigID, configID)
+	assert.Equal(test, configID, getDefaultConfigID(ctx, test, factory))
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	require.NoError(test, err)
+	configs, err := szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	assert.Contains(test, configs, plan.Comment())
+	replan, err := NewPlan(ctx, factory, &Desired{DataSources: []string{"CUSTOMERS", "WATCHLIST"}})
+	require.NoError(test, err)
+	assert.True(test, replan.IsEmpty())
+	unchangedID, err := replan.Apply(ctx, factory, "")
+	require.NoError(test, err)
+	assert.Equal(test, configID, unchangedID)
+}
+
+func TestPlan_Apply_structuredComment(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	plan, err := NewPlan(ctx, factory, &Desired{DataSources: []string{"CUSTOMERS"}})
+	require.NoError(test, err)
+	comment := szconfigcomment.Comment{Author: "jane", Summary: plan.Comment()}
+	_, err = plan.Apply(ctx, factory, comment.String())
+	require.NoError(test, err)
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	require.NoError(test, err)
+	configs, err := szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	comment.ParentConfigID = plan.ConfigID
+	assert.Contains(test, configs, strconv.Quote(comment.String()))
+}
+
+func TestPlan_Apply_defaultChanged(test *testing.T) {
+	ctx := context.TODO()
+	factory := getTestFactory(ctx, test)
+	plan, err := NewPlan(ctx, factory, &Desired{DataSources: []string{"CUSTOMERS"}})
+	require.NoError(test, err)
+	_, err = plan.Apply(ctx, factory, "")
+	require.NoError(test, err)
+	_, err = plan.Apply(ctx, factory, "")
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+}
+
+func TestPlan_Comment(test *testing.T) {
+	plan := &Plan{ConfigID: 42, Add: []string{"CUSTOMERS", "WATCHLIST"}, Delete: []string{"TEST"}}
+	assert.Equal(test, "Data sources: added CUSTOMERS, WATCHLIST; deleted TEST (from configuration 42)", plan.Comment())
+	plan = &Plan{ConfigID: 42}
+	assert.Equal(test, "Data sources: unchanged (from configuration 42)", plan.Comment())
+}
+
+func TestPlan_String(test *testing.T) {
+	plan := &Plan{ConfigID: 42, Add: []string{"CUSTOMERS"}, Delete: []string{"TEST"}, Unchanged: []string{"SEARCH"}}
+	expected := "Configuration 42:\n  + CUSTOMERS\n  - TEST\n    SEARCH\n1 to add, 1 to delete, 1 unchanged.\n"
+	assert.Equal(test, expected, plan.String())
+}
+
+func TestRead(test *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+		expected *Desired
+	}{
+		{name: "delete", document: "dataSources: [CUSTOMERS]\ndelete: [test]\n", expected: &Desired{DataSources: []string{"CUSTOMERS"}, Delete: []string{"TEST"}}},
+		{name: "json", document: `{"dataSources": ["customers", " WATCHLIST "], "prune": true}`, expected: &Desired{DataSources: []string{"CUSTOMERS", "WATCHLIST"}, Prune: true}},
+		{name: "none", document: "dataSources: []\n", expected: &Desired{DataSources: []string{}}},
+		{name: "yaml", document: "dataSources:\n  - CUSTOMERS\n  - watchlist\n", expected: &Desired{DataSources: []string{"CUSTOMERS", "WATCHLIST"}}},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			actual, err := Read(strings.NewReader(testCase.document))
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestRead_invalid(test *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+	}{
+		{name: "deleteListed", document: "dataSources: [CUSTOMERS]\ndelete: [customers]"},
+		{name: "duplicate", document: "dataSources: [CUSTOMERS, customers]"},
+		{name: "empty", document: ""},
+		{name: "emptyCode", document: `{"dataSources": [""]}`},
+		{name: "syntax", document: `{"dataSources": [`},
+		{name: "unknownField", document: "dataSource: [CUSTOMERS]"},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			actual, err := Read(strings.NewReader(testCase.document))
+			require.ErrorIs(test, err, ErrInvalidDesired)
+			assert.Nil(test, actual)
+		})
+	}
+}
+
+func TestReadFile(test *testing.T) {
+	fileName := filepath.Join(test.TempDir(), "data-sources.yaml")
+	require.NoError(test, os.WriteFile(fileName, []byte("dataSources: [CUSTOMERS]\nprune: true\n"), 0o600))
+	actual, err := ReadFile(fileName)
+	require.NoError(test, err)
+	assert.Equal(test, &Desired{DataSources: []string{"CUSTOMERS"}, Prune: true}, actual)
+	_, err = ReadFile(filepath.Join(test.TempDir(), "missing.yaml"))
+	require.ErrorIs(test, err, os.ErrNotExist)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getDefaultConfigID(ctx context.Context, test *testing.T, factory *szabstractfactory.Szabstractfactory) int64 {
+	test.Helper()
+	szConfigManager, err := factory.CreateSzConfigManager(ctx)
+	require.NoError(test, err)
+	result, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	return result
+}
+
+func getTestFactory(ctx context.Context, test *testing.T, dataSourceCodes ...string) *szabstractfactory.Szabstractfactory {
+	test.Helper()
+	server, err := fakeserver.New(fakeserver.WithDataSources(dataSourceCodes...))
+	require.NoError(test, err)
+	test.Cleanup(server.Close)
+	result, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithGrpcAddress(fakeserver.Address),
+		szabstractfactory.WithDialOptions(server.DialOptions()...),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = result.Close() })
+	return result
+}
@@ -1,8 +1,35 @@
 package szengine
 
+import (
+	"errors"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szengine"
+)
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
 
 // Identfier of the szengine package found messages having the format "senzing-6024xxxx".
 const ComponentID = 6024
+
+// The default time the export iterators wait for the consumer to read a fragment before abandoning the export.
+const DefaultExportStallTimeout = 5 * time.Minute
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrExportAbandoned is reported when an export iterator stops because its consumer stopped reading.
+var ErrExportAbandoned = errors.New("export abandoned: consumer stopped reading")
+
+// Message templates of the methods this client adds to senzing.SzEngine, added to szengine.IDMessages.
+var idMessages = map[int]string{
+	77:   "Enter " + szengine.Prefix + "ExportCsvEntities(%s, %d).",
+	78:   "Exit  " + szengine.Prefix + "ExportCsvEntities(%s, %d) returned (%v).",
+	79:   "Enter " + szengine.Prefix + "ExportJSONEntities(%d).",
+	80:   "Exit  " + szengine.Prefix + "ExportJSONEntities(%d) returned (%v).",
+	8035: szengine.Prefix + "ExportCsvEntities",
+	8036: szengine.Prefix + "ExportJSONEntities",
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"maps"
 	"strconv"
 	"time"
 
@@ -16,17 +18,22 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
 	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szflags"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szengine"
 	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
 )
 
 type Szengine struct {
-	GrpcClient     szpb.SzEngineClient
-	isTrace        bool // Performance optimization
-	logger         logging.Logging
-	observerOrigin string
-	observers      subject.Subject
+	// ExportStallTimeout is how long ExportCsvEntityReportIterator and ExportJSONEntityReportIterator wait
+	// for the consumer to read the next fragment before abandoning the export.  If zero, DefaultExportStallTimeout is used.
+	ExportStallTimeout time.Duration
+	GrpcClient         szpb.SzEngineClient
+	isTrace            bool // Performance optimization
+	logger             logging.Logging
+	observerOrigin     string
+	observers          subject.Subject
 }
 
 const (
@@ -53,9 +60,9 @@ func (client *Szengine) AddRecord(ctx context.Context, dataSourceCode string, re
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(1, dataSourceCode, recordID, recordDefinition, flags)
+		client.traceEntry(1, dataSourceCode, recordID, recordDefinition, szflags.WithInfoFlags(flags))
 		defer func() {
-			client.traceExit(2, dataSourceCode, recordID, recordDefinition, flags, result, err, time.Since(entryTime))
+			client.traceExit(2, dataSourceCode, recordID, recordDefinition, szflags.WithInfoFlags(flags), result, err, time.Since(entryTime))
 		}()
 	}
 	result, err = client.addRecord(ctx, dataSourceCode, recordID, recordDefinition, flags)
@@ -64,6 +71,7 @@ func (client *Szengine) AddRecord(ctx context.Context, dataSourceCode string, re
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
+				"flags":          szflags.WithInfoFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
 		}()
@@ -138,8 +146,10 @@ func (client *Szengine) DeleteRecord(ctx context.Context, dataSourceCode string,
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(9, dataSourceCode, recordID, flags)
-		defer func() { client.traceExit(10, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(9, dataSourceCode, recordID, szflags.WithInfoFlags(flags))
+		defer func() {
+			client.traceExit(10, dataSourceCode, recordID, szflags.WithInfoFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.deleteRecord(ctx, dataSourceCode, recordID, flags)
 	if client.observers != nil {
@@ -147,6 +157,7 @@ func (client *Szengine) DeleteRecord(ctx context.Context, dataSourceCode string,
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
+				"flags":          szflags.WithInfoFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
 		}()
@@ -176,6 +187,56 @@ func (client *Szengine) Destroy(ctx context.Context) error {
 	return err
 }
 
+/*
+The ExportCsvEntities method returns an iterator over a document of exported entities in CSV format,
+for use in a range-over-func loop.
+Each iteration yields a line of the document, or an error that ends the iteration.
+Breaking out of the loop cancels the underlying StreamExportCsvEntityReport RPC.
+The loop body may take as long as it needs: ExportStallTimeout does not apply.
+
+Input
+  - ctx: A context to control lifecycle.
+  - csvColumnList: A comma-separated list of column names for the CSV export.
+  - flags: Flags used to control information returned.
+
+Output
+  - An iterator of lines and errors.
+*/
+func (client *Szengine) ExportCsvEntities(ctx context.Context, csvColumnList string, flags int64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		var err error
+		if client.isTrace {
+			entryTime := time.Now()
+			client.traceEntry(77, csvColumnList, szflags.ExportFlags(flags))
+			defer func() { client.traceExit(78, csvColumnList, szflags.ExportFlags(flags), err, time.Since(entryTime)) }()
+		}
+		exportCtx, cancel := context.WithCancel(ctx) // Releases the server stream when the loop ends.
+		defer cancel()
+		request := szpb.StreamExportCsvEntityReportRequest{
+			CsvColumnList: csvColumnList,
+			Flags:         flags,
+		}
+		stream, err := client.GrpcClient.StreamExportCsvEntityReport(exportCtx, &request)
+		if err == nil {
+			err = yieldStrings(exportCtx, yield, func() (string, error) {
+				response, err := stream.Recv()
+				return response.GetResult(), err
+			})
+		} else {
+			err = helper.ConvertGrpcError(err)
+			yield("", err)
+		}
+		if client.observers != nil {
+			go func() {
+				details := map[string]string{
+					"flags": szflags.ExportFlags(flags).String(),
+				}
+				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8035, err, details)
+			}()
+		}
+	}
+}
+
 /*
 The ExportCsvEntityReport method initializes a cursor over a document of exported entities.
 It is part of the ExportCsvEntityReport(), FetchNext(), CloseExport()
@@ -194,13 +255,17 @@ func (client *Szengine) ExportCsvEntityReport(ctx context.Context, csvColumnList
 	var result uintptr
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(13, csvColumnList, flags)
-		defer func() { client.traceExit(14, csvColumnList, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(13, csvColumnList, szflags.ExportFlags(flags))
+		defer func() {
+			client.traceExit(14, csvColumnList, szflags.ExportFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.exportCsvEntityReport(ctx, csvColumnList, flags)
 	if client.observers != nil {
 		go func() {
-			details := map[string]string{}
+			details := map[string]string{
+				"flags": szflags.ExportFlags(flags).String(),
+			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8006, err, details)
 		}()
 	}
@@ -213,6 +278,15 @@ to scroll through a document of exported entities.
 It is a convenience method for the ExportCsvEntityReport(), FetchNext(), CloseExport()
 lifecycle of a list of entities to export.
 
+A goroutine receives the export and sends it to the channel, which buffers one fragment.
+To stop reading early, cancel ctx: the underlying RPC is cancelled at once.
+A consumer that stops reading without cancelling ctx, or that takes longer than ExportStallTimeout
+(DefaultExportStallTimeout if zero) to read a fragment, is taken to have stopped:
+the RPC is cancelled and the export ends with ErrExportAbandoned.
+An export that ends with an error sends a final fragment with the error,
+after the fragment already buffered, waiting up to ExportStallTimeout for the consumer to read them.
+Once ctx is cancelled, the goroutine does not wait: an error fragment that cannot be sent at once is dropped.
+
 Input
   - ctx: A context to control lifecycle.
   - csvColumnList: A comma-separated list of column names for the CSV export.
@@ -222,53 +296,39 @@ Output
   - A channel of strings that can be iterated over.
 */
 func (client *Szengine) ExportCsvEntityReportIterator(ctx context.Context, csvColumnList string, flags int64) chan senzing.StringFragment {
-	stringFragmentChannel := make(chan senzing.StringFragment)
+	stringFragmentChannel := make(chan senzing.StringFragment, 1)
 	go func() {
 		defer close(stringFragmentChannel)
 		var err error
 		if client.isTrace {
 			entryTime := time.Now()
-			client.traceEntry(15, csvColumnList, flags)
-			defer func() { client.traceExit(16, csvColumnList, flags, err, time.Since(entryTime)) }()
+			client.traceEntry(15, csvColumnList, szflags.ExportFlags(flags))
+			defer func() { client.traceExit(16, csvColumnList, szflags.ExportFlags(flags), err, time.Since(entryTime)) }()
 		}
+		exportCtx, cancel := context.WithCancel(ctx) // Releases the server stream before the final error fragment is sent.
+		defer cancel()
 		request := szpb.StreamExportCsvEntityReportRequest{
 			CsvColumnList: csvColumnList,
 			Flags:         flags,
 		}
-		stream, err := client.GrpcClient.StreamExportCsvEntityReport(ctx, &request)
-		if err != nil {
-			stringFragmentChannel <- senzing.StringFragment{
-				Error: helper.ConvertGrpcError(err),
-			}
-			return
-		}
-	forLoop:
-		for {
-			select {
-			case <-ctx.Done():
-				stringFragmentChannel <- senzing.StringFragment{
-					Error: helper.ConvertGrpcError(ctx.Err()),
-				}
-				break forLoop
-			default:
+		stream, err := client.GrpcClient.StreamExportCsvEntityReport(exportCtx, &request)
+		if err == nil {
+			err = client.sendStringFragments(exportCtx, stringFragmentChannel, func() (string, error) {
 				response, err := stream.Recv()
-				if err != nil {
-					if errors.Is(err, io.EOF) {
-						break forLoop
-					}
-					stringFragmentChannel <- senzing.StringFragment{
-						Error: helper.ConvertGrpcError(err),
-					}
-					break forLoop
-				}
-				stringFragmentChannel <- senzing.StringFragment{
-					Value: response.GetResult(),
-				}
-			}
+				return response.GetResult(), err
+			})
+		} else {
+			err = helper.ConvertGrpcError(err)
+		}
+		cancel()
+		if err != nil {
+			client.sendErrorFragment(ctx, stringFragmentChannel, err)
 		}
 		if client.observers != nil {
 			go func() {
-				details := map[string]string{}
+				details := map[string]string{
+					"flags": szflags.ExportFlags(flags).String(),
+				}
 				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
 			}()
 		}
@@ -276,6 +336,54 @@ func (client *Szengine) ExportCsvEntityReportIterator(ctx context.Context, csvCo
 	return stringFragmentChannel
 }
 
+/*
+The ExportJSONEntities method returns an iterator over a document of exported entities in JSON Lines format,
+for use in a range-over-func loop.
+Each iteration yields a line of the document, or an error that ends the iteration.
+Breaking out of the loop cancels the underlying StreamExportJsonEntityReport RPC.
+The loop body may take as long as it needs: ExportStallTimeout does not apply.
+
+Input
+  - ctx: A context to control lifecycle.
+  - flags: Flags used to control information returned.
+
+Output
+  - An iterator of lines and errors.
+*/
+func (client *Szengine) ExportJSONEntities(ctx context.Context, flags int64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		var err error
+		if client.isTrace {
+			entryTime := time.Now()
+			client.traceEntry(79, szflags.ExportFlags(flags))
+			defer func() { client.traceExit(80, szflags.ExportFlags(flags), err, time.Since(entryTime)) }()
+		}
+		exportCtx, cancel := context.WithCancel(ctx) // Releases the server stream when the loop ends.
+		defer cancel()
+		request := szpb.StreamExportJsonEntityReportRequest{
+			Flags: flags,
+		}
+		stream, err := client.GrpcClient.StreamExportJsonEntityReport(exportCtx, &request)
+		if err == nil {
+			err = yieldStrings(exportCtx, yield, func() (string, error) {
+				response, err := stream.Recv()
+				return response.GetResult(), err
+			})
+		} else {
+			err = helper.ConvertGrpcError(err)
+			yield("", err)
+		}
+		if client.observers != nil {
+			go func() {
+				details := map[string]string{
+					"flags": szflags.ExportFlags(flags).String(),
+				}
+				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8036, err, details)
+			}()
+		}
+	}
+}
+
 /*
 The ExportJSONEntityReport method initializes a cursor over a document of exported entities.
 It is part of the ExportJSONEntityReport(), FetchNext(), CloseExport()
@@ -293,13 +401,15 @@ func (client *Szengine) ExportJSONEntityReport(ctx context.Context, flags int64)
 	var result uintptr
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(17, flags)
-		defer func() { client.traceExit(18, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(17, szflags.ExportFlags(flags))
+		defer func() { client.traceExit(18, szflags.ExportFlags(flags), result, err, time.Since(entryTime)) }()
 	}
 	result, err = client.exportJSONEntityReport(ctx, flags)
 	if client.observers != nil {
 		go func() {
-			details := map[string]string{}
+			details := map[string]string{
+				"flags": szflags.ExportFlags(flags).String(),
+			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
 		}()
 	}
@@ -312,6 +422,15 @@ to scroll through a document of exported entities.
 It is a convenience method for the ExportJSONEntityReport(), FetchNext(), CloseExport()
 lifecycle of a list of entities to export.
 
+A goroutine receives the export and sends it to the channel, which buffers one fragment.
+To stop reading early, cancel ctx: the underlying RPC is cancelled at once.
+A consumer that stops reading without cancelling ctx, or that takes longer than ExportStallTimeout
+(DefaultExportStallTimeout if zero) to read a fragment, is taken to have stopped:
+the RPC is cancelled and the export ends with ErrExportAbandoned.
+An export that ends with an error sends a final fragment with the error,
+after the fragment already buffered, waiting up to ExportStallTimeout for the consumer to read them.
+Once ctx is cancelled, the goroutine does not wait: an error fragment that cannot be sent at once is dropped.
+
 Input
   - ctx: A context to control lifecycle.
   - flags: Flags used to control information returned.
@@ -320,52 +439,38 @@ Output
   - A channel of strings that can be iterated over.
 */
 func (client *Szengine) ExportJSONEntityReportIterator(ctx context.Context, flags int64) chan senzing.StringFragment {
-	stringFragmentChannel := make(chan senzing.StringFragment)
+	stringFragmentChannel := make(chan senzing.StringFragment, 1)
 	go func() {
 		defer close(stringFragmentChannel)
 		var err error
 		if client.isTrace {
 			entryTime := time.Now()
-			client.traceEntry(19, flags)
-			defer func() { client.traceExit(20, flags, err, time.Since(entryTime)) }()
+			client.traceEntry(19, szflags.ExportFlags(flags))
+			defer func() { client.traceExit(20, szflags.ExportFlags(flags), err, time.Since(entryTime)) }()
 		}
+		exportCtx, cancel := context.WithCancel(ctx) // Releases the server stream before the final error fragment is sent.
+		defer cancel()
 		request := szpb.StreamExportJsonEntityReportRequest{
 			Flags: flags,
 		}
-		stream, err := client.GrpcClient.StreamExportJsonEntityReport(ctx, &request)
-		if err != nil {
-			stringFragmentChannel <- senzing.StringFragment{
-				Error: helper.ConvertGrpcError(err),
-			}
-			return
-		}
-	forLoop:
-		for {
-			select {
-			case <-ctx.Done():
-				stringFragmentChannel <- senzing.StringFragment{
-					Error: helper.ConvertGrpcError(ctx.Err()),
-				}
-				break forLoop
-			default:
+		stream, err := client.GrpcClient.StreamExportJsonEntityReport(exportCtx, &request)
+		if err == nil {
+			err = client.sendStringFragments(exportCtx, stringFragmentChannel, func() (string, error) {
 				response, err := stream.Recv()
-				if err != nil {
-					if errors.Is(err, io.EOF) {
-						break forLoop
-					}
-					stringFragmentChannel <- senzing.StringFragment{
-						Error: helper.ConvertGrpcError(err),
-					}
-					break forLoop
-				}
-				stringFragmentChannel <- senzing.StringFragment{
-					Value: response.GetResult(),
-				}
-			}
+				return response.GetResult(), err
+			})
+		} else {
+			err = helper.ConvertGrpcError(err)
+		}
+		cancel()
+		if err != nil {
+			client.sendErrorFragment(ctx, stringFragmentChannel, err)
 		}
 		if client.observers != nil {
 			go func() {
-				details := map[string]string{}
+				details := map[string]string{
+					"flags": szflags.ExportFlags(flags).String(),
+				}
 				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8009, err, details)
 			}()
 		}
@@ -421,14 +526,15 @@ func (client *Szengine) FindInterestingEntitiesByEntityID(ctx context.Context, e
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(23, entityID, flags)
-		defer func() { client.traceExit(24, entityID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(23, entityID, szflags.Flags(flags))
+		defer func() { client.traceExit(24, entityID, szflags.Flags(flags), result, err, time.Since(entryTime)) }()
 	}
 	result, err = client.findInterestingEntitiesByEntityID(ctx, entityID, flags)
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
+				"flags":    szflags.Flags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8011, err, details)
 		}()
@@ -455,8 +561,10 @@ func (client *Szengine) FindInterestingEntitiesByRecordID(ctx context.Context, d
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(25, dataSourceCode, recordID, flags)
-		defer func() { client.traceExit(26, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(25, dataSourceCode, recordID, szflags.Flags(flags))
+		defer func() {
+			client.traceExit(26, dataSourceCode, recordID, szflags.Flags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.findInterestingEntitiesByRecordID(ctx, dataSourceCode, recordID, flags)
 	if client.observers != nil {
@@ -464,6 +572,7 @@ func (client *Szengine) FindInterestingEntitiesByRecordID(ctx context.Context, d
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
+				"flags":          szflags.Flags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8012, err, details)
 		}()
@@ -493,9 +602,9 @@ func (client *Szengine) FindNetworkByEntityID(ctx context.Context, entityIDs str
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(27, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
+		client.traceEntry(27, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, szflags.FindNetworkFlags(flags))
 		defer func() {
-			client.traceExit(28, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags, result, err, time.Since(entryTime))
+			client.traceExit(28, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, szflags.FindNetworkFlags(flags), result, err, time.Since(entryTime))
 		}()
 	}
 	result, err = client.findNetworkByEntityID(ctx, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
@@ -503,6 +612,7 @@ func (client *Szengine) FindNetworkByEntityID(ctx context.Context, entityIDs str
 		go func() {
 			details := map[string]string{
 				"entityIDs": entityIDs,
+				"flags":     szflags.FindNetworkFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8013, err, details)
 		}()
@@ -532,9 +642,9 @@ func (client *Szengine) FindNetworkByRecordID(ctx context.Context, recordKeys st
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(39, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
+		client.traceEntry(39, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, szflags.FindNetworkFlags(flags))
 		defer func() {
-			client.traceExit(40, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags, result, err, time.Since(entryTime))
+			client.traceExit(40, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, szflags.FindNetworkFlags(flags), result, err, time.Since(entryTime))
 		}()
 	}
 	result, err = client.findNetworkByRecordID(ctx, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
@@ -542,6 +652,7 @@ func (client *Szengine) FindNetworkByRecordID(ctx context.Context, recordKeys st
 		go func() {
 			details := map[string]string{
 				"recordKeys": recordKeys,
+				"flags":      szflags.FindNetworkFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8014, err, details)
 		}()
@@ -571,9 +682,9 @@ func (client *Szengine) FindPathByEntityID(ctx context.Context, startEntityID in
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(31, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags)
+		client.traceEntry(31, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, szflags.FindPathFlags(flags))
 		defer func() {
-			client.traceExit(32, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags, result, err, time.Since(entryTime))
+			client.traceExit(32, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, szflags.FindPathFlags(flags), result, err, time.Since(entryTime))
 		}()
 	}
 	result, err = client.findPathByEntityID(ctx, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags)
@@ -584,6 +695,7 @@ func (client *Szengine) FindPathByEntityID(ctx context.Context, startEntityID in
 				"endEntityID":         formatEntityID(endEntityID),
 				"avoidEntityIDs":      avoidEntityIDs,
 				"requiredDataSources": requiredDataSources,
+				"flags":               szflags.FindPathFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8015, err, details)
 		}()
@@ -617,9 +729,9 @@ func (client *Szengine) FindPathByRecordID(ctx context.Context, startDataSourceC
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(33, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags)
+		client.traceEntry(33, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, szflags.FindPathFlags(flags))
 		defer func() {
-			client.traceExit(34, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags, result, err, time.Since(entryTime))
+			client.traceExit(34, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, szflags.FindPathFlags(flags), result, err, time.Since(entryTime))
 		}()
 	}
 	result, err = client.findPathByRecordID(ctx, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags)
@@ -632,6 +744,7 @@ func (client *Szengine) FindPathByRecordID(ctx context.Context, startDataSourceC
 				"endRecordID":         endRecordID,
 				"avoidRecordKeys":     avoidRecordKeys,
 				"requiredDataSources": requiredDataSources,
+				"flags":               szflags.FindPathFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8016, err, details)
 		}()
@@ -684,14 +797,15 @@ func (client *Szengine) GetEntityByEntityID(ctx context.Context, entityID int64,
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(37, entityID, flags)
-		defer func() { client.traceExit(38, entityID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(37, entityID, szflags.EntityFlags(flags))
+		defer func() { client.traceExit(38, entityID, szflags.EntityFlags(flags), result, err, time.Since(entryTime)) }()
 	}
 	result, err = client.getEntityByEntityID(ctx, entityID, flags)
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
+				"flags":    szflags.EntityFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8018, err, details)
 		}()
@@ -717,8 +831,10 @@ func (client *Szengine) GetEntityByRecordID(ctx context.Context, dataSourceCode
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(39, dataSourceCode, recordID, flags)
-		defer func() { client.traceExit(40, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(39, dataSourceCode, recordID, szflags.EntityFlags(flags))
+		defer func() {
+			client.traceExit(40, dataSourceCode, recordID, szflags.EntityFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.getEntityByRecordID(ctx, dataSourceCode, recordID, flags)
 	if client.observers != nil {
@@ -726,6 +842,7 @@ func (client *Szengine) GetEntityByRecordID(ctx context.Context, dataSourceCode
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
+				"flags":          szflags.EntityFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8019, err, details)
 		}()
@@ -751,8 +868,10 @@ func (client *Szengine) GetRecord(ctx context.Context, dataSourceCode string, re
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(45, dataSourceCode, recordID, flags)
-		defer func() { client.traceExit(46, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(45, dataSourceCode, recordID, szflags.RecordFlags(flags))
+		defer func() {
+			client.traceExit(46, dataSourceCode, recordID, szflags.RecordFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.getRecord(ctx, dataSourceCode, recordID, flags)
 	if client.observers != nil {
@@ -760,6 +879,7 @@ func (client *Szengine) GetRecord(ctx context.Context, dataSourceCode string, re
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
+				"flags":          szflags.RecordFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8020, err, details)
 		}()
@@ -844,14 +964,18 @@ func (client *Szengine) GetVirtualEntityByRecordID(ctx context.Context, recordKe
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(51, recordKeys, flags)
-		defer func() { client.traceExit(52, recordKeys, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(51, recordKeys, szflags.EntityFlags(flags))
+		defer func() {
+			client.traceExit(52, recordKeys, szflags.EntityFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.getVirtualEntityByRecordID(ctx, recordKeys, flags)
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
-				"recordKeys": recordKeys}
+				"recordKeys": recordKeys,
+				"flags":      szflags.EntityFlags(flags).String(),
+			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8023, err, details)
 		}()
 	}
@@ -876,14 +1000,15 @@ func (client *Szengine) HowEntityByEntityID(ctx context.Context, entityID int64,
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(53, entityID, flags)
-		defer func() { client.traceExit(54, entityID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(53, entityID, szflags.HowFlags(flags))
+		defer func() { client.traceExit(54, entityID, szflags.HowFlags(flags), result, err, time.Since(entryTime)) }()
 	}
 	result, err = client.howEntityByEntityID(ctx, entityID, flags)
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
+				"flags":    szflags.HowFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8024, err, details)
 		}()
@@ -931,13 +1056,17 @@ func (client *Szengine) ProcessRedoRecord(ctx context.Context, redoRecord string
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(59, redoRecord, flags)
-		defer func() { client.traceExit(60, redoRecord, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(59, redoRecord, szflags.WithInfoFlags(flags))
+		defer func() {
+			client.traceExit(60, redoRecord, szflags.WithInfoFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.processRedoRecord(ctx, redoRecord, flags)
 	if client.observers != nil {
 		go func() {
-			details := map[string]string{}
+			details := map[string]string{
+				"flags": szflags.WithInfoFlags(flags).String(),
+			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8027, err, details)
 		}()
 	}
@@ -958,14 +1087,17 @@ func (client *Szengine) ReevaluateEntity(ctx context.Context, entityID int64, fl
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(61, entityID, flags)
-		defer func() { client.traceExit(62, entityID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(61, entityID, szflags.WithInfoFlags(flags))
+		defer func() {
+			client.traceExit(62, entityID, szflags.WithInfoFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.reevaluateEntity(ctx, entityID, flags)
 	if client.observers != nil {
 		go func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
+				"flags":    szflags.WithInfoFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8028, err, details)
 		}()
@@ -988,8 +1120,10 @@ func (client *Szengine) ReevaluateRecord(ctx context.Context, dataSourceCode str
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(63, dataSourceCode, recordID, flags)
-		defer func() { client.traceExit(64, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(63, dataSourceCode, recordID, szflags.WithInfoFlags(flags))
+		defer func() {
+			client.traceExit(64, dataSourceCode, recordID, szflags.WithInfoFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.reevaluateRecord(ctx, dataSourceCode, recordID, flags)
 	if client.observers != nil {
@@ -997,6 +1131,7 @@ func (client *Szengine) ReevaluateRecord(ctx context.Context, dataSourceCode str
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
+				"flags":          szflags.WithInfoFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8029, err, details)
 		}()
@@ -1048,8 +1183,10 @@ func (client *Szengine) SearchByAttributes(ctx context.Context, attributes strin
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(69, attributes, searchProfile, flags)
-		defer func() { client.traceExit(70, attributes, searchProfile, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(69, attributes, searchProfile, szflags.SearchFlags(flags))
+		defer func() {
+			client.traceExit(70, attributes, searchProfile, szflags.SearchFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.searchByAttributes(ctx, attributes, searchProfile, flags)
 	if client.observers != nil {
@@ -1057,6 +1194,7 @@ func (client *Szengine) SearchByAttributes(ctx context.Context, attributes strin
 			details := map[string]string{
 				"attributes":    attributes,
 				"searchProfile": searchProfile,
+				"flags":         szflags.SearchFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8031, err, details)
 		}()
@@ -1085,8 +1223,10 @@ func (client *Szengine) WhyEntities(ctx context.Context, entityID1 int64, entity
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(71, entityID1, entityID2, flags)
-		defer func() { client.traceExit(72, entityID1, entityID2, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(71, entityID1, entityID2, szflags.WhyFlags(flags))
+		defer func() {
+			client.traceExit(72, entityID1, entityID2, szflags.WhyFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.whyEntities(ctx, entityID1, entityID2, flags)
 	if client.observers != nil {
@@ -1094,6 +1234,7 @@ func (client *Szengine) WhyEntities(ctx context.Context, entityID1 int64, entity
 			details := map[string]string{
 				"entityID1": formatEntityID(entityID1),
 				"entityID2": formatEntityID(entityID2),
+				"flags":     szflags.WhyFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8032, err, details)
 		}()
@@ -1120,8 +1261,10 @@ func (client *Szengine) WhyRecordInEntity(ctx context.Context, dataSourceCode st
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(73, dataSourceCode, recordID, flags)
-		defer func() { client.traceExit(74, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
+		client.traceEntry(73, dataSourceCode, recordID, szflags.WhyFlags(flags))
+		defer func() {
+			client.traceExit(74, dataSourceCode, recordID, szflags.WhyFlags(flags), result, err, time.Since(entryTime))
+		}()
 	}
 	result, err = client.whyRecordInEntity(ctx, dataSourceCode, recordID, flags)
 	if client.observers != nil {
@@ -1129,6 +1272,7 @@ func (client *Szengine) WhyRecordInEntity(ctx context.Context, dataSourceCode st
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
+				"flags":          szflags.WhyFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8033, err, details)
 		}()
@@ -1156,9 +1300,9 @@ func (client *Szengine) WhyRecords(ctx context.Context, dataSourceCode1 string,
 	var result string
 	if client.isTrace {
 		entryTime := time.Now()
-		client.traceEntry(75, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
+		client.traceEntry(75, dataSourceCode1, recordID1, dataSourceCode2, recordID2, szflags.WhyFlags(flags))
 		defer func() {
-			client.traceExit(76, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags, result, err, time.Since(entryTime))
+			client.traceExit(76, dataSourceCode1, recordID1, dataSourceCode2, recordID2, szflags.WhyFlags(flags), result, err, time.Since(entryTime))
 		}()
 	}
 	result, err = client.whyRecords(ctx, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
@@ -1169,6 +1313,7 @@ func (client *Szengine) WhyRecords(ctx context.Context, dataSourceCode1 string,
 				"recordID1":       recordID1,
 				"dataSourceCode2": dataSourceCode2,
 				"recordID2":       recordID2,
+				"flags":           szflags.WhyFlags(flags).String(),
 			}
 			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8034, err, details)
 		}()
@@ -1329,6 +1474,361 @@ func (client *Szengine) UnregisterObserver(ctx context.Context, observer observe
 	return err
 }
 
+// ----------------------------------------------------------------------------
+// Typed methods
+// ----------------------------------------------------------------------------
+
+/*
+The FindNetworkByEntityIDTyped method finds a network of paths between entities, as FindNetworkByEntityID() does, and decodes the result.
+The flags and entity IDs are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityIDs: The entities of the network.
+  - maxDegrees: The maximum number of degrees for paths between entities.
+  - buildOutDegree: The degree of relationships to expand around the entities.
+  - buildOutMaxEntities: The maximum number of entities added by expanding.
+  - flags: Flags used to control information returned, such as szflags.DefaultFindNetworkFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) FindNetworkByEntityIDTyped(ctx context.Context, entityIDs szmodel.EntityIDs, maxDegrees int64, buildOutDegree int64, buildOutMaxEntities int64, flags szflags.FindNetworkFlags) (*szmodel.FindNetworkResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	entityList, err := entityIDs.JSON()
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.FindNetworkByEntityID(ctx, entityList, maxDegrees, buildOutDegree, buildOutMaxEntities, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.FindNetworkResponse](result)
+}
+
+/*
+The FindNetworkByRecordIDTyped method finds a network of paths between the entities of records, as FindNetworkByRecordID() does, and decodes the result.
+The flags and record keys are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - recordKeys: The records whose entities are in the network.
+  - maxDegrees: The maximum number of degrees for paths between entities.
+  - buildOutDegree: The degree of relationships to expand around the entities.
+  - buildOutMaxEntities: The maximum number of entities added by expanding.
+  - flags: Flags used to control information returned, such as szflags.DefaultFindNetworkFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) FindNetworkByRecordIDTyped(ctx context.Context, recordKeys szmodel.RecordKeys, maxDegrees int64, buildOutDegree int64, buildOutMaxEntities int64, flags szflags.FindNetworkFlags) (*szmodel.FindNetworkResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	recordList, err := recordKeys.JSON()
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.FindNetworkByRecordID(ctx, recordList, maxDegrees, buildOutDegree, buildOutMaxEntities, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.FindNetworkResponse](result)
+}
+
+/*
+The FindPathByEntityIDTyped method finds a path between two entities, as FindPathByEntityID() does, and decodes the result.
+The flags and lists are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - startEntityID: The entity ID of the start of the path.
+  - endEntityID: The entity ID of the end of the path.
+  - maxDegrees: The maximum number of degrees of the path.
+  - avoidEntityIDs: Entities to avoid, or nil.
+  - requiredDataSources: Data sources the path must include, or nil.
+  - flags: Flags used to control information returned, such as szflags.DefaultFindPathFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) FindPathByEntityIDTyped(ctx context.Context, startEntityID int64, endEntityID int64, maxDegrees int64, avoidEntityIDs szmodel.EntityIDs, requiredDataSources szmodel.DataSourceList, flags szflags.FindPathFlags) (*szmodel.FindPathResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	avoidList, err := optionalJSON(avoidEntityIDs)
+	if err != nil {
+		return nil, err
+	}
+	requiredList, err := optionalJSON(requiredDataSources)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.FindPathByEntityID(ctx, startEntityID, endEntityID, maxDegrees, avoidList, requiredList, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.FindPathResponse](result)
+}
+
+/*
+The FindPathByRecordIDTyped method finds a path between the entities of two records, as FindPathByRecordID() does, and decodes the result.
+The flags and lists are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - startDataSourceCode: The data source of the record at the start of the path.
+  - startRecordID: The ID of the record at the start of the path.
+  - endDataSourceCode: The data source of the record at the end of the path.
+  - endRecordID: The ID of the record at the end of the path.
+  - maxDegrees: The maximum number of degrees of the path.
+  - avoidRecordKeys: Records whose entities are avoided, or nil.
+  - requiredDataSources: Data sources the path must include, or nil.
+  - flags: Flags used to control information returned, such as szflags.DefaultFindPathFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) FindPathByRecordIDTyped(ctx context.Context, startDataSourceCode string, startRecordID string, endDataSourceCode string, endRecordID string, maxDegrees int64, avoidRecordKeys szmodel.RecordKeys, requiredDataSources szmodel.DataSourceList, flags szflags.FindPathFlags) (*szmodel.FindPathResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	avoidList, err := optionalJSON(avoidRecordKeys)
+	if err != nil {
+		return nil, err
+	}
+	requiredList, err := optionalJSON(requiredDataSources)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.FindPathByRecordID(ctx, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidList, requiredList, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.FindPathResponse](result)
+}
+
+/*
+The GetEntityByEntityIDTyped method returns an entity, as GetEntityByEntityID() does, decoded.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID: The unique identifier of an entity.
+  - flags: Flags used to control information returned, such as szflags.DefaultEntityFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) GetEntityByEntityIDTyped(ctx context.Context, entityID int64, flags szflags.EntityFlags) (*szmodel.EntityResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.GetEntityByEntityID(ctx, entityID, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.EntityResponse](result)
+}
+
+/*
+The GetEntityByRecordIDTyped method returns the entity of a record, as GetEntityByRecordID() does, decoded.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned, such as szflags.DefaultEntityFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) GetEntityByRecordIDTyped(ctx context.Context, dataSourceCode string, recordID string, flags szflags.EntityFlags) (*szmodel.EntityResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.GetEntityByRecordID(ctx, dataSourceCode, recordID, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.EntityResponse](result)
+}
+
+/*
+The GetRecordTyped method returns a record, as GetRecord() does, decoded.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned, such as szflags.DefaultRecordFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) GetRecordTyped(ctx context.Context, dataSourceCode string, recordID string, flags szflags.RecordFlags) (*szmodel.Record, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.GetRecord(ctx, dataSourceCode, recordID, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.Record](result)
+}
+
+/*
+The GetVirtualEntityByRecordIDTyped method returns the entity that a set of records would form, as GetVirtualEntityByRecordID() does, decoded.
+The flags and record keys are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - recordKeys: The records of the virtual entity.
+  - flags: Flags used to control information returned, such as szflags.DefaultEntityFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) GetVirtualEntityByRecordIDTyped(ctx context.Context, recordKeys szmodel.RecordKeys, flags szflags.EntityFlags) (*szmodel.EntityResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	recordList, err := recordKeys.JSON()
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.GetVirtualEntityByRecordID(ctx, recordList, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.EntityResponse](result)
+}
+
+/*
+The HowEntityByEntityIDTyped method explains how an entity was resolved, as HowEntityByEntityID() does, and decodes the result.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID: The unique identifier of an entity.
+  - flags: Flags used to control information returned, such as szflags.DefaultHowFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) HowEntityByEntityIDTyped(ctx context.Context, entityID int64, flags szflags.HowFlags) (*szmodel.HowResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.HowEntityByEntityID(ctx, entityID, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.HowResponse](result)
+}
+
+/*
+The SearchByAttributesTyped method searches for entities that match attributes, as SearchByAttributes() does, and decodes the result.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - attributes: A JSON document of the attributes searched for.
+  - searchProfile: The name of a search profile, or an empty string for the default.
+  - flags: Flags used to control information returned, such as szflags.DefaultSearchFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) SearchByAttributesTyped(ctx context.Context, attributes string, searchProfile string, flags szflags.SearchFlags) (*szmodel.SearchResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.SearchByAttributes(ctx, attributes, searchProfile, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.SearchResponse](result)
+}
+
+/*
+The WhyEntitiesTyped method explains how two entities are related, as WhyEntities() does, and decodes the result.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityID1: The first of two entity IDs.
+  - entityID2: The second of two entity IDs.
+  - flags: Flags used to control information returned, such as szflags.DefaultWhyFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) WhyEntitiesTyped(ctx context.Context, entityID1 int64, entityID2 int64, flags szflags.WhyFlags) (*szmodel.WhyResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.WhyEntities(ctx, entityID1, entityID2, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.WhyResponse](result)
+}
+
+/*
+The WhyRecordInEntityTyped method explains why a record is in its entity, as WhyRecordInEntity() does, and decodes the result.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned, such as szflags.DefaultWhyFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) WhyRecordInEntityTyped(ctx context.Context, dataSourceCode string, recordID string, flags szflags.WhyFlags) (*szmodel.WhyResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.WhyRecordInEntity(ctx, dataSourceCode, recordID, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.WhyResponse](result)
+}
+
+/*
+The WhyRecordsTyped method explains how two records match, as WhyRecords() does, and decodes the result.
+The flags are validated before the request is sent.
+
+Input
+  - ctx: A context to control lifecycle.
+  - dataSourceCode1: Identifies the provenance of the data.
+  - recordID1: The unique identifier within the records of the same data source.
+  - dataSourceCode2: Identifies the provenance of the data.
+  - recordID2: The unique identifier within the records of the same data source.
+  - flags: Flags used to control information returned, such as szflags.DefaultWhyFlags.
+
+Output
+  - The decoded JSON document.
+*/
+func (client *Szengine) WhyRecordsTyped(ctx context.Context, dataSourceCode1 string, recordID1 string, dataSourceCode2 string, recordID2 string, flags szflags.WhyFlags) (*szmodel.WhyResponse, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := client.WhyRecords(ctx, dataSourceCode1, recordID1, dataSourceCode2, recordID2, int64(flags))
+	if err != nil {
+		return nil, err
+	}
+	return szmodel.Unmarshal[szmodel.WhyResponse](result)
+}
+
 // ----------------------------------------------------------------------------
 // Private methods for gRPC request/response
 // ----------------------------------------------------------------------------
@@ -1665,12 +2165,75 @@ func (client *Szengine) whyRecords(ctx context.Context, dataSourceCode1 string,
 // Internal methods
 // ----------------------------------------------------------------------------
 
+// --- Export iterators -------------------------------------------------------
+
+func (client *Szengine) getExportStallTimeout() time.Duration {
+	if client.ExportStallTimeout > 0 {
+		return client.ExportStallTimeout
+	}
+	return DefaultExportStallTimeout
+}
+
+/*
+Send a final error fragment, after the fragment already buffered in the channel, if any.
+Waits up to the stall timeout for the consumer to read, so the goroutine cannot block forever,
+and not at all once ctx is cancelled: then a fragment that cannot be sent at once is dropped.
+*/
+func (client *Szengine) sendErrorFragment(ctx context.Context, stringFragmentChannel chan senzing.StringFragment, err error) {
+	select {
+	case stringFragmentChannel <- senzing.StringFragment{Error: err}:
+		return
+	default:
+	}
+	stallTimer := time.NewTimer(client.getExportStallTimeout())
+	defer stallTimer.Stop()
+	select {
+	case stringFragmentChannel <- senzing.StringFragment{Error: err}:
+	case <-ctx.Done():
+	case <-stallTimer.C:
+	}
+}
+
+/*
+Send values from receive() to the channel until receive() reports io.EOF or an error.
+Every send also waits on ctx and on the stall timeout, so the goroutine cannot block forever
+when the consumer cancels or stops reading.
+The returned error is nil when the export completes; otherwise it is for the caller to send with sendErrorFragment.
+*/
+func (client *Szengine) sendStringFragments(ctx context.Context, stringFragmentChannel chan senzing.StringFragment, receive func() (string, error)) error {
+	stallTimer := time.NewTimer(client.getExportStallTimeout())
+	defer stallTimer.Stop()
+	for {
+		value, err := receive()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return helper.ConvertGrpcError(err)
+		}
+		if !stallTimer.Stop() {
+			<-stallTimer.C
+		}
+		stallTimer.Reset(client.getExportStallTimeout())
+		select {
+		case stringFragmentChannel <- senzing.StringFragment{Value: value}:
+		case <-ctx.Done():
+			return helper.ConvertGrpcError(ctx.Err())
+		case <-stallTimer.C:
+			return ErrExportAbandoned
+		}
+	}
+}
+
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
 func (client *Szengine) getLogger() logging.Logging {
 	if client.logger == nil {
-		client.logger = helper.GetLogger(ComponentID, szengine.IDMessages, baseCallerSkip)
+		client.logger = helper.GetLogger(ComponentID, getIDMessages(), baseCallerSkip)
 	}
 	return client.logger
 }
@@ -1688,3 +2251,50 @@ func (client *Szengine) traceExit(errorNumber int, details ...interface{}) {
 func formatEntityID(entityID int64) string {
 	return strconv.FormatInt(entityID, baseTen)
 }
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The message templates of szengine.IDMessages and of the methods this client adds.
+func getIDMessages() map[int]string {
+	result := maps.Clone(szengine.IDMessages)
+	maps.Copy(result, idMessages)
+	return result
+}
+
+// Build the document of an optional list parameter.  An empty list is an empty string.
+func optionalJSON[List interface {
+	~[]Element
+	JSON() (string, error)
+}, Element any](list List) (string, error) {
+	if len(list) == 0 {
+		return "", nil
+	}
+	return list.JSON()
+}
+
+/*
+Yield values from receive() until receive() reports io.EOF or an error, or the loop body breaks.
+An error is yielded once, with an empty value.
+The returned error is nil when the export completes or the loop body breaks.
+*/
+func yieldStrings(ctx context.Context, yield func(string, error) bool, receive func() (string, error)) error {
+	for {
+		value, err := receive()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			err = helper.ConvertGrpcError(err)
+			yield("", err)
+			return err
+		}
+		if !yield(value, nil) {
+			return nil
+		}
+	}
+}
@@ -124,6 +124,25 @@ func ExampleSzengine_DeleteRecord_withInfo() {
 	// Output: {"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1003","AFFECTED_ENTITIES":[],"INTERESTING_ENTITIES":{"ENTITIES":[]}}
 }
 
+func ExampleSzengine_ExportCsvEntities() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szengine/szengine_examples_test.go
+	ctx := context.TODO()
+	szEngine, err := getSzEngine(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	csvColumnList := ""
+	flags := senzing.SzNoFlags
+	for line, err := range szEngine.ExportCsvEntities(ctx, csvColumnList, flags) {
+		if err != nil {
+			fmt.Println(err)
+			break
+		}
+		fmt.Println(line)
+	}
+	// Output: RESOLVED_ENTITY_ID,RELATED_ENTITY_ID,MATCH_LEVEL_CODE,MATCH_KEY,DATA_SOURCE,RECORD_ID
+}
+
 func ExampleSzengine_ExportCsvEntityReport() {
 	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szengine/szengine_examples_test.go
 	ctx := context.TODO()
@@ -154,6 +173,24 @@ func ExampleSzengine_ExportCsvEntityReportIterator() {
 	// Output: RESOLVED_ENTITY_ID,RELATED_ENTITY_ID,MATCH_LEVEL_CODE,MATCH_KEY,DATA_SOURCE,RECORD_ID
 }
 
+func ExampleSzengine_ExportJSONEntities() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szengine/szengine_examples_test.go
+	ctx := context.TODO()
+	szEngine, err := getSzEngine(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	flags := senzing.SzNoFlags
+	for line, err := range szEngine.ExportJSONEntities(ctx, flags) {
+		if err != nil {
+			fmt.Println(err)
+			break
+		}
+		fmt.Println(line)
+	}
+	// Output:
+}
+
 func ExampleSzengine_ExportJSONEntityReport() {
 	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szengine/szengine_examples_test.go
 	ctx := context.TODO()
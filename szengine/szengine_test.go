@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,10 +19,13 @@ import (
 	"github.com/senzing-garage/go-helpers/testfixtures"
 	"github.com/senzing-garage/go-helpers/truthset"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
 	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
 	"github.com/senzing-garage/sz-sdk-go-grpc/szconfig"
 	"github.com/senzing-garage/sz-sdk-go-grpc/szconfigmanager"
 	"github.com/senzing-garage/sz-sdk-go-grpc/szdiagnostic"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szflags"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szerror"
 	szconfigpb "github.com/senzing-garage/sz-sdk-proto/go/szconfig"
@@ -30,6 +36,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 const (
@@ -47,6 +54,8 @@ const (
 	badRedoRecord          = "{}"
 	badRequiredDataSources = "}{"
 	badSearchProfile       = "}{"
+	bufconnAddress         = "passthrough:///bufnet"
+	bufconnBufferSize      = 1024 * 1024
 	defaultTruncation      = 76
 	instanceName           = "SzEngine Test"
 	observerOrigin         = "SzEngine observer"
@@ -54,10 +63,48 @@ const (
 	verboseLogging         = senzing.SzNoLogging
 )
 
-type GetEntityByRecordIDResponse struct {
-	ResolvedEntity struct {
-		EntityID int64 `json:"ENTITY_ID"`
-	} `json:"RESOLVED_ENTITY"`
+// An observer that sends its messages to a channel.
+type channelObserver struct {
+	messages chan string
+}
+
+func (observer *channelObserver) GetObserverID(ctx context.Context) string {
+	_ = ctx
+	return "channelObserver"
+}
+
+func (observer *channelObserver) UpdateObserver(ctx context.Context, message string) {
+	_ = ctx
+	observer.messages <- message
+}
+
+// A server that streams export fragments until the client goes away, or until fragments have been sent if non-zero.
+type testExportServer struct {
+	szpb.UnimplementedSzEngineServer
+	done      chan struct{}
+	fragments atomic.Int64
+}
+
+func (server *testExportServer) StreamExportCsvEntityReport(request *szpb.StreamExportCsvEntityReportRequest, stream szpb.SzEngine_StreamExportCsvEntityReportServer) error {
+	_ = request
+	defer func() { server.done <- struct{}{} }()
+	for i, fragments := int64(0), server.fragments.Load(); fragments == 0 || i < fragments; i++ {
+		if err := stream.Send(&szpb.StreamExportCsvEntityReportResponse{Result: "RESOLVED_ENTITY_ID"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (server *testExportServer) StreamExportJsonEntityReport(request *szpb.StreamExportJsonEntityReportRequest, stream szpb.SzEngine_StreamExportJsonEntityReportServer) error {
+	_ = request
+	defer func() { server.done <- struct{}{} }()
+	for i, fragments := int64(0), server.fragments.Load(); fragments == 0 || i < fragments; i++ {
+		if err := stream.Send(&szpb.StreamExportJsonEntityReportResponse{Result: `{"RESOLVED_ENTITY":{"ENTITY_ID":1}}`}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 var (
@@ -576,6 +623,34 @@ func TestSzengine_FindNetworkByEntityID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_FindNetworkByEntityIDTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	entityID := getEntityID(truthset.CustomerRecords["1001"])
+	entityIDs := szmodel.EntityIDs{}.Add(entityID)
+	actual, err := szEngine.FindNetworkByEntityIDTyped(ctx, entityIDs, 2, 1, 10, szflags.DefaultFindNetworkFlags)
+	require.NoError(test, err)
+	require.NotEmpty(test, actual.Entities)
+	assert.Equal(test, entityID, actual.Entities[0].ResolvedEntity.EntityID)
+}
+
+func TestSzengine_FindNetworkByEntityIDTyped_badEntityIDs(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestObject(ctx, test)
+	entityIDs := szmodel.EntityIDs{0, 1}
+	actual, err := szEngine.FindNetworkByEntityIDTyped(ctx, entityIDs, 2, 1, 10, szflags.DefaultFindNetworkFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.ErrorIs(test, err, szmodel.ErrInvalidParameter)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_FindNetworkByEntityID_badEntityIDs(test *testing.T) {
 	ctx := context.TODO()
 	szEngine := getTestObject(ctx, test)
@@ -678,6 +753,34 @@ func TestSzengine_FindNetworkByRecordID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_FindNetworkByRecordIDTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+		truthset.CustomerRecords["1003"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	recordKeys := szmodel.RecordKeys{}
+	for _, record := range records {
+		recordKeys = recordKeys.Add(record.DataSource, record.ID)
+	}
+	actual, err := szEngine.FindNetworkByRecordIDTyped(ctx, recordKeys, 1, 2, 10, szflags.DefaultFindNetworkFlags)
+	require.NoError(test, err)
+	assert.NotEmpty(test, actual.Entities)
+}
+
+func TestSzengine_FindNetworkByRecordIDTyped_emptyRecordKeys(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestObject(ctx, test)
+	actual, err := szEngine.FindNetworkByRecordIDTyped(ctx, nil, 1, 2, 10, szflags.DefaultFindNetworkFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_FindNetworkByRecordID_badDataSourceCode(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -747,6 +850,34 @@ func TestSzengine_FindPathByEntityID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_FindPathByEntityIDTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	startEntityID := getEntityID(truthset.CustomerRecords["1001"])
+	endEntityID := getEntityID(truthset.CustomerRecords["1002"])
+	requiredDataSources := szmodel.DataSourceList{}.Add(truthset.CustomerRecords["1001"].DataSource)
+	actual, err := szEngine.FindPathByEntityIDTyped(ctx, startEntityID, endEntityID, 1, nil, requiredDataSources, szflags.DefaultFindPathFlags)
+	require.NoError(test, err)
+	require.Len(test, actual.EntityPaths, 1)
+	assert.Equal(test, startEntityID, actual.EntityPaths[0].StartEntityID)
+}
+
+func TestSzengine_FindPathByEntityIDTyped_badRequiredDataSources(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestObject(ctx, test)
+	requiredDataSources := szmodel.DataSourceList{"CUSTOMERS", "CUSTOMERS"}
+	actual, err := szEngine.FindPathByEntityIDTyped(ctx, 1, 2, 1, nil, requiredDataSources, szflags.DefaultFindPathFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_FindPathByEntityID_badStartEntityID(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -980,6 +1111,36 @@ func TestSzengine_FindPathByRecordID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_FindPathByRecordIDTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1002"]
+	actual, err := szEngine.FindPathByRecordIDTyped(ctx, record1.DataSource, record1.ID, record2.DataSource, record2.ID, 1, nil, nil, szflags.DefaultFindPathFlags)
+	require.NoError(test, err)
+	require.Len(test, actual.EntityPaths, 1)
+	assert.Equal(test, getEntityID(record1), actual.EntityPaths[0].StartEntityID)
+	assert.Equal(test, getEntityID(record2), actual.EntityPaths[0].EndEntityID)
+}
+
+func TestSzengine_FindPathByRecordIDTyped_badAvoidRecordKeys(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestObject(ctx, test)
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1002"]
+	avoidRecordKeys := szmodel.RecordKeys{}.Add(record1.DataSource, "")
+	actual, err := szEngine.FindPathByRecordIDTyped(ctx, record1.DataSource, record1.ID, record2.DataSource, record2.ID, 1, avoidRecordKeys, nil, szflags.DefaultFindPathFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_FindPathByRecordID_badDataSourceCode(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1185,6 +1346,32 @@ func TestSzengine_GetEntityByRecordID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_GetEntityByRecordIDTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	record := truthset.CustomerRecords["1001"]
+	actual, err := szEngine.GetEntityByRecordIDTyped(ctx, record.DataSource, record.ID, szflags.DefaultEntityFlags)
+	require.NoError(test, err)
+	assert.Positive(test, actual.ResolvedEntity.EntityID)
+	assert.Equal(test, []szmodel.RecordSummary{{DataSource: record.DataSource, RecordCount: 2}}, actual.ResolvedEntity.RecordSummary)
+	assert.NotEmpty(test, actual.ResolvedEntity.Features["NAME"])
+}
+
+func TestSzengine_GetEntityByRecordIDTyped_badDataSourceCode(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestObject(ctx, test)
+	actual, err := szEngine.GetEntityByRecordIDTyped(ctx, badDataSourceCode, "1001", szflags.DefaultEntityFlags)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_GetEntityByRecordID_badDataSourceCode(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1233,6 +1420,22 @@ func TestSzengine_GetRecord(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_GetRecordTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	record := truthset.CustomerRecords["1001"]
+	actual, err := szEngine.GetRecordTyped(ctx, record.DataSource, record.ID, szflags.DefaultRecordFlags)
+	require.NoError(test, err)
+	assert.Equal(test, record.ID, actual.RecordID)
+	assert.JSONEq(test, record.JSON, string(actual.JSONData))
+}
+
 func TestSzengine_GetRecord_badDataSourceCode(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1306,6 +1509,34 @@ func TestSzengine_GetVirtualEntityByRecordID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_GetVirtualEntityByRecordIDTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1002"]
+	recordKeys := szmodel.RecordKeys{}.Add(record1.DataSource, record1.ID).Add(record2.DataSource, record2.ID)
+	actual, err := szEngine.GetVirtualEntityByRecordIDTyped(ctx, recordKeys, szflags.DefaultVirtualEntityFlags)
+	require.NoError(test, err)
+	assert.NotEmpty(test, actual.ResolvedEntity.RecordSummary)
+}
+
+func TestSzengine_GetVirtualEntityByRecordIDTyped_duplicateRecordKeys(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestObject(ctx, test)
+	record := truthset.CustomerRecords["1001"]
+	recordKeys := szmodel.RecordKeys{}.Add(record.DataSource, record.ID).Add(record.DataSource, record.ID)
+	actual, err := szEngine.GetVirtualEntityByRecordIDTyped(ctx, recordKeys, szflags.DefaultVirtualEntityFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_GetVirtualEntityByRecordID_badDataSourceCode(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1360,6 +1591,24 @@ func TestSzengine_HowEntityByEntityID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_HowEntityByEntityIDTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	entityID := getEntityID(truthset.CustomerRecords["1001"])
+	actual, err := szEngine.HowEntityByEntityIDTyped(ctx, entityID, szflags.DefaultHowFlags)
+	require.NoError(test, err)
+	require.Len(test, actual.HowResults.FinalState.VirtualEntities, 1)
+	assert.Len(test, actual.HowResults.FinalState.VirtualEntities[0].MemberRecords, len(records))
+	assert.Len(test, actual.HowResults.ResolutionSteps, len(records)-1)
+}
+
 func TestSzengine_HowEntityByEntityID_badEntityID(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1606,6 +1855,25 @@ func TestSzengine_SearchByAttributes(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_SearchByAttributesTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+		truthset.CustomerRecords["1003"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	attributes := `{"NAME_FULL": "Robert Smith", "PHONE_NUMBER": "702-919-1300"}`
+	actual, err := szEngine.SearchByAttributesTyped(ctx, attributes, senzing.SzNoSearchProfile, szflags.DefaultSearchFlags)
+	require.NoError(test, err)
+	require.NotEmpty(test, actual.ResolvedEntities)
+	assert.Equal(test, getEntityID(truthset.CustomerRecords["1001"]), actual.ResolvedEntities[0].Entity.ResolvedEntity.EntityID)
+	assert.NotEmpty(test, actual.ResolvedEntities[0].MatchInfo.MatchKey)
+}
+
 func TestSzengine_SearchByAttributes_badAttributes(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1699,6 +1967,34 @@ func TestSzengine_WhyEntities(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_WhyEntitiesTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	entityID1 := getEntityID(truthset.CustomerRecords["1001"])
+	entityID2 := getEntityID(truthset.CustomerRecords["1002"])
+	actual, err := szEngine.WhyEntitiesTyped(ctx, entityID1, entityID2, szflags.DefaultWhyFlags)
+	require.NoError(test, err)
+	require.Len(test, actual.WhyResults, 1)
+	assert.Equal(test, entityID1, actual.WhyResults[0].EntityID)
+	assert.NotEmpty(test, actual.Entities)
+}
+
+func TestSzengine_WhyEntitiesTyped_inapplicableFlags(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestObject(ctx, test)
+	actual, err := szEngine.WhyEntitiesTyped(ctx, 1, 2, szflags.WhyFlags(senzing.SzExportIncludeAllEntities))
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.ErrorIs(test, err, szflags.ErrInapplicableFlags)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_WhyEntities_badEnitity1(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1749,6 +2045,22 @@ func TestSzengine_WhyRecordInEntity(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_WhyRecordInEntityTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	record := truthset.CustomerRecords["1001"]
+	actual, err := szEngine.WhyRecordInEntityTyped(ctx, record.DataSource, record.ID, szflags.WhyFlags(senzing.SzWhyRecordInEntityIDefaultFlags))
+	require.NoError(test, err)
+	require.Len(test, actual.WhyResults, 1)
+	assert.Equal(test, getEntityID(record), actual.WhyResults[0].EntityID)
+}
+
 func TestSzengine_WhyRecordInEntity_badDataSourceCode(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1799,6 +2111,39 @@ func TestSzengine_WhyRecords(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_WhyRecordsTyped(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+		truthset.CustomerRecords["1002"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1002"]
+	actual, err := szEngine.WhyRecordsTyped(ctx, record1.DataSource, record1.ID, record2.DataSource, record2.ID, szflags.WhyFlags(senzing.SzWhyRecordsDefaultFlags))
+	require.NoError(test, err)
+	require.Len(test, actual.WhyResults, 1)
+	assert.Equal(test, record2.ID, actual.WhyResults[0].FocusRecords2[0].RecordID)
+}
+
+func TestSzengine_WhyRecordsTyped_badRecordID(test *testing.T) {
+	ctx := context.TODO()
+	records := []record.Record{
+		truthset.CustomerRecords["1001"],
+	}
+	defer func() { handleError(deleteRecords(ctx, records)) }()
+	err := addRecords(ctx, records)
+	require.NoError(test, err)
+	szEngine := getTestObject(ctx, test)
+	record := truthset.CustomerRecords["1001"]
+	actual, err := szEngine.WhyRecordsTyped(ctx, record.DataSource, record.ID, record.DataSource, badRecordID, szflags.DefaultWhyFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	assert.Nil(test, actual)
+}
+
 func TestSzengine_WhyRecords_badDataSourceCode(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -1835,10 +2180,220 @@ func TestSzengine_WhyRecords_badRecordID(test *testing.T) {
 	printActual(test, actual)
 }
 
+// ----------------------------------------------------------------------------
+// Export iterators - consumer stops early
+// ----------------------------------------------------------------------------
+
+func TestSzengine_ExportCsvEntities_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	szEngine, server := getBufconnSzEngine(test)
+	count := 0
+	var lastErr error
+	for _, err := range szEngine.ExportCsvEntities(ctx, "", 0) {
+		lastErr = err
+		count++
+		if count == 1 {
+			require.NoError(test, err)
+			cancel()
+		}
+	}
+	require.ErrorIs(test, lastErr, context.Canceled)
+	waitForExportServer(test, server)
+}
+
+func TestSzengine_ExportJSONEntities(test *testing.T) {
+	ctx := context.TODO()
+	szEngine, server := getBufconnSzEngine(test)
+	server.fragments.Store(3)
+	count := 0
+	for line, err := range szEngine.ExportJSONEntities(ctx, 0) {
+		require.NoError(test, err)
+		assert.NotEmpty(test, line)
+		count++
+	}
+	assert.Equal(test, 3, count)
+	waitForExportServer(test, server)
+}
+
+func TestSzengine_ExportJSONEntities_break(test *testing.T) {
+	ctx := context.TODO()
+	szEngine, server := getBufconnSzEngine(test)
+	baseline := getExportGoroutineBaseline(test, szEngine, server)
+	for _, err := range szEngine.ExportJSONEntities(ctx, 0) {
+		require.NoError(test, err)
+		break
+	}
+	waitForExportServer(test, server)
+	waitForGoroutines(test, baseline)
+}
+
+func TestSzengine_ExportCsvEntityReportIterator_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	szEngine, server := getBufconnSzEngine(test)
+	fragments := szEngine.ExportCsvEntityReportIterator(ctx, "", 0)
+	fragment := <-fragments
+	require.NoError(test, fragment.Error)
+	cancel()
+	for fragment := range fragments { // The error fragment is dropped if the buffer is full when ctx is cancelled.
+		if fragment.Error != nil {
+			require.ErrorIs(test, fragment.Error, context.Canceled)
+		}
+	}
+	waitForExportServer(test, server)
+}
+
+func TestSzengine_ExportJSONEntityReportIterator_breakAndCancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	szEngine, server := getBufconnSzEngine(test)
+	baseline := getExportGoroutineBaseline(test, szEngine, server)
+	fragments := szEngine.ExportJSONEntityReportIterator(ctx, 0)
+	for fragment := range fragments {
+		require.NoError(test, fragment.Error)
+		break
+	}
+	cancel()
+	waitForExportServer(test, server)
+	waitForClosed(test, fragments)
+	waitForGoroutines(test, baseline)
+}
+
+func TestSzengine_ExportJSONEntityReportIterator_breakAndCancelWithoutReading(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	szEngine, server := getBufconnSzEngine(test)
+	szEngine.ExportStallTimeout = time.Hour
+	baseline := getExportGoroutineBaseline(test, szEngine, server)
+	fragments := szEngine.ExportJSONEntityReportIterator(ctx, 0)
+	for fragment := range fragments {
+		require.NoError(test, fragment.Error)
+		break
+	}
+	cancel()
+	waitForExportServer(test, server)
+	waitForGoroutines(test, baseline)
+}
+
+func TestSzengine_ExportJSONEntityReportIterator_breakWithoutCancel(test *testing.T) {
+	ctx := context.TODO()
+	szEngine, server := getBufconnSzEngine(test)
+	szEngine.ExportStallTimeout = 50 * time.Millisecond
+	baseline := getExportGoroutineBaseline(test, szEngine, server)
+	fragments := szEngine.ExportJSONEntityReportIterator(ctx, 0)
+	for fragment := range fragments {
+		require.NoError(test, fragment.Error)
+		break
+	}
+	waitForExportServer(test, server)
+	values := 0
+	var lastErr error
+	for fragment := range fragments {
+		if fragment.Error == nil {
+			values++
+		}
+		lastErr = fragment.Error
+	}
+	assert.Equal(test, 1, values, "the buffered fragment comes before the error")
+	require.ErrorIs(test, lastErr, ErrExportAbandoned)
+	waitForGoroutines(test, baseline)
+}
+
+func TestSzengine_ExportJSONEntityReportIterator_breakWithoutReading(test *testing.T) {
+	ctx := context.TODO()
+	szEngine, server := getBufconnSzEngine(test)
+	szEngine.ExportStallTimeout = 50 * time.Millisecond
+	baseline := getExportGoroutineBaseline(test, szEngine, server)
+	fragments := szEngine.ExportJSONEntityReportIterator(ctx, 0)
+	for fragment := range fragments {
+		require.NoError(test, fragment.Error)
+		break
+	}
+	waitForExportServer(test, server)
+	waitForGoroutines(test, baseline)
+}
+
+func TestSzengine_ExportJSONEntityReportIterator_complete(test *testing.T) {
+	ctx := context.TODO()
+	szEngine, server := getBufconnSzEngine(test)
+	server.fragments.Store(3)
+	count := 0
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, 0) {
+		require.NoError(test, fragment.Error)
+		count++
+	}
+	assert.Equal(test, 3, count)
+	waitForExportServer(test, server)
+}
+
 // ----------------------------------------------------------------------------
 // Logging and observing
 // ----------------------------------------------------------------------------
 
+func TestSzengine_ExportJSONEntityReportIterator_observerFlags(test *testing.T) {
+	ctx := context.TODO()
+	szEngine, server := getBufconnSzEngine(test)
+	server.fragments.Store(1)
+	observer := &channelObserver{messages: make(chan string, 10)}
+	err := szEngine.RegisterObserver(ctx, observer)
+	require.NoError(test, err)
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzExportIncludeMultiRecordEntities) {
+		require.NoError(test, fragment.Error)
+	}
+	waitForExportServer(test, server)
+	for { // Skip the notification of RegisterObserver.
+		details := map[string]string{}
+		select {
+		case message := <-observer.messages:
+			require.NoError(test, json.Unmarshal([]byte(message), &details))
+		case <-time.After(5 * time.Second):
+			require.Fail(test, "observer was not notified")
+		}
+		if flags, ok := details["flags"]; ok {
+			assert.Equal(test, "SZ_EXPORT_INCLUDE_MULTI_RECORD_ENTITIES", flags)
+			return
+		}
+	}
+}
+
+func TestSzengine_ExportIterators_observerMessageIDs(test *testing.T) {
+	ctx := context.TODO()
+	szEngine, server := getBufconnSzEngine(test)
+	server.fragments.Store(1)
+	observer := &channelObserver{messages: make(chan string, 10)}
+	err := szEngine.RegisterObserver(ctx, observer)
+	require.NoError(test, err)
+	for fragment := range szEngine.ExportCsvEntityReportIterator(ctx, "", 0) {
+		require.NoError(test, fragment.Error)
+	}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, 0) {
+		require.NoError(test, fragment.Error)
+	}
+	for _, err := range szEngine.ExportCsvEntities(ctx, "", 0) {
+		require.NoError(test, err)
+	}
+	for _, err := range szEngine.ExportJSONEntities(ctx, 0) {
+		require.NoError(test, err)
+	}
+	messageIDs := []string{}
+	for len(messageIDs) < 4 {
+		details := map[string]string{}
+		select {
+		case message := <-observer.messages:
+			require.NoError(test, json.Unmarshal([]byte(message), &details))
+		case <-time.After(5 * time.Second):
+			require.Fail(test, "observer was not notified")
+		}
+		if _, ok := details["flags"]; ok { // Skip the notification of RegisterObserver.
+			messageIDs = append(messageIDs, details["messageId"])
+		}
+	}
+	assert.ElementsMatch(test, []string{"8007", "8009", "8035", "8036"}, messageIDs)
+	idMessages := getIDMessages()
+	for _, messageID := range []int{77, 78, 79, 80, 8035, 8036} {
+		assert.Contains(test, idMessages, messageID)
+	}
+	assert.Contains(test, idMessages[15], "ExportCsvEntityReportIterator")
+}
+
 func TestSzengine_SetLogLevel_badLogLevelName(test *testing.T) {
 	ctx := context.TODO()
 	szConfig := getTestObject(ctx, test)
@@ -1874,6 +2429,9 @@ func TestSzengine_UnregisterObserver(test *testing.T) {
 // ----------------------------------------------------------------------------
 
 func TestSzengine_AsInterface(test *testing.T) {
+	if fakeserver.Selected() {
+		test.Skip("the redo records left by the preceding tests depend on the resolution of the real engine")
+	}
 	expected := int64(4)
 	ctx := context.TODO()
 	szEngine := getSzEngineAsInterface(ctx)
@@ -1976,6 +2534,38 @@ func deleteRecords(ctx context.Context, records []record.Record) error {
 	return err
 }
 
+// Complete one export so that connection and transport goroutines exist before counting goroutines.
+func getExportGoroutineBaseline(test *testing.T, szEngine *Szengine, server *testExportServer) int {
+	server.fragments.Store(1)
+	for fragment := range szEngine.ExportJSONEntityReportIterator(context.TODO(), 0) {
+		require.NoError(test, fragment.Error)
+	}
+	waitForExportServer(test, server)
+	server.fragments.Store(0)
+	time.Sleep(10 * time.Millisecond) // Let the finished stream's goroutines exit.
+	return runtime.NumGoroutine()
+}
+
+func getBufconnSzEngine(test *testing.T) (*Szengine, *testExportServer) {
+	server := &testExportServer{done: make(chan struct{}, 10)}
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := grpc.NewServer()
+	szpb.RegisterSzEngineServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+	bufconnConnection, err := grpc.NewClient(bufconnAddress,
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = bufconnConnection.Close() })
+	return &Szengine{GrpcClient: szpb.NewSzEngineClient(bufconnConnection)}, server
+}
+
 func getDefaultConfigID() int64 {
 	return defaultConfigID
 }
@@ -1991,16 +2581,11 @@ func getEntityIDForRecord(datasource string, id string) int64 {
 	if err != nil {
 		return result
 	}
-	response, err := szEngine.GetEntityByRecordID(ctx, datasource, id, senzing.SzWithoutInfo)
+	response, err := szEngine.GetEntityByRecordIDTyped(ctx, datasource, id, szflags.EntityFlags(senzing.SzNoFlags))
 	if err != nil {
 		return result
 	}
-	getEntityByRecordIDResponse := &GetEntityByRecordIDResponse{}
-	err = json.Unmarshal([]byte(response), &getEntityByRecordIDResponse)
-	if err != nil {
-		return result
-	}
-	return getEntityByRecordIDResponse.ResolvedEntity.EntityID
+	return response.ResolvedEntity.EntityID
 }
 
 func getEntityIDString(record record.Record) string {
@@ -2016,7 +2601,11 @@ func getEntityIDStringForRecord(datasource string, id string) string {
 func getGrpcConnection() *grpc.ClientConn {
 	var err error
 	if grpcConnection == nil {
-		grpcConnection, err = grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if fakeserver.Selected() {
+			grpcConnection, err = fakeserver.NewSharedClient()
+		} else {
+			grpcConnection, err = grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		}
 		if err != nil {
 			fmt.Printf("Did not connect: %v\n", err)
 		}
@@ -2204,6 +2793,40 @@ func truncate(aString string, length int) string {
 	return truncator.Truncate(aString, length, "...", truncator.PositionEnd)
 }
 
+func waitForClosed(test *testing.T, fragments chan senzing.StringFragment) {
+	require.Eventually(test, func() bool {
+		for {
+			select {
+			case _, ok := <-fragments:
+				if !ok {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// Poll without require.Eventually(), which runs its condition in an extra goroutine.
+func waitForGoroutines(test *testing.T, baseline int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			require.Fail(test, "goroutines leaked", "baseline: %d, now: %d", baseline, runtime.NumGoroutine())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func waitForExportServer(test *testing.T, server *testExportServer) {
+	select {
+	case <-server.done:
+	case <-time.After(5 * time.Second):
+		require.Fail(test, "export stream was not released")
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Test harness
 // ----------------------------------------------------------------------------
@@ -0,0 +1,14 @@
+/*
+The szexplain package renders the explanations returned by the Why* methods and HowEntityByEntityID
+of SzEngine as human-readable text.
+
+Why() describes why two entities or records match, or why a record is in its entity:
+the match level, the match key, the rule code, the candidate keys that brought them together,
+and the score of each feature compared.
+How() describes the resolution steps that built an entity, the virtual entities that matched at each step,
+and the final state of the entity.
+
+Both render as plain text, for terminals and logs, or as Markdown, for tickets and documentation.
+Decode the documents with szmodel, or with the ...Typed methods of szengine.Szengine, such as WhyEntitiesTyped().
+*/
+package szexplain
@@ -0,0 +1,18 @@
+package szexplain
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Format is the format of an explanation.
+type Format int
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Explanation formats.
+const (
+	FormatText     Format = iota // Plain text, with underlined headings and aligned tables.
+	FormatMarkdown               // Markdown, with headings, lists and pipe tables.
+)
@@ -0,0 +1,287 @@
+package szexplain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
+)
+
+// A writer builds an explanation in a format.
+type writer struct {
+	builder strings.Builder
+	format  Format
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The How function explains how an entity was resolved: each resolution step, and the final state.
+
+Input
+  - response: The decoded result of SzEngine.HowEntityByEntityID().
+  - format: FormatText or FormatMarkdown.
+
+Output
+  - The explanation.
+*/
+func How(response *szmodel.HowResponse, format Format) string {
+	out := &writer{format: format}
+	results := response.HowResults
+	out.heading(1, fmt.Sprintf("How the entity was resolved, in %s", plural(len(results.ResolutionSteps), "step")))
+	for _, step := range results.ResolutionSteps {
+		out.heading(2, fmt.Sprintf("Step %d: %s and %s became %s",
+			step.Step, step.VirtualEntity1.VirtualEntityID, step.VirtualEntity2.VirtualEntityID, step.ResultVirtualEntityID))
+		out.bullets(
+			fmt.Sprintf("%s: %s", virtualEntityLabel(step.VirtualEntity1, step.InboundVirtualEntityID), out.code(memberRecords(step.VirtualEntity1))),
+			fmt.Sprintf("%s: %s", virtualEntityLabel(step.VirtualEntity2, step.InboundVirtualEntityID), out.code(memberRecords(step.VirtualEntity2))),
+			"Match key: "+out.code(step.MatchInfo.MatchKey),
+			"Rule: "+out.code(step.MatchInfo.ErruleCode),
+		)
+		out.matchDetails(3, step.MatchInfo.CandidateKeys, step.MatchInfo.FeatureScores)
+	}
+	out.heading(2, "Final state")
+	if results.FinalState.NeedReevaluation != 0 {
+		out.paragraph("The entity needs to be reevaluated.")
+	}
+	lines := []string{}
+	for _, virtualEntity := range results.FinalState.VirtualEntities {
+		lines = append(lines, fmt.Sprintf("%s: %s", virtualEntity.VirtualEntityID, out.code(memberRecords(virtualEntity))))
+	}
+	out.bullets(lines...)
+	return out.String()
+}
+
+/*
+The Why function explains why entities or records match, or why a record is in its entity.
+There is a section for each result of the response.
+
+Input
+  - response: The decoded result of SzEngine.WhyEntities(), SzEngine.WhyRecords() or SzEngine.WhyRecordInEntity().
+  - format: FormatText or FormatMarkdown.
+
+Output
+  - The explanation.
+*/
+func Why(response *szmodel.WhyResponse, format Format) string {
+	out := &writer{format: format}
+	names := map[int64]string{}
+	for _, entity := range response.Entities {
+		names[entity.ResolvedEntity.EntityID] = entity.ResolvedEntity.EntityName
+	}
+	for index, result := range response.WhyResults {
+		if index > 0 {
+			out.separator()
+		}
+		out.heading(1, whyTitle(result, names))
+		out.bullets(
+			"Match level: "+out.code(result.MatchInfo.MatchLevelCode),
+			"Match key: "+out.code(result.MatchInfo.WhyKey),
+			"Rule: "+out.code(result.MatchInfo.WhyErruleCode),
+		)
+		out.matchDetails(2, result.MatchInfo.CandidateKeys, result.MatchInfo.FeatureScores)
+	}
+	return out.String()
+}
+
+// ----------------------------------------------------------------------------
+// writer methods
+// ----------------------------------------------------------------------------
+
+func (out *writer) String() string {
+	return strings.TrimRight(out.builder.String(), "\n") + "\n"
+}
+
+// Write a list.  Empty items are skipped.
+func (out *writer) bullets(items ...string) {
+	written := false
+	for _, item := range items {
+		if len(item) == 0 {
+			continue
+		}
+		out.builder.WriteString("- " + item + "\n")
+		written = true
+	}
+	if written {
+		out.builder.WriteString("\n")
+	}
+}
+
+// Quote a value, such as a match key.  An empty value is shown as "none".
+func (out *writer) code(value string) string {
+	if len(value) == 0 {
+		return "none"
+	}
+	if out.format == FormatMarkdown {
+		return "`" + strings.ReplaceAll(value, "`", "'") + "`"
+	}
+	return value
+}
+
+func (out *writer) heading(level int, text string) {
+	if out.format == FormatMarkdown {
+		out.builder.WriteString(strings.Repeat("#", level) + " " + text + "\n\n")
+		return
+	}
+	underline := "="
+	if level > 1 {
+		underline = "-"
+	}
+	out.builder.WriteString(text + "\n" + strings.Repeat(underline, len([]rune(text))) + "\n\n")
+}
+
+// Write the candidate keys and the feature scores of a match, by feature type.
+func (out *writer) matchDetails(level int, candidateKeys map[string][]szmodel.CandidateKey, featureScores map[string][]szmodel.FeatureScore) {
+	if len(candidateKeys) > 0 {
+		out.heading(level, "Candidate keys")
+		lines := []string{}
+		for _, featureType := range sortedKeys(candidateKeys) {
+			values := []string{}
+			for _, candidateKey := range candidateKeys[featureType] {
+				values = append(values, candidateKey.FeatDesc)
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s", featureType, strings.Join(values, ", ")))
+		}
+		out.bullets(lines...)
+	}
+	if len(featureScores) > 0 {
+		out.heading(level, "Feature scores")
+		rows := [][]string{}
+		for _, featureType := range sortedKeys(featureScores) {
+			for _, score := range featureScores[featureType] {
+				rows = append(rows, []string{
+					featureType,
+					featureDescription(score.InboundFeatDesc, score.InboundFeatUsageType),
+					featureDescription(score.CandidateFeatDesc, score.CandidateFeatUsageType),
+					fmt.Sprint(score.Score),
+					score.ScoreBucket,
+				})
+			}
+		}
+		out.table([]string{"Feature", "Inbound", "Candidate", "Score", "Bucket"}, rows)
+	}
+}
+
+func (out *writer) paragraph(text string) {
+	out.builder.WriteString(text + "\n\n")
+}
+
+// Separate the explanations of two results.
+func (out *writer) separator() {
+	if out.format == FormatMarkdown {
+		out.builder.WriteString("---\n\n")
+	}
+}
+
+// Write a table: a pipe table in Markdown, or aligned columns in text.
+func (out *writer) table(header []string, rows [][]string) {
+	if out.format == FormatMarkdown {
+		escape := func(cells []string) string {
+			escaped := make([]string, 0, len(cells))
+			for _, cell := range cells {
+				escaped = append(escaped, strings.ReplaceAll(cell, "|", `\|`))
+			}
+			return "| " + strings.Join(escaped, " | ") + " |\n"
+		}
+		out.builder.WriteString(escape(header))
+		out.builder.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+		for _, row := range rows {
+			out.builder.WriteString(escape(row))
+		}
+		out.builder.WriteString("\n")
+		return
+	}
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for column, cell := range row {
+			widths[column] = max(widths[column], len([]rune(cell)))
+		}
+	}
+	for _, row := range append([][]string{header}, rows...) {
+		cells := make([]string, 0, len(row))
+		for column, cell := range row {
+			cells = append(cells, cell+strings.Repeat(" ", widths[column]-len([]rune(cell))))
+		}
+		out.builder.WriteString(strings.TrimRight(strings.Join(cells, "  "), " ") + "\n")
+	}
+	out.builder.WriteString("\n")
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Describe an entity by its ID, and its name if known.
+func entityLabel(entityID int64, names map[int64]string) string {
+	if name := names[entityID]; len(name) > 0 {
+		return fmt.Sprintf("entity %d (%s)", entityID, name)
+	}
+	return fmt.Sprintf("entity %d", entityID)
+}
+
+// Describe a feature by its value, and its usage type if any, such as "Robert Smith (PRIMARY)".
+func featureDescription(featDesc string, usageType string) string {
+	if len(usageType) > 0 {
+		return fmt.Sprintf("%s (%s)", featDesc, usageType)
+	}
+	return featDesc
+}
+
+// List the records of a virtual entity, such as "CUSTOMERS:1001, CUSTOMERS:1002".
+func memberRecords(virtualEntity szmodel.VirtualEntity) string {
+	result := []string{}
+	for _, member := range virtualEntity.MemberRecords {
+		result = append(result, recordKeys(member.Records))
+	}
+	return strings.Join(result, ", ")
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// List records, such as "CUSTOMERS:1001, CUSTOMERS:1002".
+func recordKeys(records []szmodel.RecordKey) string {
+	result := make([]string, 0, len(records))
+	for _, record := range records {
+		result = append(result, record.DataSource+":"+record.RecordID)
+	}
+	return strings.Join(result, ", ")
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	result := make([]string, 0, len(values))
+	for key := range values {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Describe a virtual entity of a resolution step, marking the inbound one.
+func virtualEntityLabel(virtualEntity szmodel.VirtualEntity, inboundVirtualEntityID string) string {
+	if virtualEntity.VirtualEntityID == inboundVirtualEntityID {
+		return fmt.Sprintf("Virtual entity %s (inbound)", virtualEntity.VirtualEntityID)
+	}
+	return "Virtual entity " + virtualEntity.VirtualEntityID
+}
+
+// Title the explanation of a why result by what it compares.
+func whyTitle(result szmodel.WhyResult, names map[int64]string) string {
+	switch {
+	case len(result.FocusRecords) > 0 && len(result.FocusRecords2) > 0:
+		return fmt.Sprintf("Why record %s, of %s, and record %s, of %s, match",
+			recordKeys(result.FocusRecords), entityLabel(result.EntityID, names),
+			recordKeys(result.FocusRecords2), entityLabel(result.EntityID2, names))
+	case len(result.FocusRecords) > 0:
+		return fmt.Sprintf("Why record %s is in %s", recordKeys(result.FocusRecords), entityLabel(result.EntityID, names))
+	default:
+		return fmt.Sprintf("Why %s and %s match", entityLabel(result.EntityID, names), entityLabel(result.EntityID2, names))
+	}
+}
@@ -0,0 +1,52 @@
+package szexplain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szflags"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleWhy() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szexplain/szexplain_examples_test.go
+	ctx := context.TODO()
+	server, err := fakeserver.New(fakeserver.WithDataSources("CUSTOMERS"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	grpcConnection, err := server.NewClient()
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = grpcConnection.Close() }()
+	szEngine := &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"RECORD_ID": "1001", "NAME_FULL": "Robert Smith", "PHONE_NUMBER": "702-919-1300"}`, senzing.SzNoFlags)
+	if err != nil {
+		fmt.Println(err)
+	}
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1002", `{"RECORD_ID": "1002", "NAME_FULL": "Robert Smith", "PHONE_NUMBER": "702-919-1300"}`, senzing.SzNoFlags)
+	if err != nil {
+		fmt.Println(err)
+	}
+	response, err := szEngine.WhyRecordsTyped(ctx, "CUSTOMERS", "1001", "CUSTOMERS", "1002", szflags.DefaultWhyFlags)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Print(Why(response, FormatText))
+	// Output:
+	// Why record CUSTOMERS:1001, of entity 1 (Robert Smith), and record CUSTOMERS:1002, of entity 1 (Robert Smith), match
+	// ===================================================================================================================
+	//
+	// - Match level: RESOLVED
+	// - Match key: +NAME+PHONE
+	// - Rule: FAKE_NAME_PLUS_ONE
+}
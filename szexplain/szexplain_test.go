@@ -0,0 +1,105 @@
+package szexplain
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szmodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run "go test ./szexplain -update" to rewrite the golden files after changing the explanations.
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestHow(test *testing.T) {
+	response := readResponse[szmodel.HowResponse](test, "how_entity")
+	testGolden(test, "how_entity.txt", How(response, FormatText))
+	testGolden(test, "how_entity.md", How(response, FormatMarkdown))
+}
+
+func TestHow_needReevaluation(test *testing.T) {
+	response := &szmodel.HowResponse{HowResults: szmodel.HowResults{
+		FinalState: szmodel.HowFinalState{
+			NeedReevaluation: 1,
+			VirtualEntities: []szmodel.VirtualEntity{
+				{VirtualEntityID: "V1", MemberRecords: []szmodel.MemberRecord{{InternalID: 1, Records: []szmodel.RecordKey{{DataSource: "CUSTOMERS", RecordID: "1001"}}}}},
+				{VirtualEntityID: "V2", MemberRecords: []szmodel.MemberRecord{{InternalID: 2, Records: []szmodel.RecordKey{{DataSource: "CUSTOMERS", RecordID: "1002"}}}}},
+			},
+		},
+	}}
+	actual := How(response, FormatText)
+	assert.Contains(test, actual, "in 0 steps")
+	assert.Contains(test, actual, "The entity needs to be reevaluated.")
+	assert.Contains(test, actual, "- V2: CUSTOMERS:1002\n")
+}
+
+func TestWhy_entities(test *testing.T) {
+	response := readResponse[szmodel.WhyResponse](test, "why_entities")
+	testGolden(test, "why_entities.txt", Why(response, FormatText))
+	testGolden(test, "why_entities.md", Why(response, FormatMarkdown))
+}
+
+func TestWhy_recordInEntity(test *testing.T) {
+	response := readResponse[szmodel.WhyResponse](test, "why_record_in_entity")
+	testGolden(test, "why_record_in_entity.txt", Why(response, FormatText))
+	testGolden(test, "why_record_in_entity.md", Why(response, FormatMarkdown))
+}
+
+func TestWhy_records(test *testing.T) {
+	response := readResponse[szmodel.WhyResponse](test, "why_records")
+	testGolden(test, "why_records.txt", Why(response, FormatText))
+	testGolden(test, "why_records.md", Why(response, FormatMarkdown))
+}
+
+func TestWhy_markdownEscaping(test *testing.T) {
+	response := &szmodel.WhyResponse{WhyResults: []szmodel.WhyResult{{
+		EntityID:  1,
+		EntityID2: 2,
+		MatchInfo: szmodel.WhyMatchInfo{
+			FeatureScores: map[string][]szmodel.FeatureScore{
+				"NAME": {{InboundFeatDesc: "A|B", CandidateFeatDesc: "A B", Score: 90, ScoreBucket: "CLOSE"}},
+			},
+		},
+	}}}
+	actual := Why(response, FormatMarkdown)
+	assert.Contains(test, actual, "# Why entity 1 and entity 2 match\n")
+	assert.Contains(test, actual, "- Match key: none\n")
+	assert.Contains(test, actual, `| NAME | A\|B | A B | 90 | CLOSE |`)
+}
+
+func TestWhy_empty(test *testing.T) {
+	assert.Equal(test, "\n", Why(&szmodel.WhyResponse{}, FormatText))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Decode a response from the szmodel test data.
+func readResponse[T any](test *testing.T, name string) *T {
+	test.Helper()
+	document, err := os.ReadFile(filepath.Join("..", "szmodel", "testdata", name+".json"))
+	require.NoError(test, err)
+	result, err := szmodel.Unmarshal[T](string(document))
+	require.NoError(test, err)
+	return result
+}
+
+// Compare an explanation with testdata/<name>.
+func testGolden(test *testing.T, name string, actual string) {
+	test.Helper()
+	goldenFile := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(test, os.WriteFile(goldenFile, []byte(actual), 0o600))
+	}
+	expected, err := os.ReadFile(goldenFile)
+	require.NoError(test, err)
+	assert.Equal(test, string(expected), actual)
+}
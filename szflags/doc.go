@@ -0,0 +1,15 @@
+/*
+The szflags package has a flags type for each family of SzEngine methods, over the senzing.Sz* flag constants.
+
+Each type, such as WhyFlags, knows which flags apply to its methods.
+Its constructor, such as NewWhyFlags(), and its Validate method reject flags that do not,
+such as export flags passed to WhyEntities, before a request is sent.
+Errors match ErrInapplicableFlags and szerror.ErrSzBadInput.
+
+String() decodes a flags value into the names of its flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO",
+and the types format as these names with %v, %s and %d, so that trace messages show names instead of integers.
+
+The ...Typed methods of szengine.Szengine take these types.
+The other methods take int64 flags, as the senzing.SzEngine interface requires; convert with int64().
+*/
+package szflags
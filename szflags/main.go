@@ -0,0 +1,164 @@
+package szflags
+
+import (
+	"errors"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// EntityFlags are the flags of GetEntityByEntityID, GetEntityByRecordID and GetVirtualEntityByRecordID.
+type EntityFlags int64
+
+// ExportFlags are the flags of the ExportCsv* and ExportJSON* methods.
+type ExportFlags int64
+
+// FindNetworkFlags are the flags of FindNetworkByEntityID and FindNetworkByRecordID.
+type FindNetworkFlags int64
+
+// FindPathFlags are the flags of FindPathByEntityID and FindPathByRecordID.
+type FindPathFlags int64
+
+// Flags are any SzEngine flags.  They are decoded, but not validated.
+type Flags int64
+
+// HowFlags are the flags of HowEntityByEntityID.
+type HowFlags int64
+
+// RecordFlags are the flags of GetRecord.
+type RecordFlags int64
+
+// SearchFlags are the flags of SearchByAttributes.
+type SearchFlags int64
+
+// WhyFlags are the flags of WhyEntities, WhyRecordInEntity and WhyRecords.
+type WhyFlags int64
+
+// WithInfoFlags are the flags of AddRecord, DeleteRecord, ProcessRedoRecord, ReevaluateEntity and ReevaluateRecord.
+type WithInfoFlags int64
+
+// Flags that do not apply to the methods they were given to.  It matches ErrInapplicableFlags and szerror.ErrSzBadInput.
+type flagsError struct {
+	message string
+}
+
+// The name of a single-bit flag.
+type flagName struct {
+	flag int64
+	name string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The recommended defaults, as senzing.Sz*DefaultFlags.
+const (
+	DefaultEntityFlags        = EntityFlags(senzing.SzEntityDefaultFlags)
+	DefaultExportFlags        = ExportFlags(senzing.SzExportDefaultFlags)
+	DefaultFindNetworkFlags   = FindNetworkFlags(senzing.SzFindNetworkDefaultFlags)
+	DefaultFindPathFlags      = FindPathFlags(senzing.SzFindPathDefaultFlags)
+	DefaultHowFlags           = HowFlags(senzing.SzHowEntityDefaultFlags)
+	DefaultRecordFlags        = RecordFlags(senzing.SzRecordDefaultFlags)
+	DefaultSearchFlags        = SearchFlags(senzing.SzSearchByAttributesDefaultFlags)
+	DefaultVirtualEntityFlags = EntityFlags(senzing.SzVirtualEntityDefaultFlags)
+	DefaultWhyFlags           = WhyFlags(senzing.SzWhyEntitiesDefaultFlags)
+)
+
+// The flags that control the entity details of results.
+const entityDetailFlags = senzing.SzEntityIncludeAllRelations |
+	senzing.SzEntityIncludeAllFeatures |
+	senzing.SzEntityIncludeRepresentativeFeatures |
+	senzing.SzEntityIncludeEntityName |
+	senzing.SzEntityIncludeRecordSummary |
+	senzing.SzEntityIncludeRecordData |
+	senzing.SzEntityIncludeRecordMatchingInfo |
+	senzing.SzEntityIncludeRecordJSONData |
+	senzing.SzEntityIncludeRecordFeatureIDs |
+	senzing.SzEntityIncludeRelatedEntityName |
+	senzing.SzEntityIncludeRelatedMatchingInfo |
+	senzing.SzEntityIncludeRelatedRecordSummary |
+	senzing.SzEntityIncludeRelatedRecordData |
+	senzing.SzEntityIncludeInternalFeatures |
+	senzing.SzEntityIncludeFeatureStats |
+	senzing.SzEntityIncludeRecordTypes |
+	senzing.SzEntityIncludeRelatedRecordTypes |
+	senzing.SzEntityIncludeRecordUnmappedData |
+	senzing.SzEntityIncludeFeatureElements |
+	senzing.SzIncludeMatchKeyDetails
+
+// The flags that apply to each type.
+const (
+	entityFlagsMask      = entityDetailFlags
+	exportFlagsMask      = entityDetailFlags | senzing.SzExportIncludeAllEntities | senzing.SzExportIncludeAllHavingRelationships
+	findNetworkFlagsMask = entityDetailFlags | senzing.SzFindNetworkIncludeMatchingInfo
+	findPathFlagsMask    = entityDetailFlags | senzing.SzFindPathStrictAvoid | senzing.SzFindPathIncludeMatchingInfo
+	howFlagsMask         = senzing.SzIncludeFeatureScores | senzing.SzIncludeMatchKeyDetails
+	recordFlagsMask      = senzing.SzEntityIncludeRecordData |
+		senzing.SzEntityIncludeRecordJSONData |
+		senzing.SzEntityIncludeRecordFeatureIDs |
+		senzing.SzEntityIncludeInternalFeatures |
+		senzing.SzEntityIncludeRecordTypes |
+		senzing.SzEntityIncludeRecordUnmappedData |
+		senzing.SzEntityIncludeFeatureElements
+	searchFlagsMask   = entityDetailFlags | senzing.SzSearchIncludeAllEntities | senzing.SzIncludeFeatureScores | senzing.SzSearchIncludeStats
+	whyFlagsMask      = entityDetailFlags | senzing.SzIncludeFeatureScores
+	withInfoFlagsMask = senzing.SzWithInfo
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInapplicableFlags is matched by the errors of constructors and Validate methods.
+var ErrInapplicableFlags = errors.New("inapplicable flags")
+
+// The names of single-bit flags, in bit order.
+var flagNames = []flagName{
+	{senzing.SzExportIncludeMultiRecordEntities, "SZ_EXPORT_INCLUDE_MULTI_RECORD_ENTITIES"},
+	{senzing.SzExportIncludePossiblySame, "SZ_EXPORT_INCLUDE_POSSIBLY_SAME"},
+	{senzing.SzExportIncludePossiblyRelated, "SZ_EXPORT_INCLUDE_POSSIBLY_RELATED"},
+	{senzing.SzExportIncludeNameOnly, "SZ_EXPORT_INCLUDE_NAME_ONLY"},
+	{senzing.SzExportIncludeDisclosed, "SZ_EXPORT_INCLUDE_DISCLOSED"},
+	{senzing.SzExportIncludeSingleRecordEntities, "SZ_EXPORT_INCLUDE_SINGLE_RECORD_ENTITIES"},
+	{senzing.SzEntityIncludePossiblySameRelations, "SZ_ENTITY_INCLUDE_POSSIBLY_SAME_RELATIONS"},
+	{senzing.SzEntityIncludePossiblyRelatedRelations, "SZ_ENTITY_INCLUDE_POSSIBLY_RELATED_RELATIONS"},
+	{senzing.SzEntityIncludeNameOnlyRelations, "SZ_ENTITY_INCLUDE_NAME_ONLY_RELATIONS"},
+	{senzing.SzEntityIncludeDisclosedRelations, "SZ_ENTITY_INCLUDE_DISCLOSED_RELATIONS"},
+	{senzing.SzEntityIncludeAllFeatures, "SZ_ENTITY_INCLUDE_ALL_FEATURES"},
+	{senzing.SzEntityIncludeRepresentativeFeatures, "SZ_ENTITY_INCLUDE_REPRESENTATIVE_FEATURES"},
+	{senzing.SzEntityIncludeEntityName, "SZ_ENTITY_INCLUDE_ENTITY_NAME"},
+	{senzing.SzEntityIncludeRecordSummary, "SZ_ENTITY_INCLUDE_RECORD_SUMMARY"},
+	{senzing.SzEntityIncludeRecordData, "SZ_ENTITY_INCLUDE_RECORD_DATA"},
+	{senzing.SzEntityIncludeRecordMatchingInfo, "SZ_ENTITY_INCLUDE_RECORD_MATCHING_INFO"},
+	{senzing.SzEntityIncludeRecordJSONData, "SZ_ENTITY_INCLUDE_RECORD_JSON_DATA"},
+	{senzing.SzEntityIncludeRecordFeatureIDs, "SZ_ENTITY_INCLUDE_RECORD_FEATURE_IDS"},
+	{senzing.SzEntityIncludeRelatedEntityName, "SZ_ENTITY_INCLUDE_RELATED_ENTITY_NAME"},
+	{senzing.SzEntityIncludeRelatedMatchingInfo, "SZ_ENTITY_INCLUDE_RELATED_MATCHING_INFO"},
+	{senzing.SzEntityIncludeRelatedRecordSummary, "SZ_ENTITY_INCLUDE_RELATED_RECORD_SUMMARY"},
+	{senzing.SzEntityIncludeRelatedRecordData, "SZ_ENTITY_INCLUDE_RELATED_RECORD_DATA"},
+	{senzing.SzEntityIncludeInternalFeatures, "SZ_ENTITY_INCLUDE_INTERNAL_FEATURES"},
+	{senzing.SzEntityIncludeFeatureStats, "SZ_ENTITY_INCLUDE_FEATURE_STATS"},
+	{senzing.SzFindPathStrictAvoid, "SZ_FIND_PATH_STRICT_AVOID"},
+	{senzing.SzIncludeFeatureScores, "SZ_INCLUDE_FEATURE_SCORES"},
+	{senzing.SzSearchIncludeStats, "SZ_SEARCH_INCLUDE_STATS"},
+	{senzing.SzEntityIncludeRecordTypes, "SZ_ENTITY_INCLUDE_RECORD_TYPES"},
+	{senzing.SzEntityIncludeRelatedRecordTypes, "SZ_ENTITY_INCLUDE_RELATED_RECORD_TYPES"},
+	{senzing.SzFindPathIncludeMatchingInfo, "SZ_FIND_PATH_INCLUDE_MATCHING_INFO"},
+	{senzing.SzEntityIncludeRecordUnmappedData, "SZ_ENTITY_INCLUDE_RECORD_UNMAPPED_DATA"},
+	{senzing.SzEntityIncludeFeatureElements, "SZ_ENTITY_INCLUDE_FEATURE_ELEMENTS"},
+	{senzing.SzFindNetworkIncludeMatchingInfo, "SZ_FIND_NETWORK_INCLUDE_MATCHING_INFO"},
+	{senzing.SzIncludeMatchKeyDetails, "SZ_INCLUDE_MATCH_KEY_DETAILS"},
+	{senzing.SzWithInfo, "SZ_WITH_INFO"},
+}
+
+// SearchByAttributes reuses the export bits for the match levels of the entities found.
+var searchFlagNames = map[int64]string{
+	senzing.SzSearchIncludeResolved:        "SZ_SEARCH_INCLUDE_RESOLVED",
+	senzing.SzSearchIncludePossiblySame:    "SZ_SEARCH_INCLUDE_POSSIBLY_SAME",
+	senzing.SzSearchIncludePossiblyRelated: "SZ_SEARCH_INCLUDE_POSSIBLY_RELATED",
+	senzing.SzSearchIncludeNameOnly:        "SZ_SEARCH_INCLUDE_NAME_ONLY",
+}
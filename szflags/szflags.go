@@ -0,0 +1,435 @@
+package szflags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewEntityFlags function combines flags, as senzing.Flags() does, and checks that they apply to GetEntityByEntityID, GetEntityByRecordID and GetVirtualEntityByRecordID.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewEntityFlags(flags ...int64) (EntityFlags, error) {
+	result := EntityFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewExportFlags function combines flags, as senzing.Flags() does, and checks that they apply to the ExportCsv* and ExportJSON* methods.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewExportFlags(flags ...int64) (ExportFlags, error) {
+	result := ExportFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewFindNetworkFlags function combines flags, as senzing.Flags() does, and checks that they apply to FindNetworkByEntityID and FindNetworkByRecordID.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewFindNetworkFlags(flags ...int64) (FindNetworkFlags, error) {
+	result := FindNetworkFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewFindPathFlags function combines flags, as senzing.Flags() does, and checks that they apply to FindPathByEntityID and FindPathByRecordID.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewFindPathFlags(flags ...int64) (FindPathFlags, error) {
+	result := FindPathFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewHowFlags function combines flags, as senzing.Flags() does, and checks that they apply to HowEntityByEntityID.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewHowFlags(flags ...int64) (HowFlags, error) {
+	result := HowFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewRecordFlags function combines flags, as senzing.Flags() does, and checks that they apply to GetRecord.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewRecordFlags(flags ...int64) (RecordFlags, error) {
+	result := RecordFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewSearchFlags function combines flags, as senzing.Flags() does, and checks that they apply to SearchByAttributes.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewSearchFlags(flags ...int64) (SearchFlags, error) {
+	result := SearchFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewWhyFlags function combines flags, as senzing.Flags() does, and checks that they apply to WhyEntities, WhyRecordInEntity and WhyRecords.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewWhyFlags(flags ...int64) (WhyFlags, error) {
+	result := WhyFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+/*
+The NewWithInfoFlags function combines flags, as senzing.Flags() does, and checks that they apply to AddRecord, DeleteRecord, ProcessRedoRecord, ReevaluateEntity and ReevaluateRecord.
+
+Input
+  - flags: senzing.Sz* flags.
+
+Output
+  - The combined flags.
+*/
+func NewWithInfoFlags(flags ...int64) (WithInfoFlags, error) {
+	result := WithInfoFlags(senzing.Flags(flags...))
+	if err := result.Validate(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Flags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags Flags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags Flags) String() string {
+	return names(int64(flags), nil)
+}
+
+// ----------------------------------------------------------------------------
+// EntityFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags EntityFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags EntityFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to GetEntityByEntityID, GetEntityByRecordID and GetVirtualEntityByRecordID.
+Errors name the flags that do not.
+*/
+func (flags EntityFlags) Validate() error {
+	return validate(int64(flags), entityFlagsMask, "GetEntityByEntityID, GetEntityByRecordID and GetVirtualEntityByRecordID")
+}
+
+// ----------------------------------------------------------------------------
+// ExportFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags ExportFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags ExportFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to the ExportCsv* and ExportJSON* methods.
+Errors name the flags that do not.
+*/
+func (flags ExportFlags) Validate() error {
+	return validate(int64(flags), exportFlagsMask, "the ExportCsv* and ExportJSON* methods")
+}
+
+// ----------------------------------------------------------------------------
+// FindNetworkFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags FindNetworkFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags FindNetworkFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to FindNetworkByEntityID and FindNetworkByRecordID.
+Errors name the flags that do not.
+*/
+func (flags FindNetworkFlags) Validate() error {
+	return validate(int64(flags), findNetworkFlagsMask, "FindNetworkByEntityID and FindNetworkByRecordID")
+}
+
+// ----------------------------------------------------------------------------
+// FindPathFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags FindPathFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags FindPathFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to FindPathByEntityID and FindPathByRecordID.
+Errors name the flags that do not.
+*/
+func (flags FindPathFlags) Validate() error {
+	return validate(int64(flags), findPathFlagsMask, "FindPathByEntityID and FindPathByRecordID")
+}
+
+// ----------------------------------------------------------------------------
+// HowFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags HowFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags HowFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to HowEntityByEntityID.
+Errors name the flags that do not.
+*/
+func (flags HowFlags) Validate() error {
+	return validate(int64(flags), howFlagsMask, "HowEntityByEntityID")
+}
+
+// ----------------------------------------------------------------------------
+// RecordFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags RecordFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags RecordFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to GetRecord.
+Errors name the flags that do not.
+*/
+func (flags RecordFlags) Validate() error {
+	return validate(int64(flags), recordFlagsMask, "GetRecord")
+}
+
+// ----------------------------------------------------------------------------
+// SearchFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags SearchFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), searchFlagNames)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags SearchFlags) String() string {
+	return names(int64(flags), searchFlagNames)
+}
+
+/*
+The Validate method checks that the flags apply to SearchByAttributes.
+Errors name the flags that do not.
+*/
+func (flags SearchFlags) Validate() error {
+	return validate(int64(flags), searchFlagsMask, "SearchByAttributes")
+}
+
+// ----------------------------------------------------------------------------
+// WhyFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags WhyFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags WhyFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to WhyEntities, WhyRecordInEntity and WhyRecords.
+Errors name the flags that do not.
+*/
+func (flags WhyFlags) Validate() error {
+	return validate(int64(flags), whyFlagsMask, "WhyEntities, WhyRecordInEntity and WhyRecords")
+}
+
+// ----------------------------------------------------------------------------
+// WithInfoFlags methods
+// ----------------------------------------------------------------------------
+
+// Format formats the flags as their names with %v, %s and %d, and as a number with other verbs.
+func (flags WithInfoFlags) Format(state fmt.State, verb rune) {
+	format(state, verb, int64(flags), nil)
+}
+
+// String returns the names of the flags, such as "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_WITH_INFO", or "SZ_NO_FLAGS".
+func (flags WithInfoFlags) String() string {
+	return names(int64(flags), nil)
+}
+
+/*
+The Validate method checks that the flags apply to AddRecord, DeleteRecord, ProcessRedoRecord, ReevaluateEntity and ReevaluateRecord.
+Errors name the flags that do not.
+*/
+func (flags WithInfoFlags) Validate() error {
+	return validate(int64(flags), withInfoFlagsMask, "AddRecord, DeleteRecord, ProcessRedoRecord, ReevaluateEntity and ReevaluateRecord")
+}
+
+// ----------------------------------------------------------------------------
+// flagsError methods
+// ----------------------------------------------------------------------------
+
+func (err *flagsError) Error() string {
+	return err.message
+}
+
+func (err *flagsError) Unwrap() []error {
+	return []error{ErrInapplicableFlags, szerror.ErrSzBadInput}
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Format flags as their names for %v, %s and %d, and as a number for other verbs, such as %x.
+func format(state fmt.State, verb rune, flags int64, overrides map[int64]string) {
+	switch verb {
+	case 'd', 's', 'v':
+		_, _ = fmt.Fprintf(state, fmt.FormatString(state, 's'), names(flags, overrides))
+	default:
+		_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), flags)
+	}
+}
+
+// Decode flags into the names of their bits.  Bits without a name are shown in hexadecimal.
+func names(flags int64, overrides map[int64]string) string {
+	if flags == senzing.SzNoFlags {
+		return "SZ_NO_FLAGS"
+	}
+	result := []string{}
+	remaining := flags
+	for _, flagName := range flagNames {
+		if flags&flagName.flag == 0 {
+			continue
+		}
+		name, ok := overrides[flagName.flag]
+		if !ok {
+			name = flagName.name
+		}
+		result = append(result, name)
+		remaining &^= flagName.flag
+	}
+	if remaining != 0 {
+		result = append(result, fmt.Sprintf("%#x", uint64(remaining)))
+	}
+	return strings.Join(result, " | ")
+}
+
+func validate(flags int64, mask int64, methods string) error {
+	if inapplicable := flags &^ mask; inapplicable != 0 {
+		return &flagsError{message: fmt.Sprintf("flags %s do not apply to %s", names(inapplicable, nil), methods)}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package szflags
+
+import (
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNewWhyFlags() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szflags/szflags_examples_test.go
+	flags, err := NewWhyFlags(senzing.SzEntityIncludeEntityName, senzing.SzIncludeFeatureScores)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(flags)
+	_, err = NewWhyFlags(senzing.SzExportIncludeAllEntities)
+	fmt.Println(err)
+	// Output:
+	// SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_INCLUDE_FEATURE_SCORES
+	// flags SZ_EXPORT_INCLUDE_MULTI_RECORD_ENTITIES | SZ_EXPORT_INCLUDE_SINGLE_RECORD_ENTITIES do not apply to WhyEntities, WhyRecordInEntity and WhyRecords
+}
+
+func ExampleFlags_String() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szflags/szflags_examples_test.go
+	fmt.Println(Flags(senzing.SzFindPathDefaultFlags | senzing.SzWithInfo).String())
+	// Output: SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_ENTITY_INCLUDE_RECORD_SUMMARY | SZ_FIND_PATH_INCLUDE_MATCHING_INFO | SZ_WITH_INFO
+}
@@ -0,0 +1,119 @@
+package szflags
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestDefaults_Validate(test *testing.T) {
+	for _, flags := range []interface{ Validate() error }{
+		DefaultEntityFlags,
+		DefaultExportFlags,
+		DefaultFindNetworkFlags,
+		DefaultFindPathFlags,
+		DefaultHowFlags,
+		DefaultRecordFlags,
+		DefaultSearchFlags,
+		DefaultVirtualEntityFlags,
+		DefaultWhyFlags,
+		WhyFlags(senzing.SzWhyRecordInEntityIDefaultFlags),
+		WhyFlags(senzing.SzWhyRecordsDefaultFlags),
+		WithInfoFlags(senzing.SzWithInfo),
+		WithInfoFlags(senzing.SzNoFlags),
+	} {
+		require.NoError(test, flags.Validate(), "%T %v", flags, flags)
+	}
+}
+
+func TestFlags_String(test *testing.T) {
+	testCases := map[int64]string{
+		senzing.SzNoFlags:                        "SZ_NO_FLAGS",
+		senzing.SzWithInfo:                       "SZ_WITH_INFO",
+		senzing.SzFindPathDefaultFlags:           "SZ_ENTITY_INCLUDE_ENTITY_NAME | SZ_ENTITY_INCLUDE_RECORD_SUMMARY | SZ_FIND_PATH_INCLUDE_MATCHING_INFO",
+		senzing.SzExportIncludeAllEntities:       "SZ_EXPORT_INCLUDE_MULTI_RECORD_ENTITIES | SZ_EXPORT_INCLUDE_SINGLE_RECORD_ENTITIES",
+		senzing.Bit18 | senzing.SzWithInfo:       "SZ_WITH_INFO | 0x20000",
+		senzing.SzIncludeMatchKeyDetails | 1:     "SZ_EXPORT_INCLUDE_MULTI_RECORD_ENTITIES | SZ_INCLUDE_MATCH_KEY_DETAILS",
+		senzing.SzEntityIncludeAllRelations:      "SZ_ENTITY_INCLUDE_POSSIBLY_SAME_RELATIONS | SZ_ENTITY_INCLUDE_POSSIBLY_RELATED_RELATIONS | SZ_ENTITY_INCLUDE_NAME_ONLY_RELATIONS | SZ_ENTITY_INCLUDE_DISCLOSED_RELATIONS",
+		senzing.SzEntityIncludeRecordJSONData:    "SZ_ENTITY_INCLUDE_RECORD_JSON_DATA",
+		senzing.SzFindNetworkIncludeMatchingInfo: "SZ_FIND_NETWORK_INCLUDE_MATCHING_INFO",
+	}
+	for flags, expected := range testCases {
+		assert.Equal(test, expected, Flags(flags).String())
+	}
+}
+
+func TestFlags_Format(test *testing.T) {
+	flags := Flags(senzing.SzWithInfo)
+	assert.Equal(test, "SZ_WITH_INFO", fmt.Sprintf("%v", flags))
+	assert.Equal(test, "SZ_WITH_INFO", fmt.Sprintf("%s", flags))
+	assert.Equal(test, "SZ_WITH_INFO", fmt.Sprintf("%d", flags))
+	assert.Equal(test, "SZ_WITH_INFO", fmt.Sprintf("%#v", flags))
+	assert.Equal(test, "4000000000000000", fmt.Sprintf("%x", flags))
+	assert.Equal(test, "Enter AddRecord(CUSTOMERS, SZ_NO_FLAGS).", fmt.Sprintf("Enter AddRecord(%s, %d).", "CUSTOMERS", WithInfoFlags(0)))
+}
+
+func TestSearchFlags_String(test *testing.T) {
+	assert.Equal(test, "SZ_SEARCH_INCLUDE_RESOLVED | SZ_SEARCH_INCLUDE_POSSIBLY_SAME", SearchFlags(senzing.SzSearchByAttributesMinimalStrong).String())
+	assert.Equal(test, "SZ_EXPORT_INCLUDE_MULTI_RECORD_ENTITIES | SZ_EXPORT_INCLUDE_POSSIBLY_SAME", ExportFlags(senzing.SzSearchByAttributesMinimalStrong).String())
+}
+
+func TestNewWhyFlags(test *testing.T) {
+	actual, err := NewWhyFlags(senzing.SzEntityIncludeEntityName, senzing.SzIncludeFeatureScores)
+	require.NoError(test, err)
+	assert.Equal(test, WhyFlags(senzing.SzEntityIncludeEntityName|senzing.SzIncludeFeatureScores), actual)
+}
+
+func TestNewWhyFlags_exportFlags(test *testing.T) {
+	actual, err := NewWhyFlags(senzing.SzIncludeFeatureScores, senzing.SzExportIncludeAllEntities)
+	require.ErrorIs(test, err, ErrInapplicableFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.EqualError(test, err, "flags SZ_EXPORT_INCLUDE_MULTI_RECORD_ENTITIES | SZ_EXPORT_INCLUDE_SINGLE_RECORD_ENTITIES do not apply to WhyEntities, WhyRecordInEntity and WhyRecords")
+	assert.Zero(test, actual)
+}
+
+func TestNew_inapplicable(test *testing.T) {
+	testCases := map[string]func() error{
+		"entity":      func() error { _, err := NewEntityFlags(senzing.SzWithInfo); return err },
+		"export":      func() error { _, err := NewExportFlags(senzing.SzIncludeFeatureScores); return err },
+		"findNetwork": func() error { _, err := NewFindNetworkFlags(senzing.SzFindPathIncludeMatchingInfo); return err },
+		"findPath":    func() error { _, err := NewFindPathFlags(senzing.SzFindNetworkIncludeMatchingInfo); return err },
+		"how":         func() error { _, err := NewHowFlags(senzing.SzEntityIncludeEntityName); return err },
+		"record":      func() error { _, err := NewRecordFlags(senzing.SzEntityIncludeAllRelations); return err },
+		"search":      func() error { _, err := NewSearchFlags(senzing.SzFindPathStrictAvoid); return err },
+		"why":         func() error { _, err := NewWhyFlags(senzing.SzSearchIncludeStats); return err },
+		"withInfo":    func() error { _, err := NewWithInfoFlags(senzing.SzEntityIncludeEntityName); return err },
+	}
+	for name, newFlags := range testCases {
+		test.Run(name, func(test *testing.T) {
+			require.ErrorIs(test, newFlags(), ErrInapplicableFlags)
+		})
+	}
+}
+
+func TestNew_applicable(test *testing.T) {
+	_, err := NewEntityFlags(senzing.SzEntityIncludeAllFeatures, senzing.SzIncludeMatchKeyDetails)
+	require.NoError(test, err)
+	_, err = NewExportFlags(senzing.SzExportIncludeAllHavingRelationships, senzing.SzEntityIncludeEntityName)
+	require.NoError(test, err)
+	_, err = NewFindNetworkFlags(senzing.SzFindNetworkIncludeMatchingInfo)
+	require.NoError(test, err)
+	_, err = NewFindPathFlags(senzing.SzFindPathStrictAvoid, senzing.SzFindPathIncludeMatchingInfo)
+	require.NoError(test, err)
+	_, err = NewHowFlags(senzing.SzIncludeFeatureScores)
+	require.NoError(test, err)
+	_, err = NewRecordFlags(senzing.SzEntityIncludeRecordUnmappedData)
+	require.NoError(test, err)
+	_, err = NewSearchFlags(senzing.SzSearchByAttributesAll, senzing.SzSearchIncludeStats)
+	require.NoError(test, err)
+	_, err = NewWithInfoFlags()
+	require.NoError(test, err)
+}
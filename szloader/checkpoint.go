@@ -0,0 +1,145 @@
+package szloader
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// Tracks the records added or rejected, to move a checkpoint past them in the order they were read.
+type checkpointer struct {
+	checkpoint Checkpoint
+	fileName   string
+	lock       sync.Mutex
+	next       int64                   // The sequence of the record the checkpoint waits for.
+	pending    map[int64]recordOutcome // Records done out of order, by sequence.
+}
+
+// What became of a record.
+type recordOutcome struct {
+	loaded bool
+	offset int64
+}
+
+// ----------------------------------------------------------------------------
+// checkpointer methods
+// ----------------------------------------------------------------------------
+
+/*
+Record that a record was added or rejected, and move the checkpoint past every record read before it that is also done.
+Does nothing on a nil checkpointer, so that loads without a checkpoint need no checks.
+*/
+func (checkpointer *checkpointer) done(record record, loaded bool) {
+	if checkpointer == nil {
+		return
+	}
+	checkpointer.lock.Lock()
+	defer checkpointer.lock.Unlock()
+	checkpointer.pending[record.sequence] = recordOutcome{loaded: loaded, offset: record.offset}
+	for {
+		outcome, ok := checkpointer.pending[checkpointer.next]
+		if !ok {
+			return
+		}
+		delete(checkpointer.pending, checkpointer.next)
+		checkpointer.next++
+		checkpointer.checkpoint.Offset = outcome.offset
+		checkpointer.checkpoint.Read++
+		if outcome.loaded {
+			checkpointer.checkpoint.Loaded++
+		} else {
+			checkpointer.checkpoint.Rejected++
+		}
+	}
+}
+
+// Mark the whole input as done, including any blank lines after its last record.
+func (checkpointer *checkpointer) finish() {
+	checkpointer.lock.Lock()
+	defer checkpointer.lock.Unlock()
+	checkpointer.checkpoint.Offset = checkpointer.checkpoint.Input.Size
+	checkpointer.checkpoint.Complete = true
+}
+
+// Write the checkpoint file, replacing it atomically.  Does nothing on a nil checkpointer.
+func (checkpointer *checkpointer) save() error {
+	if checkpointer == nil {
+		return nil
+	}
+	checkpointer.lock.Lock()
+	content, err := json.Marshal(checkpointer.checkpoint)
+	checkpointer.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	temporaryFile := checkpointer.fileName + ".tmp"
+	if err := os.WriteFile(temporaryFile, append(content, '\n'), 0o600); err != nil {
+		return fmt.Errorf("cannot write checkpoint: %w", err)
+	}
+	if err := os.Rename(temporaryFile, checkpointer.fileName); err != nil {
+		return fmt.Errorf("cannot write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func newCheckpointer(fileName string, checkpoint Checkpoint) *checkpointer {
+	return &checkpointer{
+		checkpoint: checkpoint,
+		fileName:   fileName,
+		pending:    map[int64]recordOutcome{},
+	}
+}
+
+// Read the fingerprint of a file, and the position of an offset in it.
+func fingerprintFile(file io.ReadSeeker, offset int64) (Fingerprint, position, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return Fingerprint{}, position{}, err
+	}
+	digest := sha256.New()
+	result := position{offset: offset}
+	size := int64(0)
+	reader := bufio.NewReader(io.TeeReader(file, digest))
+	buffer := make([]byte, 64*1024)
+	for {
+		count, err := reader.Read(buffer)
+		if before := min(int64(count), offset-size); before > 0 {
+			result.line += int64(bytes.Count(buffer[:before], []byte{'\n'}))
+		}
+		size += int64(count)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Fingerprint{}, position{}, err
+		}
+	}
+	return Fingerprint{Size: size, SHA256: hex.EncodeToString(digest.Sum(nil))}, result, nil
+}
+
+// Read a checkpoint file.  A missing file is an empty checkpoint.
+func readCheckpoint(fileName string) (Checkpoint, error) {
+	result := Checkpoint{}
+	content, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return result, fmt.Errorf("checkpoint %s: %w", fileName, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,17 @@
+/*
+The szloader package loads files of records into Senzing with SzEngine.AddRecord().
+
+A Loader reads records from JSON lines, one record per line, or from CSV with a header row of attribute names,
+and adds them with a pool of concurrent workers.
+Records that cannot be parsed or added are written with their error to a rejects file and the load continues;
+an error matching szerror.ErrSzUnrecoverable stops it.
+Progress, including throughput, is reported at a regular interval and when the load ends.
+
+When the context is cancelled, for example on SIGINT, the Loader stops reading,
+lets the records already handed to workers finish, and returns the progress so far with the context's error.
+
+With WithCheckpoint, Loader.LoadFile keeps a checkpoint file of the input offset before which every record was added or rejected,
+so that loading the file again, for example after a restart, resumes there.
+A checkpoint holds the size and SHA-256 digest of its input; loading a file that changed since is an error, ErrInputChanged.
+*/
+package szloader
@@ -0,0 +1,70 @@
+package szloader
+
+import (
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Checkpoint is the content of a checkpoint file.
+It records how far loads of an input got, so that a later load of the same input resumes there.
+*/
+type Checkpoint struct {
+	Input    Fingerprint `json:"input"`    // The input the checkpoint applies to.
+	Offset   int64       `json:"offset"`   // Every record that ends at or before this byte offset was added or rejected.
+	Read     int64       `json:"read"`     // Records read before Offset, by every load.
+	Loaded   int64       `json:"loaded"`   // Records added before Offset, by every load.
+	Rejected int64       `json:"rejected"` // Records rejected before Offset, by every load.
+	Complete bool        `json:"complete"` // Whether a load reached the end of the input.
+}
+
+// Fingerprint identifies the content of an input file.
+type Fingerprint struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"` // The hex SHA-256 digest of the file.
+}
+
+// Format is the format of a record file.
+type Format int
+
+// Progress counts the records of a load.
+type Progress struct {
+	Read     int64         // Records read, including those rejected before being added.
+	Loaded   int64         // Records added.
+	Rejected int64         // Records written to the rejects file.
+	Elapsed  time.Duration // Time since the load started.
+}
+
+// Reject is a line of the rejects file: a record that could not be parsed or added.
+type Reject struct {
+	Line   int64  `json:"line"`   // The line number of the record, counting from 1.  For CSV, the header is line 1.
+	Error  string `json:"error"`  // Why the record was rejected.
+	Record string `json:"record"` // The record as read, or as converted to JSON from CSV.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Record file formats.
+const (
+	FormatJSONL Format = iota // One JSON record per line.  Blank lines are skipped.
+	FormatCSV                 // A header row of attribute names, then one record per row.  Empty values are omitted.
+)
+
+// DefaultProgressInterval is how often progress is reported, unless changed with WithProgress().
+const DefaultProgressInterval = 10 * time.Second
+
+// DefaultWorkers is the number of concurrent AddRecord calls, unless changed with WithWorkers().
+const DefaultWorkers = 4
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInputChanged is returned by Loader.LoadFile when the file is not the one its checkpoint was written for.
+var ErrInputChanged = errors.New("input changed since the checkpoint was written")
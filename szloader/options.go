@@ -0,0 +1,136 @@
+package szloader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Option configures a Loader created by New.
+type Option func(*Loader) error
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The WithCheckpoint function keeps a checkpoint file, so that Loader.LoadFile resumes where an earlier load of the file stopped.
+The checkpoint is saved at the progress interval and when a load ends.
+A checkpoint applies to one input file: a file that changed since the checkpoint was written is an error, ErrInputChanged.
+To detect changes, LoadFile reads the whole file once before loading it.
+Loader.Load cannot be used with a checkpoint.
+
+Input
+  - fileName: The checkpoint file.  It is created if it does not exist.
+*/
+func WithCheckpoint(fileName string) Option {
+	return func(loader *Loader) error {
+		if len(fileName) == 0 {
+			return errors.New("checkpoint file name cannot be empty")
+		}
+		loader.checkpointFile = fileName
+		return nil
+	}
+}
+
+/*
+The WithDataSource function sets the data source of records that have no DATA_SOURCE attribute.
+Without it, such records are rejected.
+
+Input
+  - dataSourceCode: The data source code, such as "CUSTOMERS".
+*/
+func WithDataSource(dataSourceCode string) Option {
+	return func(loader *Loader) error {
+		if len(dataSourceCode) == 0 {
+			return errors.New("data source code cannot be empty")
+		}
+		loader.dataSourceCode = dataSourceCode
+		return nil
+	}
+}
+
+/*
+The WithInfo function requests the entities affected by each record and writes them to the writer,
+one JSON document per line, for example to feed downstream systems.
+
+Input
+  - writer: Where the "with info" results are written.
+*/
+func WithInfo(writer io.Writer) Option {
+	return func(loader *Loader) error {
+		if writer == nil {
+			return errors.New("info writer cannot be nil")
+		}
+		loader.flags |= senzing.SzWithInfo
+		loader.info = writer
+		return nil
+	}
+}
+
+/*
+The WithProgress function reports progress to a function at an interval, and once more when the load ends.
+The interval is also how often a checkpoint is saved.
+The function is called from a single goroutine.
+
+Input
+  - report: The function called with the progress so far.
+  - interval: How often progress is reported.  Zero means DefaultProgressInterval.
+*/
+func WithProgress(report func(Progress), interval time.Duration) Option {
+	return func(loader *Loader) error {
+		if report == nil {
+			return errors.New("progress function cannot be nil")
+		}
+		if interval < 0 {
+			return fmt.Errorf("progress interval cannot be negative: %s", interval)
+		}
+		if interval == 0 {
+			interval = DefaultProgressInterval
+		}
+		loader.progressInterval = interval
+		loader.report = report
+		return nil
+	}
+}
+
+/*
+The WithRejects function writes rejected records to the writer as JSON lines of Reject.
+Without it, rejected records are only counted.
+
+Input
+  - writer: The rejects file.
+*/
+func WithRejects(writer io.Writer) Option {
+	return func(loader *Loader) error {
+		if writer == nil {
+			return errors.New("rejects writer cannot be nil")
+		}
+		loader.rejects = writer
+		return nil
+	}
+}
+
+/*
+The WithWorkers function sets the number of concurrent AddRecord calls.
+The default is DefaultWorkers.
+
+Input
+  - workers: The number of workers, at least 1.
+*/
+func WithWorkers(workers int) Option {
+	return func(loader *Loader) error {
+		if workers < 1 {
+			return fmt.Errorf("workers must be at least 1, not %d", workers)
+		}
+		loader.workers = workers
+		return nil
+	}
+}
@@ -0,0 +1,532 @@
+package szloader
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// Loader adds the records of files to Senzing.
+type Loader struct {
+	checkpointFile   string
+	dataSourceCode   string
+	flags            int64
+	info             io.Writer
+	progressInterval time.Duration
+	rejects          io.Writer
+	report           func(Progress)
+	szEngine         senzing.SzEngine
+	workers          int
+}
+
+// The state of one call to Load or LoadFile.
+type load struct {
+	checkpoint *checkpointer // Nil without WithCheckpoint.
+	err        error
+	errOnce    sync.Once
+	loaded     atomic.Int64
+	loader     *Loader
+	read       atomic.Int64
+	rejected   atomic.Int64
+	startTime  time.Time
+	writeLock  sync.Mutex
+}
+
+// A record read from a file.
+type record struct {
+	dataSourceCode   string
+	err              error // Why the record cannot be added, if it cannot.
+	line             int64
+	offset           int64 // The byte offset of the end of the record in the input.
+	recordDefinition string
+	recordID         string
+	sequence         int64 // The order in which the record was read, from 0.
+}
+
+// A place in an input.
+type position struct {
+	line   int64 // The number of lines before the place.
+	offset int64
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function creates a Loader.
+
+Input
+  - szEngine: The engine the records are added to.
+  - options: Options that set the workers, the rejects and info files, progress reporting and a default data source.
+*/
+func New(szEngine senzing.SzEngine, options ...Option) (*Loader, error) {
+	if szEngine == nil {
+		return nil, errors.New("szEngine cannot be nil")
+	}
+	result := &Loader{
+		progressInterval: DefaultProgressInterval,
+		szEngine:         szEngine,
+		workers:          DefaultWorkers,
+	}
+	for _, option := range options {
+		if err := option(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+/*
+The FormatOf function chooses the format of a record file from its name:
+FormatCSV for names ending in ".csv", otherwise FormatJSONL.
+
+Input
+  - fileName: The name of the record file.
+*/
+func FormatOf(fileName string) Format {
+	if strings.HasSuffix(strings.ToLower(fileName), ".csv") {
+		return FormatCSV
+	}
+	return FormatJSONL
+}
+
+// ----------------------------------------------------------------------------
+// Loader methods
+// ----------------------------------------------------------------------------
+
+/*
+The Load method adds the records read from the reader.
+It returns when every record has been added or rejected, when a record fails with an unrecoverable error,
+or when the context is cancelled and the records already handed to workers have finished.
+
+Input
+  - ctx: Cancel it to stop the load gracefully.
+  - reader: The record file.
+  - format: The format of the record file.
+
+Output
+  - The final progress.
+  - An error if the file could not be read, the rejects or info file could not be written,
+    a record failed with an unrecoverable error, or the context was cancelled.
+*/
+func (loader *Loader) Load(ctx context.Context, reader io.Reader, format Format) (Progress, error) {
+	if len(loader.checkpointFile) > 0 {
+		return Progress{}, errors.New("a Loader with a checkpoint loads files with LoadFile")
+	}
+	return loader.run(ctx, newLoad(loader), func(send func(record) error) error {
+		return readRecords(reader, format, position{}, nil, send)
+	})
+}
+
+/*
+The LoadFile method adds the records of a file, as Load does.
+With WithCheckpoint, it resumes after the records that earlier loads of the same file added or rejected,
+and it keeps the checkpoint file up to date as records are added.
+Progress counts only the records of this call.
+
+Input
+  - ctx: Cancel it to stop the load gracefully.
+  - fileName: The record file.
+  - format: The format of the record file.
+
+Output
+  - The final progress.
+  - An error as for Load.  It matches ErrInputChanged if the file changed since the checkpoint was written.
+*/
+func (loader *Loader) LoadFile(ctx context.Context, fileName string, format Format) (Progress, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return Progress{}, err
+	}
+	defer file.Close()
+	if len(loader.checkpointFile) == 0 {
+		return loader.Load(ctx, file, format)
+	}
+	checkpoint, err := readCheckpoint(loader.checkpointFile)
+	if err != nil {
+		return Progress{}, err
+	}
+	fingerprint, start, err := fingerprintFile(file, checkpoint.Offset)
+	if err != nil {
+		return Progress{}, err
+	}
+	if checkpoint.Input != (Fingerprint{}) && checkpoint.Input != fingerprint {
+		return Progress{}, fmt.Errorf("%s: %w", fileName, ErrInputChanged)
+	}
+	checkpoint.Input = fingerprint
+	var header []string
+	if format == FormatCSV && start.offset > 0 {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return Progress{}, err
+		}
+		if header, err = readCsvHeader(csv.NewReader(file)); err != nil {
+			return Progress{}, err
+		}
+	}
+	if _, err := file.Seek(start.offset, io.SeekStart); err != nil {
+		return Progress{}, err
+	}
+	state := newLoad(loader)
+	state.checkpoint = newCheckpointer(loader.checkpointFile, checkpoint)
+	return loader.run(ctx, state, func(send func(record) error) error {
+		return readRecords(file, format, start, header, send)
+	})
+}
+
+/*
+Read records with a reader goroutine, add them with the workers, and report progress, until the records end,
+the load fails or the context is cancelled.
+*/
+func (loader *Loader) run(ctx context.Context, state *load, read func(send func(record) error) error) (Progress, error) {
+	loadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	records := make(chan record, loader.workers)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(records)
+		readErr <- read(loader.sender(loadCtx, records))
+	}()
+	reportDone := make(chan struct{})
+	stopReport := make(chan struct{})
+	go func() {
+		defer close(reportDone)
+		state.reportProgress(stopReport, cancel)
+	}()
+	workers := sync.WaitGroup{}
+	for range loader.workers {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for record := range records {
+				if loadCtx.Err() != nil {
+					continue // Drain records queued before the load stopped.
+				}
+				if err := state.add(ctx, record); err != nil {
+					state.fail(err)
+					cancel()
+				}
+			}
+		}()
+	}
+	workers.Wait()
+	close(stopReport)
+	<-reportDone
+	if err := <-readErr; err != nil && !errors.Is(err, context.Canceled) {
+		state.fail(err)
+	}
+	if state.err == nil && ctx.Err() != nil {
+		state.fail(ctx.Err())
+	}
+	if state.checkpoint != nil {
+		if state.err == nil {
+			state.checkpoint.finish()
+		}
+		if err := state.checkpoint.save(); err != nil {
+			state.fail(err)
+		}
+	}
+	return state.progress(), state.err
+}
+
+// A function that sends records to the channel, in sequence, until the context is cancelled.
+func (loader *Loader) sender(ctx context.Context, records chan<- record) func(record) error {
+	sequence := int64(0)
+	return func(result record) error {
+		result.sequence = sequence
+		sequence++
+		if result.err == nil && len(result.dataSourceCode) == 0 {
+			result.dataSourceCode = loader.dataSourceCode
+		}
+		if result.err == nil && len(result.dataSourceCode) == 0 {
+			result.err = errors.New("record has no DATA_SOURCE")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case records <- result:
+			return nil
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// load methods
+// ----------------------------------------------------------------------------
+
+/*
+Add a record, or reject it.  Calls are not cancelled with the load, so that a record is never half-loaded.
+Returns an error only if the load must stop.
+*/
+func (state *load) add(ctx context.Context, record record) error {
+	state.read.Add(1)
+	if record.err != nil {
+		return state.reject(record, record.err)
+	}
+	if err := state.addRecord(ctx, record); err != nil {
+		return err
+	}
+	state.checkpoint.done(record, true)
+	return nil
+}
+
+func (state *load) addRecord(ctx context.Context, record record) error {
+	info, err := state.loader.szEngine.AddRecord(context.WithoutCancel(ctx), record.dataSourceCode, record.recordID, record.recordDefinition, state.loader.flags)
+	if err != nil {
+		if errors.Is(err, szerror.ErrSzUnrecoverable) {
+			return fmt.Errorf("line %d: %w", record.line, err)
+		}
+		return state.reject(record, err)
+	}
+	state.loaded.Add(1)
+	if state.loader.info != nil {
+		return state.write(state.loader.info, []byte(strings.TrimSpace(info)))
+	}
+	return nil
+}
+
+// Keep the first error that stops the load.
+func (state *load) fail(err error) {
+	state.errOnce.Do(func() { state.err = err })
+}
+
+func (state *load) progress() Progress {
+	return Progress{
+		Read:     state.read.Load(),
+		Loaded:   state.loaded.Load(),
+		Rejected: state.rejected.Load(),
+		Elapsed:  time.Since(state.startTime),
+	}
+}
+
+func (state *load) reject(record record, err error) error {
+	state.rejected.Add(1)
+	if state.loader.rejects == nil {
+		state.checkpoint.done(record, false)
+		return nil
+	}
+	line, marshalErr := json.Marshal(Reject{Line: record.line, Error: err.Error(), Record: record.recordDefinition})
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if err := state.write(state.loader.rejects, line); err != nil {
+		return err
+	}
+	state.checkpoint.done(record, false)
+	return nil
+}
+
+/*
+Report progress and save the checkpoint at the interval until stopped, then report once more.
+If the checkpoint cannot be saved, the load fails.
+*/
+func (state *load) reportProgress(stop <-chan struct{}, cancel context.CancelFunc) {
+	if state.loader.report == nil && state.checkpoint == nil {
+		return
+	}
+	ticker := time.NewTicker(state.loader.progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			if state.loader.report != nil {
+				state.loader.report(state.progress())
+			}
+			return
+		case <-ticker.C:
+			if state.loader.report != nil {
+				state.loader.report(state.progress())
+			}
+			if err := state.checkpoint.save(); err != nil {
+				state.fail(err)
+				cancel()
+			}
+		}
+	}
+}
+
+// Write a line to the rejects or info file.
+func (state *load) write(writer io.Writer, line []byte) error {
+	state.writeLock.Lock()
+	defer state.writeLock.Unlock()
+	_, err := writer.Write(append(line, '\n'))
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Progress methods
+// ----------------------------------------------------------------------------
+
+// The RecordsPerSecond method returns the throughput: records read per second of elapsed time.
+func (progress Progress) RecordsPerSecond() float64 {
+	if progress.Elapsed <= 0 {
+		return 0
+	}
+	return float64(progress.Read) / progress.Elapsed.Seconds()
+}
+
+// The String method describes the progress, for example in log messages.
+func (progress Progress) String() string {
+	return fmt.Sprintf("%d read, %d loaded, %d rejected in %s (%.1f records/s)",
+		progress.Read, progress.Loaded, progress.Rejected, progress.Elapsed.Round(time.Millisecond), progress.RecordsPerSecond())
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func newLoad(loader *Loader) *load {
+	return &load{loader: loader, startTime: time.Now()}
+}
+
+// A record from the attributes of a JSON record.
+func newRecord(line int64, recordDefinition string, attributes map[string]any) record {
+	result := record{line: line, recordDefinition: recordDefinition}
+	result.dataSourceCode = attributeString(attributes["DATA_SOURCE"])
+	result.recordID = attributeString(attributes["RECORD_ID"])
+	if len(result.recordID) == 0 {
+		result.err = errors.New("record has no RECORD_ID")
+	}
+	return result
+}
+
+// The string value of an attribute.  Numbers, such as a numeric RECORD_ID, are formatted as written.
+func attributeString(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case json.Number:
+		return typed.String()
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// Read CSV records: a header row of attribute names, then a record per row.
+func readCsv(reader io.Reader, start position, header []string, send func(record) error) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	if header == nil {
+		var err error
+		if header, err = readCsvHeader(csvReader); err != nil || header == nil {
+			return err
+		}
+	}
+	for {
+		row, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return err
+		}
+		line := start.line
+		if parseErr != nil {
+			line += int64(parseErr.StartLine) // FieldPos panics after a parse error.
+		} else {
+			fieldLine, _ := csvReader.FieldPos(0)
+			line += int64(fieldLine)
+		}
+		var result record
+		switch {
+		case parseErr != nil:
+			result = record{line: line, err: err}
+		case len(row) > len(header):
+			result = record{line: line, recordDefinition: strings.Join(row, ","), err: fmt.Errorf("row has %d fields but the header has %d", len(row), len(header))}
+		default:
+			attributes := map[string]any{}
+			for index, value := range row {
+				if len(value) > 0 {
+					attributes[header[index]] = value
+				}
+			}
+			recordDefinition, err := json.Marshal(attributes)
+			if err != nil {
+				return err
+			}
+			result = newRecord(line, string(recordDefinition), attributes)
+		}
+		result.offset = start.offset + csvReader.InputOffset()
+		if err := send(result); err != nil {
+			return err
+		}
+	}
+}
+
+// Read the header row of a CSV file.  Returns nil for an empty file.
+func readCsvHeader(csvReader *csv.Reader) ([]string, error) {
+	result, err := csvReader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CSV header: %w", err)
+	}
+	if len(result) > 0 {
+		result[0] = strings.TrimPrefix(result[0], "\ufeff")
+	}
+	for index := range result {
+		result[index] = strings.TrimSpace(result[index])
+	}
+	return result, nil
+}
+
+// Read JSON records, one per line.
+func readJSONL(reader io.Reader, start position, send func(record) error) error {
+	bufferedReader := bufio.NewReader(reader)
+	offset := start.offset
+	for line := start.line + 1; ; line++ {
+		text, err := bufferedReader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		offset += int64(len(text))
+		if trimmed := bytes.TrimSpace(text); len(trimmed) > 0 {
+			result := parseJSONRecord(line, trimmed)
+			result.offset = offset
+			if sendErr := send(result); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+/*
+Read the records of an input from a position: from the start, or from where a checkpoint left off.
+A CSV input read from past its start needs its header.
+*/
+func readRecords(reader io.Reader, format Format, start position, header []string, send func(record) error) error {
+	if format == FormatCSV {
+		return readCsv(reader, start, header, send)
+	}
+	return readJSONL(reader, start, send)
+}
+
+func parseJSONRecord(line int64, text []byte) record {
+	attributes := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(&attributes); err != nil {
+		return record{line: line, recordDefinition: string(text), err: fmt.Errorf("invalid JSON: %w", err)}
+	}
+	return newRecord(line, string(text), attributes)
+}
@@ -0,0 +1,48 @@
+package szloader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleLoader_Load() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szloader/szloader_examples_test.go
+	ctx := context.TODO()
+	server, err := fakeserver.New(fakeserver.WithDataSources("CUSTOMERS"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	grpcConnection, err := server.NewClient()
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = grpcConnection.Close() }()
+	szEngine := &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+	loader, err := New(szEngine, WithDataSource("CUSTOMERS"), WithRejects(os.Stdout))
+	if err != nil {
+		fmt.Println(err)
+	}
+	records := `{"RECORD_ID": "1001", "NAME_FULL": "Robert Smith"}
+{"RECORD_ID": "1002", "NAME_FULL": "Bob Smith"}
+{"NAME_FULL": "Mary Jones"}
+`
+	progress, err := loader.Load(ctx, strings.NewReader(records), FormatJSONL)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(progress.Loaded, progress.Rejected)
+	// Output:
+	// {"line":3,"error":"record has no RECORD_ID","record":"{\"NAME_FULL\": \"Mary Jones\"}"}
+	// 2 1
+}
@@ -0,0 +1,311 @@
+package szloader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	szenginepb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const addRecordMethod = "/szengine.SzEngine/AddRecord"
+
+var testJSONL = `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAME_FULL": "Robert Smith", "PHONE_NUMBER": "702-919-1300"}
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002", "NAME_FULL": "Bob Smith", "PHONE_NUMBER": "702-919-1300"}
+
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": 1003, "NAME_FULL": "Mary Jones"}
+`
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLoader_Load_jsonl(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	loader, err := New(szEngine)
+	require.NoError(test, err)
+	progress, err := loader.Load(ctx, strings.NewReader(testJSONL), FormatJSONL)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), progress.Read)
+	assert.Equal(test, int64(3), progress.Loaded)
+	assert.Equal(test, int64(0), progress.Rejected)
+	_, err = szEngine.GetRecord(ctx, "CUSTOMERS", "1003", senzing.SzNoFlags)
+	require.NoError(test, err)
+}
+
+func TestLoader_Load_csv(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	loader, err := New(szEngine, WithDataSource("CUSTOMERS"))
+	require.NoError(test, err)
+	csvFile := "\ufeffRECORD_ID, NAME_FULL,PHONE_NUMBER\n1001,Robert Smith,702-919-1300\n1002,\"Smith, Bob\",\n"
+	progress, err := loader.Load(ctx, strings.NewReader(csvFile), FormatCSV)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), progress.Loaded)
+	actual, err := szEngine.GetRecord(ctx, "CUSTOMERS", "1002", senzing.SzEntityIncludeRecordJSONData)
+	require.NoError(test, err)
+	assert.Contains(test, actual, `"NAME_FULL":"Smith, Bob"`)
+	assert.NotContains(test, actual, "PHONE_NUMBER")
+	rejects := &bytes.Buffer{}
+	loader, err = New(szEngine, WithDataSource("CUSTOMERS"), WithRejects(rejects), WithWorkers(1))
+	require.NoError(test, err)
+	csvFile = "RECORD_ID,NAME_FULL\n10\"03,Mary Smith\n1004,Mary Jones\n"
+	progress, err = loader.Load(ctx, strings.NewReader(csvFile), FormatCSV)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), progress.Loaded)
+	assert.Equal(test, int64(1), progress.Rejected)
+	reject := Reject{}
+	require.NoError(test, json.Unmarshal(rejects.Bytes(), &reject))
+	assert.Equal(test, int64(2), reject.Line)
+	assert.Contains(test, reject.Error, "bare \"")
+	_, err = szEngine.GetRecord(ctx, "CUSTOMERS", "1004", senzing.SzNoFlags)
+	require.NoError(test, err)
+}
+
+func TestLoader_Load_rejects(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getTestEngine(test)
+	rejects := &bytes.Buffer{}
+	loader, err := New(szEngine, WithRejects(rejects), WithWorkers(1))
+	require.NoError(test, err)
+	records := `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAME_FULL": "Robert Smith"}
+{"DATA_SOURCE": "CUSTOMERS", "NAME_FULL": "No Record ID"}
+{"RECORD_ID": "1003", "NAME_FULL": "No Data Source"}
+not JSON
+{"DATA_SOURCE": "BOB", "RECORD_ID": "1005", "NAME_FULL": "Unknown Data Source"}
+`
+	progress, err := loader.Load(ctx, strings.NewReader(records), FormatJSONL)
+	require.NoError(test, err)
+	assert.Equal(test, Progress{Read: 5, Loaded: 1, Rejected: 4, Elapsed: progress.Elapsed}, progress)
+	lines := []int64{}
+	for _, line := range strings.Split(strings.TrimSpace(rejects.String()), "\n") {
+		reject := Reject{}
+		require.NoError(test, json.Unmarshal([]byte(line), &reject))
+		assert.NotEmpty(test, reject.Error)
+		assert.NotEmpty(test, reject.Record)
+		lines = append(lines, reject.Line)
+	}
+	assert.Equal(test, []int64{2, 3, 4, 5}, lines)
+}
+
+func TestLoader_Load_cancel(test *testing.T) {
+	server, szEngine := getTestServerEngine(test)
+	server.SetFaults(fakeserver.Latency(addRecordMethod, 20*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.TODO())
+	reports := 0
+	loader, err := New(szEngine, WithWorkers(1), WithProgress(func(progress Progress) {
+		reports++
+		if progress.Loaded > 0 {
+			cancel()
+		}
+	}, time.Millisecond))
+	require.NoError(test, err)
+	progress, err := loader.Load(ctx, strings.NewReader(testJSONL), FormatJSONL)
+	require.ErrorIs(test, err, context.Canceled)
+	assert.Less(test, progress.Read, int64(3))
+	assert.Equal(test, progress.Read, progress.Loaded)
+	assert.Positive(test, reports)
+}
+
+func TestLoader_Load_unrecoverable(test *testing.T) {
+	ctx := context.TODO()
+	server, szEngine := getTestServerEngine(test)
+	server.SetFaults(fakeserver.MalformedError(addRecordMethod, codes.PermissionDenied, "denied"))
+	rejects := &bytes.Buffer{}
+	loader, err := New(szEngine, WithRejects(rejects))
+	require.NoError(test, err)
+	progress, err := loader.Load(ctx, strings.NewReader(testJSONL), FormatJSONL)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+	assert.Equal(test, int64(0), progress.Loaded)
+	assert.Empty(test, rejects.String())
+}
+
+func TestLoader_Load_withInfo(test *testing.T) {
+	ctx := context.TODO()
+	info := &bytes.Buffer{}
+	loader, err := New(getTestEngine(test), WithInfo(info))
+	require.NoError(test, err)
+	_, err = loader.Load(ctx, strings.NewReader(testJSONL), FormatJSONL)
+	require.NoError(test, err)
+	assert.Len(test, strings.Split(strings.TrimSpace(info.String()), "\n"), 3)
+	assert.Contains(test, info.String(), `"AFFECTED_ENTITIES"`)
+}
+
+func TestLoader_Load_workers(test *testing.T) {
+	ctx := context.TODO()
+	server, szEngine := getTestServerEngine(test)
+	server.SetFaults(fakeserver.Latency(addRecordMethod, 10*time.Millisecond))
+	records := strings.Builder{}
+	for index := range 40 {
+		fmt.Fprintf(&records, `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "%d", "NAME_FULL": "Person %d"}`+"\n", index, index)
+	}
+	loader, err := New(szEngine, WithWorkers(8))
+	require.NoError(test, err)
+	progress, err := loader.Load(ctx, strings.NewReader(records.String()), FormatJSONL)
+	require.NoError(test, err)
+	assert.Equal(test, int64(40), progress.Loaded)
+	assert.Less(test, progress.Elapsed, 40*10*time.Millisecond)
+}
+
+func TestLoader_Load_checkpoint(test *testing.T) {
+	loader, err := New(getTestEngine(test), WithCheckpoint(filepath.Join(test.TempDir(), "checkpoint.json")))
+	require.NoError(test, err)
+	_, err = loader.Load(context.TODO(), strings.NewReader(testJSONL), FormatJSONL)
+	require.Error(test, err)
+}
+
+func TestLoader_LoadFile_resume(test *testing.T) {
+	directory := test.TempDir()
+	inputFile := filepath.Join(directory, "customers.jsonl")
+	require.NoError(test, os.WriteFile(inputFile, []byte(testJSONL), 0o600))
+	checkpointFile := filepath.Join(directory, "checkpoint.json")
+	added := []string{}
+	countAdds := func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if info.FullMethod == addRecordMethod {
+			added = append(added, request.(*szenginepb.AddRecordRequest).GetRecordId())
+		}
+		return handler(ctx, request)
+	}
+	server, szEngine := getTestServerEngine(test, fakeserver.WithServerOptions(grpc.UnaryInterceptor(countAdds)))
+	server.SetFaults(fakeserver.Latency(addRecordMethod, 50*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.TODO())
+	loader, err := New(szEngine, WithWorkers(1), WithCheckpoint(checkpointFile), WithProgress(func(progress Progress) {
+		if progress.Loaded > 0 {
+			cancel()
+		}
+	}, time.Millisecond))
+	require.NoError(test, err)
+	first, err := loader.LoadFile(ctx, inputFile, FormatJSONL)
+	require.ErrorIs(test, err, context.Canceled)
+	checkpoint, err := readCheckpoint(checkpointFile)
+	require.NoError(test, err)
+	assert.False(test, checkpoint.Complete)
+	assert.Equal(test, first.Loaded, checkpoint.Loaded)
+	server.SetFaults()
+	second, err := loader.LoadFile(context.TODO(), inputFile, FormatJSONL)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), first.Loaded+second.Loaded)
+	assert.Equal(test, []string{"1001", "1002", "1003"}, added)
+	checkpoint, err = readCheckpoint(checkpointFile)
+	require.NoError(test, err)
+	assert.Equal(test, Checkpoint{Input: checkpoint.Input, Offset: int64(len(testJSONL)), Read: 3, Loaded: 3, Complete: true}, checkpoint)
+	third, err := loader.LoadFile(context.TODO(), inputFile, FormatJSONL)
+	require.NoError(test, err)
+	assert.Equal(test, int64(0), third.Read)
+}
+
+func TestLoader_LoadFile_resumeCsv(test *testing.T) {
+	directory := test.TempDir()
+	inputFile := filepath.Join(directory, "customers.csv")
+	require.NoError(test, os.WriteFile(inputFile, []byte("RECORD_ID,NAME_FULL\n1001,Robert Smith\n1002,Bob Smith\n,No Record ID\n"), 0o600))
+	checkpointFile := filepath.Join(directory, "checkpoint.json")
+	fingerprint, _, err := fingerprintFile(mustOpen(test, inputFile), 0)
+	require.NoError(test, err)
+	checkpoint := Checkpoint{Input: fingerprint, Offset: int64(len("RECORD_ID,NAME_FULL\n1001,Robert Smith\n")), Read: 1, Loaded: 1}
+	require.NoError(test, newCheckpointer(checkpointFile, checkpoint).save())
+	rejects := &bytes.Buffer{}
+	loader, err := New(getTestEngine(test), WithDataSource("CUSTOMERS"), WithCheckpoint(checkpointFile), WithRejects(rejects))
+	require.NoError(test, err)
+	progress, err := loader.LoadFile(context.TODO(), inputFile, FormatCSV)
+	require.NoError(test, err)
+	assert.Equal(test, Progress{Read: 2, Loaded: 1, Rejected: 1, Elapsed: progress.Elapsed}, progress)
+	assert.Contains(test, rejects.String(), `"line":4`)
+	checkpoint, err = readCheckpoint(checkpointFile)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), checkpoint.Read)
+	assert.Equal(test, int64(2), checkpoint.Loaded)
+	assert.Equal(test, int64(1), checkpoint.Rejected)
+}
+
+func TestLoader_LoadFile_inputChanged(test *testing.T) {
+	directory := test.TempDir()
+	inputFile := filepath.Join(directory, "customers.jsonl")
+	require.NoError(test, os.WriteFile(inputFile, []byte(testJSONL), 0o600))
+	loader, err := New(getTestEngine(test), WithCheckpoint(filepath.Join(directory, "checkpoint.json")))
+	require.NoError(test, err)
+	_, err = loader.LoadFile(context.TODO(), inputFile, FormatJSONL)
+	require.NoError(test, err)
+	require.NoError(test, os.WriteFile(inputFile, []byte(strings.Replace(testJSONL, "Mary", "Mari", 1)), 0o600))
+	_, err = loader.LoadFile(context.TODO(), inputFile, FormatJSONL)
+	require.ErrorIs(test, err, ErrInputChanged)
+}
+
+func TestCheckpointer_done(test *testing.T) {
+	checkpointer := newCheckpointer("", Checkpoint{Offset: 10})
+	checkpointer.done(record{sequence: 1, offset: 30}, false)
+	assert.Equal(test, int64(10), checkpointer.checkpoint.Offset)
+	checkpointer.done(record{sequence: 0, offset: 20}, true)
+	assert.Equal(test, Checkpoint{Offset: 30, Read: 2, Loaded: 1, Rejected: 1}, checkpointer.checkpoint)
+	checkpointer.done(record{sequence: 3, offset: 50}, true)
+	assert.Equal(test, int64(30), checkpointer.checkpoint.Offset)
+}
+
+func TestNew_badOptions(test *testing.T) {
+	szEngine := &szengine.Szengine{}
+	for _, option := range []Option{
+		WithCheckpoint(""),
+		WithDataSource(""),
+		WithInfo(nil),
+		WithProgress(nil, time.Second),
+		WithProgress(func(Progress) {}, -time.Second),
+		WithRejects(nil),
+		WithWorkers(0),
+	} {
+		_, err := New(szEngine, option)
+		require.Error(test, err)
+	}
+	_, err := New(nil)
+	require.Error(test, err)
+}
+
+func TestFormatOf(test *testing.T) {
+	assert.Equal(test, FormatCSV, FormatOf("customers.CSV"))
+	assert.Equal(test, FormatJSONL, FormatOf("customers.jsonl"))
+	assert.Equal(test, FormatJSONL, FormatOf("-"))
+}
+
+func TestProgress_String(test *testing.T) {
+	progress := Progress{Read: 30, Loaded: 20, Rejected: 10, Elapsed: 2 * time.Second}
+	assert.InDelta(test, 15.0, progress.RecordsPerSecond(), 0.001)
+	assert.Equal(test, "30 read, 20 loaded, 10 rejected in 2s (15.0 records/s)", progress.String())
+	assert.Zero(test, Progress{}.RecordsPerSecond())
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func mustOpen(test *testing.T, fileName string) *os.File {
+	test.Helper()
+	result, err := os.Open(fileName)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = result.Close() })
+	return result
+}
+
+func getTestEngine(test *testing.T) *szengine.Szengine {
+	test.Helper()
+	_, result := getTestServerEngine(test)
+	return result
+}
+
+func getTestServerEngine(test *testing.T, options ...fakeserver.Option) (*fakeserver.Server, *szengine.Szengine) {
+	test.Helper()
+	server, grpcConnection := fakeserver.NewTestClient(test, append([]fakeserver.Option{fakeserver.WithDataSources("CUSTOMERS")}, options...)...)
+	return server, &szengine.Szengine{GrpcClient: szenginepb.NewSzEngineClient(grpcConnection)}
+}
@@ -0,0 +1,14 @@
+/*
+The szmetrics package records Prometheus metrics for calls made to a Senzing gRPC server.
+
+A Collector counts requests and errors, measures latency, and tracks in-flight calls for every
+method of the szconfig, szconfigmanager, szdiagnostic, szengine and szproduct clients.
+Errors are labeled with the gRPC code and the Senzing reason code.
+For streaming exports, such as ExportJSONEntityReportIterator() and ExportCsvEntityReportIterator(),
+it also counts the fragments and bytes received.
+
+The Collector implements prometheus.Collector and is registered by the application.
+Its interceptors are usually passed to szabstractfactory.WithUnaryInterceptors()
+and szabstractfactory.WithStreamInterceptors().
+*/
+package szmetrics
@@ -0,0 +1,41 @@
+package szmetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Option configures a Collector created by New.
+type Option func(*config)
+
+type config struct {
+	buckets     []float64
+	constLabels prometheus.Labels
+	namespace   string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The default namespace of every metric name.
+const DefaultNamespace = "senzing_client"
+
+// Label names.
+const (
+	GrpcCodeLabel   = "grpc_code"
+	MethodLabel     = "method"
+	ReasonCodeLabel = "reason_code"
+	ServiceLabel    = "service"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// DefaultBuckets are the latency histogram buckets, in seconds.
+// They extend prometheus.DefBuckets to cover long-running calls such as exports and searches.
+var DefaultBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
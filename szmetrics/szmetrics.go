@@ -0,0 +1,270 @@
+package szmetrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Collector records metrics for Senzing gRPC calls.  It implements prometheus.Collector.
+type Collector struct {
+	errorsTotal     *prometheus.CounterVec
+	exportBytes     *prometheus.CounterVec
+	exportFragments *prometheus.CounterVec
+	inFlight        *prometheus.GaugeVec
+	requestDuration *prometheus.HistogramVec
+	requestsTotal   *prometheus.CounterVec
+}
+
+type measuredClientStream struct {
+	grpc.ClientStream
+	collector *Collector
+	method    string
+	once      sync.Once
+	service   string
+	startTime time.Time
+	stop      func() bool // Stops finishing the call when the context is done.  Called by RecvMsg when the stream ends.
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function creates a Collector.
+The Collector must be registered, for example with prometheus.MustRegister(), before its metrics are exported.
+
+Input
+  - options: Options that set the namespace, constant labels and latency buckets.
+*/
+func New(options ...Option) *Collector {
+	config := &config{
+		buckets:   DefaultBuckets,
+		namespace: DefaultNamespace,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	methodLabels := []string{ServiceLabel, MethodLabel}
+	return &Collector{
+		errorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			ConstLabels: config.constLabels,
+			Help:        "Number of Senzing gRPC calls that failed, by gRPC code and Senzing reason code.",
+			Name:        "errors_total",
+			Namespace:   config.namespace,
+		}, []string{ServiceLabel, MethodLabel, GrpcCodeLabel, ReasonCodeLabel}),
+		exportBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			ConstLabels: config.constLabels,
+			Help:        "Number of bytes received from streaming exports.",
+			Name:        "export_bytes_total",
+			Namespace:   config.namespace,
+		}, methodLabels),
+		exportFragments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			ConstLabels: config.constLabels,
+			Help:        "Number of fragments received from streaming exports.",
+			Name:        "export_fragments_total",
+			Namespace:   config.namespace,
+		}, methodLabels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			ConstLabels: config.constLabels,
+			Help:        "Number of Senzing gRPC calls in progress.",
+			Name:        "in_flight_requests",
+			Namespace:   config.namespace,
+		}, methodLabels),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Buckets:     config.buckets,
+			ConstLabels: config.constLabels,
+			Help:        "Duration of Senzing gRPC calls in seconds.  For streams, the time until the stream ends.",
+			Name:        "request_duration_seconds",
+			Namespace:   config.namespace,
+		}, methodLabels),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			ConstLabels: config.constLabels,
+			Help:        "Number of Senzing gRPC calls started.",
+			Name:        "requests_total",
+			Namespace:   config.namespace,
+		}, methodLabels),
+	}
+}
+
+/*
+The WithBuckets function sets the latency histogram buckets.
+The default is DefaultBuckets.
+
+Input
+  - buckets: Upper bounds of the buckets, in seconds.
+*/
+func WithBuckets(buckets []float64) Option {
+	return func(config *config) {
+		config.buckets = buckets
+	}
+}
+
+/*
+The WithConstLabels function adds labels with fixed values to every metric,
+for example to distinguish several Senzing gRPC servers.
+
+Input
+  - constLabels: The label names and values.
+*/
+func WithConstLabels(constLabels prometheus.Labels) Option {
+	return func(config *config) {
+		config.constLabels = constLabels
+	}
+}
+
+/*
+The WithNamespace function sets the prefix of every metric name.
+The default is DefaultNamespace.
+
+Input
+  - namespace: The metric namespace.
+*/
+func WithNamespace(namespace string) Option {
+	return func(config *config) {
+		config.namespace = namespace
+	}
+}
+
+// ----------------------------------------------------------------------------
+// prometheus.Collector interface methods
+// ----------------------------------------------------------------------------
+
+// Collect sends the current value of every metric to the channel.
+func (collector *Collector) Collect(metrics chan<- prometheus.Metric) {
+	for _, metricCollector := range collector.getCollectors() {
+		metricCollector.Collect(metrics)
+	}
+}
+
+// Describe sends the descriptor of every metric to the channel.
+func (collector *Collector) Describe(descriptors chan<- *prometheus.Desc) {
+	for _, metricCollector := range collector.getCollectors() {
+		metricCollector.Describe(descriptors)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interceptors
+// ----------------------------------------------------------------------------
+
+/*
+The StreamClientInterceptor method returns an interceptor that records metrics for streaming calls.
+A stream is in flight until it ends, or until the context of the call is done,
+as when the consumer of an export iterator breaks out of its loop.
+Each message received counts as an export fragment.
+*/
+func (collector *Collector) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, streamer grpc.Streamer, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		service, methodName := splitMethod(method)
+		startTime := collector.start(service, methodName)
+		clientStream, err := streamer(ctx, streamDesc, grpcConnection, method, options...)
+		if err != nil {
+			collector.finish(service, methodName, startTime, err)
+			return nil, err
+		}
+		result := &measuredClientStream{
+			ClientStream: clientStream,
+			collector:    collector,
+			method:       methodName,
+			service:      service,
+			startTime:    startTime,
+		}
+		result.stop = context.AfterFunc(ctx, func() { result.finish(ctx.Err()) })
+		return result, nil
+	}
+}
+
+// The UnaryClientInterceptor method returns an interceptor that records metrics for unary calls.
+func (collector *Collector) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+		service, methodName := splitMethod(method)
+		startTime := collector.start(service, methodName)
+		err := invoker(ctx, method, request, reply, grpcConnection, options...)
+		collector.finish(service, methodName, startTime, err)
+		return err
+	}
+}
+
+// ----------------------------------------------------------------------------
+// grpc.ClientStream interface methods
+// ----------------------------------------------------------------------------
+
+func (stream *measuredClientStream) RecvMsg(message interface{}) error {
+	err := stream.ClientStream.RecvMsg(message)
+	switch {
+	case err == nil:
+		stream.collector.exportFragments.WithLabelValues(stream.service, stream.method).Inc()
+		if response, ok := message.(interface{ GetResult() string }); ok {
+			stream.collector.exportBytes.WithLabelValues(stream.service, stream.method).Add(float64(len(response.GetResult())))
+		}
+	case errors.Is(err, io.EOF):
+		stream.stop()
+		stream.finish(nil)
+	default:
+		stream.stop()
+		stream.finish(err)
+	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (collector *Collector) finish(service string, method string, startTime time.Time, err error) {
+	collector.inFlight.WithLabelValues(service, method).Dec()
+	collector.requestDuration.WithLabelValues(service, method).Observe(time.Since(startTime).Seconds())
+	if err == nil {
+		return
+	}
+	convertedError := helper.ConvertGrpcError(err)
+	reasonCode := "none"
+	var szGrpcError *helper.SzGrpcError
+	if errors.As(convertedError, &szGrpcError) && szGrpcError.ReasonCode > 0 {
+		reasonCode = strconv.Itoa(szGrpcError.ReasonCode)
+	}
+	grpcCode := status.Code(convertedError).String()
+	collector.errorsTotal.WithLabelValues(service, method, grpcCode, reasonCode).Inc()
+}
+
+func (collector *Collector) getCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		collector.errorsTotal,
+		collector.exportBytes,
+		collector.exportFragments,
+		collector.inFlight,
+		collector.requestDuration,
+		collector.requestsTotal,
+	}
+}
+
+func (collector *Collector) start(service string, method string) time.Time {
+	collector.requestsTotal.WithLabelValues(service, method).Inc()
+	collector.inFlight.WithLabelValues(service, method).Inc()
+	return time.Now()
+}
+
+// Finish the call once, when the stream ends or its context is done, whichever is first.
+func (stream *measuredClientStream) finish(err error) {
+	stream.once.Do(func() { stream.collector.finish(stream.service, stream.method, stream.startTime, err) })
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Split "/szengine.SzEngine/AddRecord" into "szengine.SzEngine" and "AddRecord".
+func splitMethod(fullMethod string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service, method
+}
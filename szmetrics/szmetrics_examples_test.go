@@ -0,0 +1,30 @@
+package szmetrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNew() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szmetrics/szmetrics_examples_test.go
+	ctx := context.TODO()
+	collector := New(WithConstLabels(prometheus.Labels{"senzing_server": "primary"}))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+	szAbstractFactory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithUnaryInterceptors(collector.UnaryClientInterceptor()),
+		szabstractfactory.WithStreamInterceptors(collector.StreamClientInterceptor()),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = szAbstractFactory.Close() }()
+	// Output:
+}
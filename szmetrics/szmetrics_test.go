@@ -0,0 +1,221 @@
+package szmetrics
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	bufconnAddress    = "passthrough:///bufnet"
+	bufconnBufferSize = 1024 * 1024
+	engineService     = "szengine.SzEngine"
+	exportFragment    = `{"RESOLVED_ENTITY":{"ENTITY_ID":1}}`
+)
+
+type testSzEngineServer struct {
+	szpb.UnimplementedSzEngineServer
+	block chan struct{}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestCollector_register(test *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(test, registry.Register(New()))
+	require.NoError(test, registry.Register(New(WithNamespace("other"))))
+	require.Error(test, registry.Register(New()))
+}
+
+func TestCollector_UnaryClientInterceptor(test *testing.T) {
+	ctx := context.TODO()
+	collector, szEngine, _ := getTestSzengine(test)
+	for i := 0; i < 3; i++ {
+		_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, 0)
+		require.NoError(test, err)
+	}
+	assert.InDelta(test, 3, testutil.ToFloat64(collector.requestsTotal.WithLabelValues(engineService, "AddRecord")), 0)
+	assert.InDelta(test, 0, testutil.ToFloat64(collector.inFlight.WithLabelValues(engineService, "AddRecord")), 0)
+	assert.Equal(test, 0, testutil.CollectAndCount(collector.errorsTotal))
+	assert.Equal(test, 1, testutil.CollectAndCount(collector.requestDuration))
+	assert.Equal(test, uint64(3), getSampleCount(test, collector, "AddRecord"))
+}
+
+func TestCollector_UnaryClientInterceptor_errors(test *testing.T) {
+	ctx := context.TODO()
+	collector, szEngine, _ := getTestSzengine(test)
+	_, err := szEngine.GetRecord(ctx, "CUSTOMERS", "9999", 0)
+	require.Error(test, err)
+	_, err = szEngine.GetRecord(ctx, "CUSTOMERS", "9999", 0)
+	require.Error(test, err)
+	_, err = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", 0)
+	require.Error(test, err)
+	expected := `
+		# HELP senzing_client_errors_total Number of Senzing gRPC calls that failed, by gRPC code and Senzing reason code.
+		# TYPE senzing_client_errors_total counter
+		senzing_client_errors_total{grpc_code="NotFound",method="GetRecord",reason_code="33",service="szengine.SzEngine"} 2
+		senzing_client_errors_total{grpc_code="Unavailable",method="DeleteRecord",reason_code="none",service="szengine.SzEngine"} 1
+	`
+	require.NoError(test, testutil.CollectAndCompare(collector, strings.NewReader(expected), "senzing_client_errors_total"))
+}
+
+func TestCollector_UnaryClientInterceptor_inFlight(test *testing.T) {
+	ctx := context.TODO()
+	collector, szEngine, server := getTestSzengine(test)
+	done := make(chan error)
+	go func() {
+		_, err := szEngine.GetStats(ctx)
+		done <- err
+	}()
+	require.Eventually(test, func() bool {
+		return testutil.ToFloat64(collector.inFlight.WithLabelValues(engineService, "GetStats")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	close(server.block)
+	require.NoError(test, <-done)
+	assert.InDelta(test, 0, testutil.ToFloat64(collector.inFlight.WithLabelValues(engineService, "GetStats")), 0)
+}
+
+func TestCollector_StreamClientInterceptor(test *testing.T) {
+	ctx := context.TODO()
+	collector, szEngine, _ := getTestSzengine(test)
+	count := 0
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, 0) {
+		require.NoError(test, fragment.Error)
+		count++
+	}
+	assert.Equal(test, 5, count)
+	method := "StreamExportJsonEntityReport"
+	assert.InDelta(test, 5, testutil.ToFloat64(collector.exportFragments.WithLabelValues(engineService, method)), 0)
+	assert.InDelta(test, 5*len(exportFragment), testutil.ToFloat64(collector.exportBytes.WithLabelValues(engineService, method)), 0)
+	assert.InDelta(test, 1, testutil.ToFloat64(collector.requestsTotal.WithLabelValues(engineService, method)), 0)
+	assert.InDelta(test, 0, testutil.ToFloat64(collector.inFlight.WithLabelValues(engineService, method)), 0)
+	assert.Equal(test, 0, testutil.CollectAndCount(collector.errorsTotal))
+	assert.Equal(test, uint64(1), getSampleCount(test, collector, method))
+}
+
+func TestCollector_StreamClientInterceptor_break(test *testing.T) {
+	ctx := context.TODO()
+	collector, szEngine, _ := getTestSzengine(test)
+	method := "StreamExportJsonEntityReport"
+	for _, err := range szEngine.ExportJSONEntities(ctx, 0) {
+		require.NoError(test, err)
+		break
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	fragments := szEngine.ExportJSONEntityReportIterator(ctx, 0)
+	fragment := <-fragments
+	require.NoError(test, fragment.Error)
+	cancel()
+	require.Eventually(test, func() bool {
+		return getSampleCount(test, collector, method) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.InDelta(test, 2, testutil.ToFloat64(collector.requestsTotal.WithLabelValues(engineService, method)), 0)
+	assert.InDelta(test, 0, testutil.ToFloat64(collector.inFlight.WithLabelValues(engineService, method)), 0)
+	assert.InDelta(test, 2, testutil.ToFloat64(collector.errorsTotal.WithLabelValues(engineService, method, codes.Canceled.String(), "none")), 0)
+}
+
+func TestCollector_WithConstLabels(test *testing.T) {
+	collector := New(WithConstLabels(prometheus.Labels{"server": "primary"}), WithBuckets([]float64{1}))
+	collector.requestsTotal.WithLabelValues(engineService, "AddRecord").Inc()
+	expected := `
+		# HELP senzing_client_requests_total Number of Senzing gRPC calls started.
+		# TYPE senzing_client_requests_total counter
+		senzing_client_requests_total{method="AddRecord",server="primary",service="szengine.SzEngine"} 1
+	`
+	require.NoError(test, testutil.CollectAndCompare(collector, strings.NewReader(expected), "senzing_client_requests_total"))
+}
+
+func TestSplitMethod(test *testing.T) {
+	service, method := splitMethod("/szengine.SzEngine/AddRecord")
+	assert.Equal(test, engineService, service)
+	assert.Equal(test, "AddRecord", method)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getSampleCount(test *testing.T, collector *Collector, method string) uint64 {
+	metric := &dto.Metric{}
+	observer := collector.requestDuration.WithLabelValues(engineService, method)
+	require.NoError(test, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func (server *testSzEngineServer) AddRecord(ctx context.Context, request *szpb.AddRecordRequest) (*szpb.AddRecordResponse, error) {
+	_ = ctx
+	_ = request
+	return &szpb.AddRecordResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) DeleteRecord(ctx context.Context, request *szpb.DeleteRecordRequest) (*szpb.DeleteRecordResponse, error) {
+	_ = ctx
+	_ = request
+	return nil, status.Error(codes.Unavailable, "server restarting")
+}
+
+func (server *testSzEngineServer) GetRecord(ctx context.Context, request *szpb.GetRecordRequest) (*szpb.GetRecordResponse, error) {
+	_ = ctx
+	_ = request
+	return nil, status.Error(codes.NotFound, `{"reason": "SENZ0033E|Unknown record"}`)
+}
+
+func (server *testSzEngineServer) GetStats(ctx context.Context, request *szpb.GetStatsRequest) (*szpb.GetStatsResponse, error) {
+	_ = request
+	select {
+	case <-server.block:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &szpb.GetStatsResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) StreamExportJsonEntityReport(request *szpb.StreamExportJsonEntityReportRequest, stream szpb.SzEngine_StreamExportJsonEntityReportServer) error {
+	_ = request
+	for i := 0; i < 5; i++ {
+		if err := stream.Send(&szpb.StreamExportJsonEntityReportResponse{Result: exportFragment}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getTestSzengine(test *testing.T, options ...Option) (*Collector, *szengine.Szengine, *testSzEngineServer) {
+	collector := New(options...)
+	server := &testSzEngineServer{block: make(chan struct{})}
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := grpc.NewServer()
+	szpb.RegisterSzEngineServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+	grpcConnection, err := grpc.NewClient(bufconnAddress,
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(collector.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(collector.StreamClientInterceptor()),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = grpcConnection.Close() })
+	return collector, &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}, server
+}
@@ -0,0 +1,17 @@
+/*
+The szmodel package defines Go types for the JSON documents returned by SzEngine methods:
+resolved and related entities, records, features, match information and record summaries,
+and the explanations returned by the Why* methods and HowEntityByEntityID.
+ConfigsResponse decodes the list of registered configurations returned by SzConfigManager.GetConfigs.
+
+The types follow the Senzing JSON field names, such as "RESOLVED_ENTITY" and "RECORD_SUMMARY".
+Fields that Senzing omits, because of the flags of a call, are left at their zero values,
+and fields the types do not know are ignored, so documents from newer Senzing versions still decode.
+
+Decode a document with Unmarshal, or use the ...Typed methods of szengine.Szengine, such as GetEntityByRecordIDTyped().
+
+RecordKeys, EntityIDs and DataSourceList build the JSON parameters of methods such as GetVirtualEntityByRecordID,
+FindNetworkByEntityID and FindPathByEntityID, and validate them before they are sent.
+The ...Typed methods take them in place of JSON strings; for optional lists, such as avoided entities, pass nil.
+*/
+package szmodel
@@ -0,0 +1,281 @@
+package szmodel
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// CandidateKey is a feature value that made an entity a candidate for matching.
+type CandidateKey struct {
+	FeatID   int64  `json:"FEAT_ID,omitempty"`
+	FeatDesc string `json:"FEAT_DESC,omitempty"`
+}
+
+// ConfigEntry is a registered configuration, as listed by SzConfigManager.GetConfigs.
+type ConfigEntry struct {
+	ConfigID       int64  `json:"CONFIG_ID"`
+	ConfigComments string `json:"CONFIG_COMMENTS"`
+	SysCreateDate  string `json:"SYS_CREATE_DT"` // Such as "2024-06-29 17:01:23.456", in UTC.
+}
+
+// ConfigsResponse is returned by SzConfigManager.GetConfigs.
+type ConfigsResponse struct {
+	Configs []ConfigEntry `json:"CONFIGS"`
+}
+
+/*
+DataSourceList is a list of data source codes, such as the requiredDataSources parameter of FindPathByEntityID.
+Its JSON() method builds the document, such as {"DATA_SOURCES": ["CUSTOMERS"]}.
+*/
+type DataSourceList []string
+
+/*
+EntityIDs is a list of entity IDs, such as the entityIDs parameter of FindNetworkByEntityID.
+Its JSON() method builds the document, such as {"ENTITIES": [{"ENTITY_ID": 1}]}.
+*/
+type EntityIDs []int64
+
+// EntityNetworkLink is a relationship between two entities of a network.
+type EntityNetworkLink struct {
+	MinEntityID int64 `json:"MIN_ENTITY_ID"`
+	MaxEntityID int64 `json:"MAX_ENTITY_ID"`
+	MatchInfo
+	IsAmbiguous int64 `json:"IS_AMBIGUOUS,omitempty"`
+	IsDisclosed int64 `json:"IS_DISCLOSED,omitempty"`
+}
+
+// EntityPath is a path between two entities, as a list of the entity IDs along it.  Entities is empty if there is no path.
+type EntityPath struct {
+	StartEntityID int64   `json:"START_ENTITY_ID"`
+	EndEntityID   int64   `json:"END_ENTITY_ID"`
+	Entities      []int64 `json:"ENTITIES"`
+}
+
+// EntityResponse is returned by GetEntityByEntityID, GetEntityByRecordID and GetVirtualEntityByRecordID.
+type EntityResponse struct {
+	ResolvedEntity  ResolvedEntity  `json:"RESOLVED_ENTITY"`
+	RelatedEntities []RelatedEntity `json:"RELATED_ENTITIES,omitempty"`
+}
+
+// Feature is a feature of an entity, such as a name or an address, with the values it was built from.
+type Feature struct {
+	FeatDesc       string             `json:"FEAT_DESC,omitempty"`
+	LibFeatID      int64              `json:"LIB_FEAT_ID,omitempty"`
+	UsageType      string             `json:"USAGE_TYPE,omitempty"`
+	FeatDescValues []FeatureDescValue `json:"FEAT_DESC_VALUES,omitempty"`
+}
+
+// FeatureDescValue is one of the values a feature was built from.
+type FeatureDescValue struct {
+	FeatDesc  string `json:"FEAT_DESC,omitempty"`
+	LibFeatID int64  `json:"LIB_FEAT_ID,omitempty"`
+}
+
+// FeatureID identifies a feature of a record.
+type FeatureID struct {
+	LibFeatID int64  `json:"LIB_FEAT_ID,omitempty"`
+	UsageType string `json:"USAGE_TYPE,omitempty"`
+}
+
+// FeatureScore compares a feature of a search, or of an entity, with a feature of a candidate entity.
+type FeatureScore struct {
+	InboundFeatID          int64  `json:"INBOUND_FEAT_ID,omitempty"`
+	InboundFeatDesc        string `json:"INBOUND_FEAT_DESC,omitempty"`
+	InboundFeatUsageType   string `json:"INBOUND_FEAT_USAGE_TYPE,omitempty"`
+	CandidateFeatID        int64  `json:"CANDIDATE_FEAT_ID,omitempty"`
+	CandidateFeatDesc      string `json:"CANDIDATE_FEAT_DESC,omitempty"`
+	CandidateFeatUsageType string `json:"CANDIDATE_FEAT_USAGE_TYPE,omitempty"`
+	Score                  int64  `json:"SCORE,omitempty"`
+	ScoreBucket            string `json:"SCORE_BUCKET,omitempty"`
+	ScoreBehavior          string `json:"SCORE_BEHAVIOR,omitempty"`
+}
+
+// FindNetworkResponse is returned by FindNetworkByEntityID and FindNetworkByRecordID.
+type FindNetworkResponse struct {
+	EntityPaths        []EntityPath        `json:"ENTITY_PATHS,omitempty"`
+	EntityNetworkLinks []EntityNetworkLink `json:"ENTITY_NETWORK_LINKS,omitempty"`
+	Entities           []EntityResponse    `json:"ENTITIES"`
+}
+
+// FindPathResponse is returned by FindPathByEntityID and FindPathByRecordID.
+type FindPathResponse struct {
+	EntityPaths []EntityPath     `json:"ENTITY_PATHS,omitempty"`
+	Entities    []EntityResponse `json:"ENTITIES"`
+}
+
+// HowFinalState is the entity at the end of its resolution, as virtual entities.  There is more than one if the entity needs to be split.
+type HowFinalState struct {
+	NeedReevaluation int64           `json:"NEED_REEVALUATION,omitempty"`
+	VirtualEntities  []VirtualEntity `json:"VIRTUAL_ENTITIES,omitempty"`
+}
+
+// HowMatchInfo describes how the virtual entities of a resolution step matched.
+type HowMatchInfo struct {
+	MatchKey      string                    `json:"MATCH_KEY,omitempty"`
+	ErruleCode    string                    `json:"ERRULE_CODE,omitempty"`
+	CandidateKeys map[string][]CandidateKey `json:"CANDIDATE_KEYS,omitempty"`
+	FeatureScores map[string][]FeatureScore `json:"FEATURE_SCORES,omitempty"`
+}
+
+// HowResponse is returned by HowEntityByEntityID.
+type HowResponse struct {
+	HowResults HowResults `json:"HOW_RESULTS"`
+}
+
+// HowResults are the steps that resolved an entity, and the result.
+type HowResults struct {
+	ResolutionSteps []HowStep     `json:"RESOLUTION_STEPS,omitempty"`
+	FinalState      HowFinalState `json:"FINAL_STATE"`
+}
+
+// HowStep is a resolution step: two virtual entities matched and became one.
+type HowStep struct {
+	Step                   int64         `json:"STEP"`
+	VirtualEntity1         VirtualEntity `json:"VIRTUAL_ENTITY_1"`
+	VirtualEntity2         VirtualEntity `json:"VIRTUAL_ENTITY_2"`
+	InboundVirtualEntityID string        `json:"INBOUND_VIRTUAL_ENTITY_ID,omitempty"`
+	ResultVirtualEntityID  string        `json:"RESULT_VIRTUAL_ENTITY_ID,omitempty"`
+	MatchInfo              HowMatchInfo  `json:"MATCH_INFO"`
+}
+
+// MatchInfo describes how an entity or a record matched.  Its fields appear inline in related entities and records.
+type MatchInfo struct {
+	MatchLevel     int64  `json:"MATCH_LEVEL,omitempty"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE,omitempty"`
+	MatchKey       string `json:"MATCH_KEY,omitempty"`
+	ErruleCode     string `json:"ERRULE_CODE,omitempty"`
+}
+
+// MemberRecord is a record of a virtual entity, with the records it was deduplicated with.
+type MemberRecord struct {
+	InternalID int64       `json:"INTERNAL_ID,omitempty"`
+	Records    []RecordKey `json:"RECORDS,omitempty"`
+}
+
+/*
+Record is a record of an entity, or the result of GetRecord.
+JSONData is the record as added; it is present with the SzEntityIncludeRecordJSONData flag.
+*/
+type Record struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+	InternalID int64  `json:"INTERNAL_ID,omitempty"`
+	MatchInfo
+	FirstSeenDt string          `json:"FIRST_SEEN_DT,omitempty"`
+	LastSeenDt  string          `json:"LAST_SEEN_DT,omitempty"`
+	JSONData    json.RawMessage `json:"JSON_DATA,omitempty"`
+	Features    []FeatureID     `json:"FEATURES,omitempty"`
+}
+
+// RecordKey identifies a record.
+type RecordKey struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+}
+
+/*
+RecordKeys is a list of records, such as the recordKeys parameter of GetVirtualEntityByRecordID.
+Its JSON() method builds the document, such as {"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]}.
+*/
+type RecordKeys []RecordKey
+
+// RecordSummary counts the records of an entity from a data source.
+type RecordSummary struct {
+	DataSource  string `json:"DATA_SOURCE"`
+	RecordCount int64  `json:"RECORD_COUNT"`
+}
+
+// RelatedEntity is an entity related to a resolved entity, with how they match.
+type RelatedEntity struct {
+	EntityID int64 `json:"ENTITY_ID"`
+	MatchInfo
+	IsAmbiguous   int64           `json:"IS_AMBIGUOUS,omitempty"`
+	IsDisclosed   int64           `json:"IS_DISCLOSED,omitempty"`
+	EntityName    string          `json:"ENTITY_NAME,omitempty"`
+	RecordSummary []RecordSummary `json:"RECORD_SUMMARY,omitempty"`
+	Records       []Record        `json:"RECORDS,omitempty"`
+}
+
+// ResolvedEntity is an entity: its name, its features by feature type, and its records.
+type ResolvedEntity struct {
+	EntityID      int64                `json:"ENTITY_ID"`
+	EntityName    string               `json:"ENTITY_NAME,omitempty"`
+	Features      map[string][]Feature `json:"FEATURES,omitempty"`
+	RecordSummary []RecordSummary      `json:"RECORD_SUMMARY,omitempty"`
+	Records       []Record             `json:"RECORDS,omitempty"`
+}
+
+// SearchMatchInfo describes how an entity matched a search, with the scores of the features compared, by feature type.
+type SearchMatchInfo struct {
+	MatchInfo
+	FeatureScores map[string][]FeatureScore `json:"FEATURE_SCORES,omitempty"`
+}
+
+// SearchResponse is returned by SearchByAttributes.
+type SearchResponse struct {
+	ResolvedEntities []SearchResult `json:"RESOLVED_ENTITIES,omitempty"`
+}
+
+// SearchResult is an entity found by a search.
+type SearchResult struct {
+	MatchInfo SearchMatchInfo `json:"MATCH_INFO"`
+	Entity    EntityResponse  `json:"ENTITY"`
+}
+
+// VirtualEntity is an entity as it was during resolution, identified by a virtual entity ID such as "V1-S2".
+type VirtualEntity struct {
+	VirtualEntityID string         `json:"VIRTUAL_ENTITY_ID"`
+	MemberRecords   []MemberRecord `json:"MEMBER_RECORDS,omitempty"`
+}
+
+// WhyMatchInfo describes why entities or records did, or did not, match.
+type WhyMatchInfo struct {
+	WhyKey         string                    `json:"WHY_KEY,omitempty"`
+	WhyErruleCode  string                    `json:"WHY_ERRULE_CODE,omitempty"`
+	MatchLevelCode string                    `json:"MATCH_LEVEL_CODE,omitempty"`
+	CandidateKeys  map[string][]CandidateKey `json:"CANDIDATE_KEYS,omitempty"`
+	FeatureScores  map[string][]FeatureScore `json:"FEATURE_SCORES,omitempty"`
+}
+
+// WhyResponse is returned by WhyEntities, WhyRecordInEntity and WhyRecords.
+type WhyResponse struct {
+	WhyResults []WhyResult      `json:"WHY_RESULTS"`
+	Entities   []EntityResponse `json:"ENTITIES"`
+}
+
+/*
+WhyResult compares two entities, or two records, or a record with the rest of its entity.
+The fields ending in 2 are empty when a record is compared with its entity.
+*/
+type WhyResult struct {
+	InternalID    int64        `json:"INTERNAL_ID,omitempty"`
+	EntityID      int64        `json:"ENTITY_ID"`
+	FocusRecords  []RecordKey  `json:"FOCUS_RECORDS,omitempty"`
+	InternalID2   int64        `json:"INTERNAL_ID_2,omitempty"`
+	EntityID2     int64        `json:"ENTITY_ID_2,omitempty"`
+	FocusRecords2 []RecordKey  `json:"FOCUS_RECORDS_2,omitempty"`
+	MatchInfo     WhyMatchInfo `json:"MATCH_INFO"`
+}
+
+// A malformed parameter document.  It matches ErrInvalidParameter and szerror.ErrSzBadInput.
+type parameterError struct {
+	message string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The layout of ConfigEntry.SysCreateDate.  Fractional seconds are optional.
+const createDateLayout = "2006-01-02 15:04:05.999999999"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidParameter is matched by the errors of DataSourceList, EntityIDs and RecordKeys methods.
+var ErrInvalidParameter = errors.New("invalid parameter")
@@ -0,0 +1,250 @@
+package szmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Unmarshal function decodes a JSON document returned by an SzEngine method into a model type.
+Unknown fields are ignored.
+
+Input
+  - document: The JSON document, such as the result of GetEntityByRecordID.
+
+Output
+  - The decoded document, such as an *EntityResponse.
+*/
+func Unmarshal[T any](document string) (*T, error) {
+	result := new(T)
+	if err := json.Unmarshal([]byte(document), result); err != nil {
+		return nil, fmt.Errorf("cannot decode %T: %w", *result, err)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// ConfigEntry methods
+// ----------------------------------------------------------------------------
+
+// The CreateTime method parses SysCreateDate.
+func (configEntry ConfigEntry) CreateTime() (time.Time, error) {
+	result, err := time.Parse(createDateLayout, configEntry.SysCreateDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse creation date of configuration %d: %w", configEntry.ConfigID, err)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// DataSourceList methods
+// ----------------------------------------------------------------------------
+
+/*
+The Add method appends data source codes to the list.
+
+Input
+  - dataSourceCodes: Data source codes, such as "CUSTOMERS".
+
+Output
+  - The longer list.
+*/
+func (dataSourceList DataSourceList) Add(dataSourceCodes ...string) DataSourceList {
+	return append(dataSourceList, dataSourceCodes...)
+}
+
+/*
+The JSON method validates the list and builds its document.
+
+Output
+  - A JSON document, such as {"DATA_SOURCES": ["CUSTOMERS"]}.
+*/
+func (dataSourceList DataSourceList) JSON() (string, error) {
+	if err := dataSourceList.Validate(); err != nil {
+		return "", err
+	}
+	return marshalParameter(struct {
+		DataSources []string `json:"DATA_SOURCES"`
+	}{DataSources: dataSourceList})
+}
+
+/*
+The Validate method checks that the list has at least one data source code,
+that none is empty or contains whitespace, and that none is listed twice.
+Errors match ErrInvalidParameter and szerror.ErrSzBadInput.
+*/
+func (dataSourceList DataSourceList) Validate() error {
+	if len(dataSourceList) == 0 {
+		return newParameterError("data source list is empty")
+	}
+	listed := map[string]bool{}
+	for index, dataSourceCode := range dataSourceList {
+		switch {
+		case len(dataSourceCode) == 0:
+			return newParameterError("data source code %d is empty", index+1)
+		case strings.ContainsFunc(dataSourceCode, unicode.IsSpace):
+			return newParameterError("data source code %q contains whitespace", dataSourceCode)
+		case listed[dataSourceCode]:
+			return newParameterError("data source code %q is listed more than once", dataSourceCode)
+		}
+		listed[dataSourceCode] = true
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// EntityIDs methods
+// ----------------------------------------------------------------------------
+
+/*
+The Add method appends entity IDs to the list.
+
+Input
+  - ids: The unique identifiers of entities.
+
+Output
+  - The longer list.
+*/
+func (entityIDs EntityIDs) Add(ids ...int64) EntityIDs {
+	return append(entityIDs, ids...)
+}
+
+/*
+The JSON method validates the list and builds its document.
+
+Output
+  - A JSON document, such as {"ENTITIES": [{"ENTITY_ID": 1}, {"ENTITY_ID": 2}]}.
+*/
+func (entityIDs EntityIDs) JSON() (string, error) {
+	if err := entityIDs.Validate(); err != nil {
+		return "", err
+	}
+	type entityID struct {
+		EntityID int64 `json:"ENTITY_ID"`
+	}
+	document := struct {
+		Entities []entityID `json:"ENTITIES"`
+	}{Entities: make([]entityID, 0, len(entityIDs))}
+	for _, id := range entityIDs {
+		document.Entities = append(document.Entities, entityID{EntityID: id})
+	}
+	return marshalParameter(document)
+}
+
+/*
+The Validate method checks that the list has at least one entity ID,
+that every entity ID is positive, and that none is listed twice.
+Errors match ErrInvalidParameter and szerror.ErrSzBadInput.
+*/
+func (entityIDs EntityIDs) Validate() error {
+	if len(entityIDs) == 0 {
+		return newParameterError("entity ID list is empty")
+	}
+	listed := map[int64]bool{}
+	for _, entityID := range entityIDs {
+		switch {
+		case entityID <= 0:
+			return newParameterError("entity ID %d is not positive", entityID)
+		case listed[entityID]:
+			return newParameterError("entity ID %d is listed more than once", entityID)
+		}
+		listed[entityID] = true
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// RecordKeys methods
+// ----------------------------------------------------------------------------
+
+/*
+The Add method appends a record to the list.
+
+Input
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+
+Output
+  - The longer list.
+*/
+func (recordKeys RecordKeys) Add(dataSourceCode string, recordID string) RecordKeys {
+	return append(recordKeys, RecordKey{DataSource: dataSourceCode, RecordID: recordID})
+}
+
+/*
+The JSON method validates the list and builds its document.
+
+Output
+  - A JSON document, such as {"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]}.
+*/
+func (recordKeys RecordKeys) JSON() (string, error) {
+	if err := recordKeys.Validate(); err != nil {
+		return "", err
+	}
+	return marshalParameter(struct {
+		Records []RecordKey `json:"RECORDS"`
+	}{Records: recordKeys})
+}
+
+/*
+The Validate method checks that the list has at least one record,
+that every record has a data source code and a record ID, and that no record is listed twice.
+Errors match ErrInvalidParameter and szerror.ErrSzBadInput.
+*/
+func (recordKeys RecordKeys) Validate() error {
+	if len(recordKeys) == 0 {
+		return newParameterError("record list is empty")
+	}
+	listed := map[RecordKey]bool{}
+	for index, recordKey := range recordKeys {
+		switch {
+		case len(recordKey.DataSource) == 0:
+			return newParameterError("record %d has an empty DATA_SOURCE", index+1)
+		case strings.ContainsFunc(recordKey.DataSource, unicode.IsSpace):
+			return newParameterError("record %d has a DATA_SOURCE %q that contains whitespace", index+1, recordKey.DataSource)
+		case len(recordKey.RecordID) == 0:
+			return newParameterError("record %d has an empty RECORD_ID", index+1)
+		case listed[recordKey]:
+			return newParameterError("record %s:%s is listed more than once", recordKey.DataSource, recordKey.RecordID)
+		}
+		listed[recordKey] = true
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// parameterError methods
+// ----------------------------------------------------------------------------
+
+func (err *parameterError) Error() string {
+	return err.message
+}
+
+func (err *parameterError) Unwrap() []error {
+	return []error{ErrInvalidParameter, szerror.ErrSzBadInput}
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func marshalParameter(document any) (string, error) {
+	result, err := json.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode parameter: %w", err)
+	}
+	return string(result), nil
+}
+
+func newParameterError(format string, arguments ...any) error {
+	return &parameterError{message: "invalid parameter: " + fmt.Sprintf(format, arguments...)}
+}
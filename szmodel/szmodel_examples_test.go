@@ -0,0 +1,27 @@
+package szmodel
+
+import (
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleRecordKeys_JSON() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szmodel/szmodel_examples_test.go
+	recordKeys := RecordKeys{}.Add("CUSTOMERS", "1001").Add("CUSTOMERS", "1002")
+	recordList, err := recordKeys.JSON()
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(recordList)
+	// Output: {"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1002"}]}
+}
+
+func ExampleRecordKeys_Validate() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szmodel/szmodel_examples_test.go
+	recordKeys := RecordKeys{}.Add("CUSTOMERS", "1001").Add("CUSTOMERS", "1001")
+	fmt.Println(recordKeys.Validate())
+	// Output: invalid parameter: record CUSTOMERS:1001 is listed more than once
+}
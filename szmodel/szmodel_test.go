@@ -0,0 +1,217 @@
+package szmodel
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run "go test ./szmodel -update" to rewrite the golden files after changing the models.
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestUnmarshal_configs(test *testing.T) {
+	actual := testGolden[ConfigsResponse](test, "get_configs")
+	require.Len(test, actual.Configs, 2)
+	assert.Equal(test, int64(831594791), actual.Configs[1].ConfigID)
+	assert.Equal(test, "Default configuration", actual.Configs[0].ConfigComments)
+}
+
+func TestUnmarshal_entity(test *testing.T) {
+	actual := testGolden[EntityResponse](test, "get_entity")
+	entity := actual.ResolvedEntity
+	assert.Equal(test, int64(1), entity.EntityID)
+	assert.Equal(test, []RecordSummary{{DataSource: "CUSTOMERS", RecordCount: 2}}, entity.RecordSummary)
+	assert.Len(test, entity.Features["NAME"][0].FeatDescValues, 2)
+	assert.Equal(test, MatchInfo{MatchLevelCode: "RESOLVED", MatchKey: "+NAME+PHONE", ErruleCode: "CNAME_CFF"}, entity.Records[1].MatchInfo)
+	assert.JSONEq(test, `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "PRIMARY_NAME_LAST": "Smith", "PRIMARY_NAME_FIRST": "Robert", "PHONE_NUMBER": "702-919-1300"}`, string(entity.Records[0].JSONData))
+	assert.Equal(test, int64(11), actual.RelatedEntities[0].MatchLevel)
+}
+
+func TestUnmarshal_findNetwork(test *testing.T) {
+	actual := testGolden[FindNetworkResponse](test, "find_network")
+	assert.Equal(test, []int64{1, 2}, actual.EntityPaths[0].Entities)
+	assert.Equal(test, "+EMAIL", actual.EntityNetworkLinks[0].MatchKey)
+	assert.Len(test, actual.Entities, 2)
+}
+
+func TestUnmarshal_findPath(test *testing.T) {
+	actual := testGolden[FindPathResponse](test, "find_path")
+	assert.Equal(test, EntityPath{StartEntityID: 1, EndEntityID: 3, Entities: []int64{1, 2, 3}}, actual.EntityPaths[0])
+	assert.Equal(test, int64(2), actual.Entities[0].RelatedEntities[0].EntityID)
+}
+
+func TestUnmarshal_howEntity(test *testing.T) {
+	actual := testGolden[HowResponse](test, "how_entity")
+	steps := actual.HowResults.ResolutionSteps
+	require.Len(test, steps, 2)
+	assert.Equal(test, "V1-S1", steps[0].ResultVirtualEntityID)
+	assert.Equal(test, "CNAME_CFF", steps[0].MatchInfo.ErruleCode)
+	assert.Equal(test, int64(92), steps[0].MatchInfo.FeatureScores["NAME"][0].Score)
+	assert.Len(test, actual.HowResults.FinalState.VirtualEntities[0].MemberRecords, 3)
+}
+
+func TestUnmarshal_record(test *testing.T) {
+	actual := testGolden[Record](test, "get_record")
+	assert.Equal(test, "1001", actual.RecordID)
+	assert.Contains(test, string(actual.JSONData), `"DATE_OF_BIRTH"`)
+}
+
+func TestUnmarshal_search(test *testing.T) {
+	actual := testGolden[SearchResponse](test, "search")
+	result := actual.ResolvedEntities[0]
+	assert.Equal(test, "+NAME+PHONE", result.MatchInfo.MatchKey)
+	assert.Equal(test, int64(100), result.MatchInfo.FeatureScores["PHONE"][0].Score)
+	assert.Equal(test, "Robert Smith", result.Entity.ResolvedEntity.EntityName)
+}
+
+func TestUnmarshal_whyEntities(test *testing.T) {
+	actual := testGolden[WhyResponse](test, "why_entities")
+	result := actual.WhyResults[0]
+	assert.Equal(test, int64(2), result.EntityID2)
+	assert.Equal(test, "+EMAIL-DOB", result.MatchInfo.WhyKey)
+	assert.Equal(test, "bsmith@WORK.COM", result.MatchInfo.CandidateKeys["EMAIL_KEY"][0].FeatDesc)
+	assert.Len(test, actual.Entities, 2)
+}
+
+func TestUnmarshal_whyRecordInEntity(test *testing.T) {
+	actual := testGolden[WhyResponse](test, "why_record_in_entity")
+	result := actual.WhyResults[0]
+	assert.Equal(test, []RecordKey{{DataSource: "CUSTOMERS", RecordID: "1002"}}, result.FocusRecords)
+	assert.Empty(test, result.FocusRecords2)
+}
+
+func TestUnmarshal_whyRecords(test *testing.T) {
+	actual := testGolden[WhyResponse](test, "why_records")
+	result := actual.WhyResults[0]
+	assert.Equal(test, "1002", result.FocusRecords2[0].RecordID)
+	assert.Equal(test, "RESOLVED", result.MatchInfo.MatchLevelCode)
+}
+
+func TestUnmarshal_badJSON(test *testing.T) {
+	_, err := Unmarshal[EntityResponse](`{"RESOLVED_ENTITY": [`)
+	require.ErrorContains(test, err, "szmodel.EntityResponse")
+	_, err = Unmarshal[EntityResponse](`{"RESOLVED_ENTITY": {"ENTITY_ID": "one"}}`)
+	require.Error(test, err)
+}
+
+func TestConfigEntry_CreateTime(test *testing.T) {
+	actual, err := ConfigEntry{SysCreateDate: "2024-06-29 17:01:23.456"}.CreateTime()
+	require.NoError(test, err)
+	assert.Equal(test, time.Date(2024, 6, 29, 17, 1, 23, 456000000, time.UTC), actual)
+	actual, err = ConfigEntry{SysCreateDate: "2024-07-01 09:30:00"}.CreateTime()
+	require.NoError(test, err)
+	assert.Equal(test, time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC), actual)
+	_, err = ConfigEntry{ConfigID: 1, SysCreateDate: "yesterday"}.CreateTime()
+	require.ErrorContains(test, err, "configuration 1")
+}
+
+func TestDataSourceList_JSON(test *testing.T) {
+	actual, err := DataSourceList{"CUSTOMERS"}.Add("REFERENCE", "WATCHLIST").JSON()
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES": ["CUSTOMERS", "REFERENCE", "WATCHLIST"]}`, actual)
+}
+
+func TestDataSourceList_Validate(test *testing.T) {
+	testCases := map[string]DataSourceList{
+		"empty":      {},
+		"emptyCode":  {"CUSTOMERS", ""},
+		"whitespace": {"MY CUSTOMERS"},
+		"duplicate":  {"CUSTOMERS", "CUSTOMERS"},
+	}
+	for name, dataSourceList := range testCases {
+		test.Run(name, func(test *testing.T) {
+			err := dataSourceList.Validate()
+			require.ErrorIs(test, err, ErrInvalidParameter)
+			require.ErrorIs(test, err, szerror.ErrSzBadInput)
+			_, err = dataSourceList.JSON()
+			require.ErrorIs(test, err, ErrInvalidParameter)
+		})
+	}
+}
+
+func TestEntityIDs_JSON(test *testing.T) {
+	actual, err := EntityIDs{1}.Add(2, 3).JSON()
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"ENTITIES": [{"ENTITY_ID": 1}, {"ENTITY_ID": 2}, {"ENTITY_ID": 3}]}`, actual)
+}
+
+func TestEntityIDs_Validate(test *testing.T) {
+	testCases := map[string]EntityIDs{
+		"empty":     nil,
+		"zero":      {1, 0},
+		"negative":  {-1},
+		"duplicate": {1, 2, 1},
+	}
+	for name, entityIDs := range testCases {
+		test.Run(name, func(test *testing.T) {
+			err := entityIDs.Validate()
+			require.ErrorIs(test, err, ErrInvalidParameter)
+			require.ErrorIs(test, err, szerror.ErrSzBadInput)
+		})
+	}
+}
+
+func TestRecordKeys_JSON(test *testing.T) {
+	actual, err := RecordKeys{}.Add("CUSTOMERS", "1001").Add("WATCHLIST", "1007").JSON()
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}, {"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "1007"}]}`, actual)
+}
+
+func TestRecordKeys_Validate(test *testing.T) {
+	testCases := map[string]RecordKeys{
+		"empty":           {},
+		"emptyDataSource": RecordKeys{}.Add("", "1001"),
+		"whitespace":      RecordKeys{}.Add("MY CUSTOMERS", "1001"),
+		"emptyRecordID":   RecordKeys{}.Add("CUSTOMERS", "1001").Add("CUSTOMERS", ""),
+		"duplicate":       RecordKeys{}.Add("CUSTOMERS", "1001").Add("CUSTOMERS", "1001"),
+	}
+	for name, recordKeys := range testCases {
+		test.Run(name, func(test *testing.T) {
+			err := recordKeys.Validate()
+			require.ErrorIs(test, err, ErrInvalidParameter)
+			require.ErrorIs(test, err, szerror.ErrSzBadInput)
+		})
+	}
+}
+
+func TestRecordKeys_Validate_message(test *testing.T) {
+	err := RecordKeys{}.Add("CUSTOMERS", "1001").Add("CUSTOMERS", "").Validate()
+	require.EqualError(test, err, "invalid parameter: record 2 has an empty RECORD_ID")
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Decode testdata/<name>.json and compare the model, encoded again, with testdata/<name>.golden.json.
+The golden file shows which fields of the response the model keeps.
+*/
+func testGolden[T any](test *testing.T, name string) *T {
+	test.Helper()
+	document, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	require.NoError(test, err)
+	result, err := Unmarshal[T](string(document))
+	require.NoError(test, err)
+	actual, err := json.MarshalIndent(result, "", "  ")
+	require.NoError(test, err)
+	goldenFile := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		require.NoError(test, os.WriteFile(goldenFile, append(actual, '\n'), 0o600))
+	}
+	expected, err := os.ReadFile(goldenFile)
+	require.NoError(test, err)
+	assert.JSONEq(test, string(expected), string(actual))
+	return result
+}
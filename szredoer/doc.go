@@ -0,0 +1,19 @@
+/*
+The szredoer package processes the Senzing redo queue with SzEngine.CountRedoRecords(),
+SzEngine.GetRedoRecord() and SzEngine.ProcessRedoRecord().
+
+A Redoer polls the number of redo records.
+When there are some, a pool of workers each takes records with GetRedoRecord and processes them
+with ProcessRedoRecord until the queue is empty.
+When there are none, the Redoer waits before polling again, doubling the wait up to a maximum.
+With WithSink, the "with info" result of each record is sent to a Sink, such as one writing JSON lines.
+
+Processing that fails with a retryable error, as classified by szretry.IsRetryable(), is retried with backoff.
+A record that fails with another error is counted as failed, and written as a Failure with WithFailures;
+the Redoer goes on with the next record.
+Polling or taking a record that fails with an error that is not retryable stops the Redoer.
+When the context is cancelled, records already taken from the queue are still processed:
+a record waiting to be retried is retried at once, and for at most the maximum poll interval more,
+before it is reported as failed.  Then the Redoer stops.
+*/
+package szredoer
@@ -0,0 +1,44 @@
+package szredoer
+
+import (
+	"context"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Failure is a line of the failures writer: a redo record that was taken from the queue but not processed.
+type Failure struct {
+	Error      string `json:"error"`      // Why the record was not processed.
+	RedoRecord string `json:"redoRecord"` // The redo record, as returned by GetRedoRecord.
+}
+
+// Sink receives the "with info" result of each redo record.  Send is called from several workers at once.
+type Sink interface {
+	Send(ctx context.Context, info string) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, info string) error
+
+// Stats counts the work of a Redoer.
+type Stats struct {
+	Failed    int64 // Redo records taken from the queue but not processed.
+	Processed int64 // Redo records processed.
+	Retried   int64 // ProcessRedoRecord calls retried after a retryable error.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultMaxPollInterval is the longest wait between polls of an empty queue, unless changed with WithPollInterval().
+const DefaultMaxPollInterval = 30 * time.Second
+
+// DefaultPollInterval is the first wait between polls of an empty queue, unless changed with WithPollInterval().
+const DefaultPollInterval = time.Second
+
+// DefaultWorkers is the number of concurrent GetRedoRecord and ProcessRedoRecord workers, unless changed with WithWorkers().
+const DefaultWorkers = 4
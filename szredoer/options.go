@@ -0,0 +1,92 @@
+package szredoer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Option configures a Redoer created by New.
+type Option func(*Redoer) error
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The WithFailures function writes redo records that were taken from the queue but not processed
+to the writer as JSON lines of Failure.
+Without it, such records are only counted.
+
+Input
+  - writer: The failures file.
+*/
+func WithFailures(writer io.Writer) Option {
+	return func(redoer *Redoer) error {
+		if writer == nil {
+			return errors.New("failures writer cannot be nil")
+		}
+		redoer.failures = &writerSink{writer: writer}
+		return nil
+	}
+}
+
+/*
+The WithPollInterval function sets how long the Redoer waits before polling an empty queue again.
+The wait starts at initial and doubles after each empty poll, up to maximum.
+
+Input
+  - initial: The first wait.
+  - maximum: The longest wait, at least initial.
+*/
+func WithPollInterval(initial time.Duration, maximum time.Duration) Option {
+	return func(redoer *Redoer) error {
+		if initial <= 0 || maximum < initial {
+			return fmt.Errorf("poll interval must be positive and at most %s, not %s", maximum, initial)
+		}
+		redoer.pollInterval = initial
+		redoer.maxPollInterval = maximum
+		return nil
+	}
+}
+
+/*
+The WithSink function requests the entities affected by each redo record and sends them to a Sink.
+
+Input
+  - sink: Where the "with info" results are sent.
+*/
+func WithSink(sink Sink) Option {
+	return func(redoer *Redoer) error {
+		if sink == nil {
+			return errors.New("sink cannot be nil")
+		}
+		redoer.flags |= senzing.SzWithInfo
+		redoer.sink = sink
+		return nil
+	}
+}
+
+/*
+The WithWorkers function sets the number of concurrent workers.
+The default is DefaultWorkers.
+
+Input
+  - workers: The number of workers, at least 1.
+*/
+func WithWorkers(workers int) Option {
+	return func(redoer *Redoer) error {
+		if workers < 1 {
+			return fmt.Errorf("workers must be at least 1, not %d", workers)
+		}
+		redoer.workers = workers
+		return nil
+	}
+}
@@ -0,0 +1,293 @@
+package szredoer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szretry"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// Redoer processes the redo queue of a Senzing engine.
+type Redoer struct {
+	failures        Sink
+	flags           int64
+	maxPollInterval time.Duration
+	pollInterval    time.Duration
+	sink            Sink
+	szEngine        senzing.SzEngine
+	workers         int
+}
+
+// The counters of one call to Run or Drain.
+type counters struct {
+	failed    atomic.Int64
+	processed atomic.Int64
+	retried   atomic.Int64
+}
+
+// A Sink that writes JSON lines.
+type writerSink struct {
+	lock   sync.Mutex
+	writer io.Writer
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function creates a Redoer.
+
+Input
+  - szEngine: The engine whose redo queue is processed.
+  - options: Options that set the workers, the poll interval and a Sink.
+*/
+func New(szEngine senzing.SzEngine, options ...Option) (*Redoer, error) {
+	if szEngine == nil {
+		return nil, errors.New("szEngine cannot be nil")
+	}
+	result := &Redoer{
+		maxPollInterval: DefaultMaxPollInterval,
+		pollInterval:    DefaultPollInterval,
+		szEngine:        szEngine,
+		workers:         DefaultWorkers,
+	}
+	for _, option := range options {
+		if err := option(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+/*
+The NewWriterSink function creates a Sink that writes each result to the writer as a line.
+
+Input
+  - writer: Where the results are written.
+*/
+func NewWriterSink(writer io.Writer) Sink {
+	return &writerSink{writer: writer}
+}
+
+// ----------------------------------------------------------------------------
+// Redoer methods
+// ----------------------------------------------------------------------------
+
+/*
+The Drain method processes redo records until the queue is empty.
+Polls that fail with a retryable error are retried after a wait, as set by WithPollInterval().
+
+Input
+  - ctx: Cancel it to stop gracefully.
+
+Output
+  - What was done.
+  - An error if polling or taking a record failed with an error that is not retryable, or the context was cancelled.
+*/
+func (redoer *Redoer) Drain(ctx context.Context) (Stats, error) {
+	return redoer.run(ctx, true)
+}
+
+/*
+The Run method processes redo records as they arrive, until the context is cancelled.
+When the queue is empty, or a poll fails with a retryable error, it waits before polling again,
+as set by WithPollInterval().
+
+Input
+  - ctx: Cancel it to stop gracefully.
+
+Output
+  - What was done.
+  - An error if polling or taking a record failed with an error that is not retryable.  Cancellation is not an error.
+*/
+func (redoer *Redoer) Run(ctx context.Context) (Stats, error) {
+	return redoer.run(ctx, false)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+Take and process redo records with the workers until the queue is empty, a worker fails, or the context is cancelled.
+Returns the first error.
+*/
+func (redoer *Redoer) drain(ctx context.Context, counters *counters) error {
+	drainCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		errOnce sync.Once
+		result  error
+		workers sync.WaitGroup
+	)
+	for range redoer.workers {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			if err := redoer.work(drainCtx, counters); err != nil {
+				errOnce.Do(func() { result = err })
+				cancel()
+			}
+		}()
+	}
+	workers.Wait()
+	return result
+}
+
+/*
+Report a redo record that was taken from the queue but not processed.
+An error writing the report is returned; the record's own error is not.
+*/
+func (redoer *Redoer) fail(ctx context.Context, redoRecord string, err error, counters *counters) error {
+	counters.failed.Add(1)
+	if redoer.failures == nil {
+		return nil
+	}
+	line, marshalErr := json.Marshal(Failure{Error: err.Error(), RedoRecord: redoRecord})
+	if marshalErr != nil {
+		return marshalErr
+	}
+	return redoer.failures.Send(ctx, string(line))
+}
+
+/*
+Process a redo record, retrying retryable errors with backoff.
+The record has been taken from the queue, so the calls are not cancelled with the context.
+When the context is cancelled during a wait, the record is retried at once and then for at most
+the maximum poll interval more, after which it is reported as failed.
+A record that fails with an error that is not retryable is reported as failed, and the workers go on.
+*/
+func (redoer *Redoer) process(ctx context.Context, redoRecord string, counters *counters) error {
+	callCtx := context.WithoutCancel(ctx)
+	waitCtx := ctx
+	interval := redoer.pollInterval
+	for {
+		info, err := redoer.szEngine.ProcessRedoRecord(callCtx, redoRecord, redoer.flags)
+		if err == nil {
+			counters.processed.Add(1)
+			if redoer.sink != nil {
+				return redoer.sink.Send(callCtx, info)
+			}
+			return nil
+		}
+		if !szretry.IsRetryable(err) {
+			return redoer.fail(callCtx, redoRecord, err, counters)
+		}
+		counters.retried.Add(1)
+		if sleep(waitCtx, interval) != nil {
+			if waitCtx != ctx {
+				return redoer.fail(callCtx, redoRecord, err, counters)
+			}
+			graceCtx, cancel := context.WithTimeout(callCtx, redoer.maxPollInterval)
+			defer cancel()
+			waitCtx = graceCtx
+			continue
+		}
+		interval = min(2*interval, redoer.maxPollInterval)
+	}
+}
+
+/*
+Poll the queue and drain it until the context is cancelled, or, for Drain, until the queue is empty.
+On cancellation, Drain returns the context's error and Run returns nil.
+*/
+func (redoer *Redoer) run(ctx context.Context, untilEmpty bool) (Stats, error) {
+	counters := &counters{}
+	stopped := func() (Stats, error) {
+		if untilEmpty {
+			return counters.stats(), ctx.Err()
+		}
+		return counters.stats(), nil
+	}
+	interval := redoer.pollInterval
+	for {
+		count, err := redoer.szEngine.CountRedoRecords(ctx)
+		if err == nil && count > 0 {
+			err = redoer.drain(ctx, counters)
+		}
+		switch {
+		case ctx.Err() != nil:
+			return stopped()
+		case err != nil && !szretry.IsRetryable(err):
+			return counters.stats(), err
+		case err == nil && count > 0:
+			interval = redoer.pollInterval
+			continue
+		case err == nil && untilEmpty:
+			return counters.stats(), nil
+		}
+		if sleep(ctx, interval) != nil {
+			return stopped()
+		}
+		interval = min(2*interval, redoer.maxPollInterval)
+	}
+}
+
+// Take and process redo records until the queue is empty, a call fails, or the context is cancelled.
+func (redoer *Redoer) work(ctx context.Context, counters *counters) error {
+	for ctx.Err() == nil {
+		redoRecord, err := redoer.szEngine.GetRedoRecord(context.WithoutCancel(ctx))
+		if err != nil {
+			return err
+		}
+		if len(redoRecord) == 0 {
+			return nil
+		}
+		if err := redoer.process(ctx, redoRecord, counters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// SinkFunc methods
+// ----------------------------------------------------------------------------
+
+// The Send method calls the function.
+func (sink SinkFunc) Send(ctx context.Context, info string) error {
+	return sink(ctx, info)
+}
+
+// ----------------------------------------------------------------------------
+// counters and writerSink methods
+// ----------------------------------------------------------------------------
+
+func (counters *counters) stats() Stats {
+	return Stats{
+		Failed:    counters.failed.Load(),
+		Processed: counters.processed.Load(),
+		Retried:   counters.retried.Load(),
+	}
+}
+
+func (sink *writerSink) Send(ctx context.Context, info string) error {
+	_ = ctx
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+	_, err := io.WriteString(sink.writer, strings.TrimSpace(info)+"\n")
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
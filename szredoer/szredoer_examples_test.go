@@ -0,0 +1,47 @@
+package szredoer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleRedoer_Drain() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szredoer/szredoer_examples_test.go
+	ctx := context.TODO()
+	server, err := fakeserver.New(fakeserver.WithDataSources("CUSTOMERS"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer server.Close()
+	grpcConnection, err := server.NewClient()
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = grpcConnection.Close() }()
+	szEngine := &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+	_, _ = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"NAME_FULL": "Robert Smith", "PHONE_NUMBER": "702-919-1300"}`, senzing.SzNoFlags)
+	_, _ = szEngine.AddRecord(ctx, "CUSTOMERS", "1002", `{"NAME_FULL": "Bob Smith", "PHONE_NUMBER": "702-919-1300"}`, senzing.SzNoFlags)
+	_, _ = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	redoer, err := New(szEngine, WithSink(NewWriterSink(os.Stdout)))
+	if err != nil {
+		fmt.Println(err)
+	}
+	stats, err := redoer.Drain(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(stats.Processed)
+	// Output:
+	// {"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[],"INTERESTING_ENTITIES":{"ENTITIES":[]}}
+	// 1
+}
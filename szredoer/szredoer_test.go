@@ -0,0 +1,220 @@
+package szredoer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	szenginepb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	getRedoRecordMethod     = "/szengine.SzEngine/GetRedoRecord"
+	processRedoRecordMethod = "/szengine.SzEngine/ProcessRedoRecord"
+	testPollInterval        = time.Millisecond
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRedoer_Drain(test *testing.T) {
+	ctx := context.TODO()
+	_, szEngine := getTestServerEngine(test)
+	addRedoRecords(ctx, test, szEngine, 5)
+	infos := &bytes.Buffer{}
+	redoer, err := New(szEngine, WithWorkers(3), WithSink(NewWriterSink(infos)))
+	require.NoError(test, err)
+	stats, err := redoer.Drain(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, Stats{Processed: 5}, stats)
+	assert.Len(test, strings.Split(strings.TrimSpace(infos.String()), "\n"), 5)
+	assert.Contains(test, infos.String(), `"AFFECTED_ENTITIES"`)
+	count, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Zero(test, count)
+}
+
+func TestRedoer_Drain_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, szEngine := getTestServerEngine(test)
+	redoer, err := New(szEngine)
+	require.NoError(test, err)
+	_, err = redoer.Drain(ctx)
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestRedoer_Drain_error(test *testing.T) {
+	ctx := context.TODO()
+	server, szEngine := getTestServerEngine(test)
+	addRedoRecords(ctx, test, szEngine, 1)
+	server.SetFaults(fakeserver.MalformedError(getRedoRecordMethod, codes.PermissionDenied, "denied"))
+	redoer, err := New(szEngine)
+	require.NoError(test, err)
+	_, err = redoer.Drain(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzUnrecoverable)
+}
+
+func TestRedoer_Drain_retry(test *testing.T) {
+	ctx := context.TODO()
+	server, szEngine := getTestServerEngine(test)
+	addRedoRecords(ctx, test, szEngine, 2)
+	server.SetFaults(fakeserver.Unavailable(processRedoRecordMethod, 1, 2))
+	redoer, err := New(szEngine, WithWorkers(1), WithPollInterval(testPollInterval, testPollInterval))
+	require.NoError(test, err)
+	stats, err := redoer.Drain(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, Stats{Processed: 2, Retried: 2}, stats)
+}
+
+func TestRedoer_Drain_cancelDuringRetry(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	server, szEngine := getTestServerEngine(test)
+	addRedoRecords(ctx, test, szEngine, 1)
+	server.SetFaults(fakeserver.Unavailable(processRedoRecordMethod, 1, 1))
+	redoer, err := New(&cancellingEngine{Szengine: szEngine, cancel: cancel}, WithWorkers(1), WithPollInterval(time.Hour, time.Hour))
+	require.NoError(test, err)
+	stats, err := redoer.Drain(ctx)
+	require.ErrorIs(test, err, context.Canceled)
+	assert.Equal(test, Stats{Processed: 1, Retried: 1}, stats)
+}
+
+func TestRedoer_Drain_cancelDuringRetry_failed(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	server, szEngine := getTestServerEngine(test)
+	addRedoRecords(ctx, test, szEngine, 1)
+	server.SetFaults(fakeserver.Unavailable(processRedoRecordMethod, 1, 0))
+	failures := &bytes.Buffer{}
+	redoer, err := New(&cancellingEngine{Szengine: szEngine, cancel: cancel}, WithWorkers(1), WithPollInterval(testPollInterval, 5*testPollInterval), WithFailures(failures))
+	require.NoError(test, err)
+	stats, err := redoer.Drain(ctx)
+	require.ErrorIs(test, err, context.Canceled)
+	assert.Equal(test, int64(1), stats.Failed)
+	assert.Zero(test, stats.Processed)
+	failure := Failure{}
+	require.NoError(test, json.Unmarshal(failures.Bytes(), &failure))
+	assert.Contains(test, failure.Error, "injected unavailable")
+	assert.NotEmpty(test, failure.RedoRecord)
+}
+
+func TestRedoer_Drain_failed(test *testing.T) {
+	ctx := context.TODO()
+	server, szEngine := getTestServerEngine(test)
+	addRedoRecords(ctx, test, szEngine, 2)
+	server.SetFaults(fakeserver.Fault{Method: processRedoRecordMethod, From: 1, Count: 1, Err: status.Error(codes.PermissionDenied, "denied")})
+	failures := &bytes.Buffer{}
+	redoer, err := New(szEngine, WithWorkers(1), WithFailures(failures))
+	require.NoError(test, err)
+	stats, err := redoer.Drain(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, Stats{Failed: 1, Processed: 1}, stats)
+	assert.Equal(test, 1, strings.Count(failures.String(), "\n"))
+	assert.Contains(test, failures.String(), `"error":`)
+	count, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Zero(test, count)
+}
+
+func TestRedoer_Run(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	_, szEngine := getTestServerEngine(test)
+	infos := []string{}
+	lock := sync.Mutex{}
+	sink := SinkFunc(func(ctx context.Context, info string) error {
+		_ = ctx
+		lock.Lock()
+		defer lock.Unlock()
+		infos = append(infos, info)
+		return nil
+	})
+	redoer, err := New(szEngine, WithSink(sink), WithPollInterval(testPollInterval, 5*testPollInterval))
+	require.NoError(test, err)
+	type result struct {
+		err   error
+		stats Stats
+	}
+	done := make(chan result)
+	go func() {
+		stats, err := redoer.Run(ctx)
+		done <- result{err: err, stats: stats}
+	}()
+	time.Sleep(20 * testPollInterval)
+	addRedoRecords(ctx, test, szEngine, 2)
+	assert.Eventually(test, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(infos) == 2
+	}, time.Second, testPollInterval)
+	cancel()
+	actual := <-done
+	require.NoError(test, actual.err)
+	assert.Equal(test, Stats{Processed: 2}, actual.stats)
+}
+
+func TestNew_badOptions(test *testing.T) {
+	szEngine := &szengine.Szengine{}
+	for _, option := range []Option{
+		WithFailures(nil),
+		WithPollInterval(0, time.Second),
+		WithPollInterval(time.Second, time.Millisecond),
+		WithSink(nil),
+		WithWorkers(0),
+	} {
+		_, err := New(szEngine, option)
+		require.Error(test, err)
+	}
+	_, err := New(nil)
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// An engine that cancels the context of a Redoer when it is asked to process a redo record.
+type cancellingEngine struct {
+	*szengine.Szengine
+	cancel context.CancelFunc
+}
+
+func (szEngine *cancellingEngine) ProcessRedoRecord(ctx context.Context, redoRecord string, flags int64) (string, error) {
+	szEngine.cancel()
+	return szEngine.Szengine.ProcessRedoRecord(ctx, redoRecord, flags)
+}
+
+// Add pairs of records that resolve, then delete one of each pair, leaving a redo record for each.
+func addRedoRecords(ctx context.Context, test *testing.T, szEngine senzing.SzEngine, count int) {
+	test.Helper()
+	for index := range count {
+		for _, recordID := range []string{fmt.Sprintf("%dA", index), fmt.Sprintf("%dB", index)} {
+			recordDefinition := fmt.Sprintf(`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "%s", "NAME_FULL": "Person %d", "PHONE_NUMBER": "702-555-%04d"}`, recordID, index, index)
+			_, err := szEngine.AddRecord(ctx, "CUSTOMERS", recordID, recordDefinition, senzing.SzNoFlags)
+			require.NoError(test, err)
+		}
+		_, err := szEngine.DeleteRecord(ctx, "CUSTOMERS", fmt.Sprintf("%dA", index), senzing.SzNoFlags)
+		require.NoError(test, err)
+	}
+}
+
+func getTestServerEngine(test *testing.T) (*fakeserver.Server, *szengine.Szengine) {
+	test.Helper()
+	server, grpcConnection := fakeserver.NewTestClient(test, fakeserver.WithDataSources("CUSTOMERS"))
+	return server, &szengine.Szengine{GrpcClient: szenginepb.NewSzEngineClient(grpcConnection)}
+}
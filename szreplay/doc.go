@@ -0,0 +1,15 @@
+/*
+The szreplay package records the gRPC traffic of the Senzing clients and serves it back.
+
+A Recorder provides client interceptors that write every call of the szconfig, szconfigmanager,
+szdiagnostic, szengine and szproduct services to a capture file, one JSON Entry per line:
+the request, each response, the error status and the timing.
+The interceptors are usually passed to szabstractfactory.WithUnaryInterceptors()
+and szabstractfactory.WithStreamInterceptors().
+
+A Replayer serves a capture back from a grpc.Server, without a Senzing server or its data store.
+Each call is answered by the first unused Entry of the same method with an equal request,
+so a session replays the same results, in the same order, every time.
+A call with no matching Entry fails with codes.FailedPrecondition.
+*/
+package szreplay
@@ -0,0 +1,40 @@
+package szreplay
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+Entry is a recorded call: one line of a capture file.
+Messages are in the protobuf JSON mapping, so a capture can be read and edited by hand.
+*/
+type Entry struct {
+	Sequence  int64             `json:"sequence"`         // The order in which calls started, counting from 1.
+	Method    string            `json:"method"`           // The full gRPC method name, such as "/szengine.SzEngine/AddRecord".
+	StartTime time.Time         `json:"startTime"`        // When the call started.
+	Duration  time.Duration     `json:"duration"`         // Nanoseconds until the call, or the stream, ended.
+	Request   json.RawMessage   `json:"request"`          // The request message.
+	Responses []json.RawMessage `json:"responses"`        // The response messages: one for a unary call, one per fragment for a stream.
+	Status    json.RawMessage   `json:"status,omitempty"` // The google.rpc.Status of a failed call, including its details.
+}
+
+// ReplayOption configures a Replayer created by NewReplayer.
+type ReplayOption func(*Replayer)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Services lists the gRPC services recorded by a Recorder.  Calls of other services are not recorded.
+var Services = []string{
+	"szconfig.SzConfig",
+	"szconfigmanager.SzConfigManager",
+	"szdiagnostic.SzDiagnostic",
+	"szengine.SzEngine",
+	"szproduct.SzProduct",
+}
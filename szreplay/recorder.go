@@ -0,0 +1,243 @@
+package szreplay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Recorder writes the calls made through its interceptors to a capture file.
+type Recorder struct {
+	err      error
+	mutex    sync.Mutex
+	now      func() time.Time
+	sequence int64
+	writer   io.Writer
+}
+
+type recordedClientStream struct {
+	grpc.ClientStream
+	entry     *Entry
+	mutex     sync.Mutex // Guards entry, as the context of the call may end the stream while a message is received.
+	once      sync.Once
+	recorder  *Recorder
+	startTime time.Time
+	stop      func() bool // Stops finishing the entry when the context is done.  Called by RecvMsg when the stream ends.
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewRecorder function creates a Recorder.
+Entries are written to the writer as each call ends, so concurrent calls may be written out of Sequence order.
+
+Input
+  - writer: The capture file.  The caller closes it after the last call has ended.
+*/
+func NewRecorder(writer io.Writer) *Recorder {
+	return &Recorder{
+		now:    time.Now,
+		writer: writer,
+	}
+}
+
+/*
+The ReadEntries function reads a capture file written by a Recorder.
+
+Input
+  - reader: The capture file.
+
+Output
+  - The entries, in the order they were written.
+*/
+func ReadEntries(reader io.Reader) ([]Entry, error) {
+	result := []Entry{}
+	decoder := json.NewDecoder(reader)
+	for {
+		entry := Entry{}
+		err := decoder.Decode(&entry)
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("szreplay: entry %d: %w", len(result)+1, err)
+		}
+		result = append(result, entry)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Recorder methods
+// ----------------------------------------------------------------------------
+
+// The Err method returns the first error met while writing the capture file, if any.
+// Calls are not affected by errors writing the capture.
+func (recorder *Recorder) Err() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.err
+}
+
+// ----------------------------------------------------------------------------
+// Interceptors
+// ----------------------------------------------------------------------------
+
+/*
+The StreamClientInterceptor method returns an interceptor that records streaming calls, such as StreamExportJsonEntityReport.
+A stream is written when it ends: after its last message, an error, or when the context of the call is done,
+as when the consumer of an export iterator cancels or breaks out of its loop.
+A stream is then written with the messages received so far and a codes.Canceled or codes.DeadlineExceeded status.
+*/
+func (recorder *Recorder) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, streamer grpc.Streamer, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !isRecorded(method) {
+			return streamer(ctx, streamDesc, grpcConnection, method, options...)
+		}
+		entry, startTime := recorder.start(method)
+		clientStream, err := streamer(ctx, streamDesc, grpcConnection, method, options...)
+		if err != nil {
+			recorder.finish(entry, startTime, err)
+			return nil, err
+		}
+		result := &recordedClientStream{
+			ClientStream: clientStream,
+			entry:        entry,
+			recorder:     recorder,
+			startTime:    startTime,
+		}
+		result.stop = context.AfterFunc(ctx, func() { result.finish(status.FromContextError(ctx.Err()).Err()) })
+		return result, nil
+	}
+}
+
+// The UnaryClientInterceptor method returns an interceptor that records unary calls.
+func (recorder *Recorder) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+		if !isRecorded(method) {
+			return invoker(ctx, method, request, reply, grpcConnection, options...)
+		}
+		entry, startTime := recorder.start(method)
+		entry.Request = marshalMessage(request)
+		err := invoker(ctx, method, request, reply, grpcConnection, options...)
+		if err == nil {
+			entry.Responses = append(entry.Responses, marshalMessage(reply))
+		}
+		recorder.finish(entry, startTime, err)
+		return err
+	}
+}
+
+// ----------------------------------------------------------------------------
+// grpc.ClientStream interface methods
+// ----------------------------------------------------------------------------
+
+func (stream *recordedClientStream) RecvMsg(message interface{}) error {
+	err := stream.ClientStream.RecvMsg(message)
+	switch {
+	case err == nil:
+		stream.mutex.Lock()
+		stream.entry.Responses = append(stream.entry.Responses, marshalMessage(message))
+		stream.mutex.Unlock()
+	case errors.Is(err, io.EOF):
+		stream.stop()
+		stream.finish(nil)
+	default:
+		stream.stop()
+		stream.finish(err)
+	}
+	return err
+}
+
+func (stream *recordedClientStream) SendMsg(message interface{}) error {
+	stream.mutex.Lock()
+	if stream.entry.Request == nil {
+		stream.entry.Request = marshalMessage(message)
+	}
+	stream.mutex.Unlock()
+	return stream.ClientStream.SendMsg(message)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Complete an entry and write it as a line of the capture file.
+func (recorder *Recorder) finish(entry *Entry, startTime time.Time, err error) {
+	entry.Duration = recorder.now().Sub(startTime)
+	if err != nil {
+		entry.Status = marshalMessage(status.Convert(err).Proto())
+	}
+	line, marshalErr := json.Marshal(entry)
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if recorder.err != nil {
+		return
+	}
+	if marshalErr != nil {
+		recorder.err = fmt.Errorf("szreplay: entry %d: %w", entry.Sequence, marshalErr)
+		return
+	}
+	if _, err := recorder.writer.Write(append(line, '\n')); err != nil {
+		recorder.err = fmt.Errorf("szreplay: entry %d: %w", entry.Sequence, err)
+	}
+}
+
+// Start an entry for a call.
+func (recorder *Recorder) start(method string) (*Entry, time.Time) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.sequence++
+	startTime := recorder.now()
+	result := &Entry{
+		Sequence:  recorder.sequence,
+		Method:    method,
+		StartTime: startTime,
+		Responses: []json.RawMessage{},
+	}
+	return result, startTime
+}
+
+// Write the entry once, when the stream ends or its context is done, whichever is first.
+func (stream *recordedClientStream) finish(err error) {
+	stream.once.Do(func() {
+		stream.mutex.Lock()
+		defer stream.mutex.Unlock()
+		stream.recorder.finish(stream.entry, stream.startTime, err)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// Report whether a full gRPC method name, such as "/szengine.SzEngine/AddRecord", belongs to one of Services.
+func isRecorded(method string) bool {
+	service, _, found := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return found && slices.Contains(Services, service)
+}
+
+// Marshal a message in the protobuf JSON mapping.  Values that are not protobuf messages are recorded as null.
+func marshalMessage(message interface{}) json.RawMessage {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return json.RawMessage("null")
+	}
+	result, err := protojson.Marshal(protoMessage)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return result
+}
@@ -0,0 +1,206 @@
+package szreplay
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/senzing-garage/sz-sdk-proto/go/szconfig"        // Register the messages of the recorded services.
+	_ "github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager" // Register the messages of the recorded services.
+	_ "github.com/senzing-garage/sz-sdk-proto/go/szdiagnostic"    // Register the messages of the recorded services.
+	_ "github.com/senzing-garage/sz-sdk-proto/go/szengine"        // Register the messages of the recorded services.
+	_ "github.com/senzing-garage/sz-sdk-proto/go/szproduct"       // Register the messages of the recorded services.
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"      // Register the details of recorded statuses.
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Replayer serves the entries of a capture file.
+type Replayer struct {
+	entries []Entry
+	latency bool
+	mutex   sync.Mutex
+	used    []bool
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewReplayer function creates a Replayer.
+
+Input
+  - entries: The recorded calls, usually from ReadEntries().
+  - options: Options that change how calls are served.
+*/
+func NewReplayer(entries []Entry, options ...ReplayOption) *Replayer {
+	result := &Replayer{
+		entries: entries,
+		used:    make([]bool, len(entries)),
+	}
+	for _, option := range options {
+		option(result)
+	}
+	return result
+}
+
+/*
+The WithRecordedLatency function makes a Replayer wait for the recorded Duration of each call before answering it,
+to reproduce timing-dependent behavior such as deadlines.
+*/
+func WithRecordedLatency() ReplayOption {
+	return func(replayer *Replayer) {
+		replayer.latency = true
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Replayer methods
+// ----------------------------------------------------------------------------
+
+/*
+The NewServer method creates a grpc.Server that answers calls of every method from the entries of the Replayer.
+The caller serves it on a listener, such as a net.Listener or a google.golang.org/grpc/test/bufconn listener.
+
+Input
+  - serverOptions: Options passed to grpc.NewServer().
+*/
+func (replayer *Replayer) NewServer(serverOptions ...grpc.ServerOption) *grpc.Server {
+	return grpc.NewServer(append(serverOptions, grpc.UnknownServiceHandler(replayer.handle))...)
+}
+
+// The Unused method returns the entries that have not answered a call, in order.
+func (replayer *Replayer) Unused() []Entry {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+	result := []Entry{}
+	for index, entry := range replayer.entries {
+		if !replayer.used[index] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Answer a call of any method: receive its request, then send the responses and status of the matching entry.
+func (replayer *Replayer) handle(server interface{}, stream grpc.ServerStream) error {
+	_ = server
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "szreplay: no method in stream")
+	}
+	methodDescriptor, err := findMethod(method)
+	if err != nil {
+		return err
+	}
+	request, err := newMessage(methodDescriptor.Input())
+	if err != nil {
+		return err
+	}
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	entry, err := replayer.match(method, request)
+	if err != nil {
+		return err
+	}
+	if replayer.latency && entry.Duration > 0 {
+		timer := time.NewTimer(entry.Duration)
+		defer timer.Stop()
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-timer.C:
+		}
+	}
+	for index, rawResponse := range entry.Responses {
+		response, err := newMessage(methodDescriptor.Output())
+		if err != nil {
+			return err
+		}
+		if err := protojson.Unmarshal(rawResponse, response); err != nil {
+			return status.Errorf(codes.Internal, "szreplay: entry %d: response %d: %v", entry.Sequence, index+1, err)
+		}
+		if err := stream.SendMsg(response); err != nil {
+			return err
+		}
+	}
+	return entryStatus(entry)
+}
+
+// Take the first unused entry of the method whose request equals the request.
+func (replayer *Replayer) match(method string, request proto.Message) (Entry, error) {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+	for index, entry := range replayer.entries {
+		if replayer.used[index] || entry.Method != method {
+			continue
+		}
+		recorded := request.ProtoReflect().New().Interface()
+		if err := protojson.Unmarshal(entry.Request, recorded); err != nil {
+			return Entry{}, status.Errorf(codes.Internal, "szreplay: entry %d: request: %v", entry.Sequence, err)
+		}
+		if proto.Equal(recorded, request) {
+			replayer.used[index] = true
+			return entry, nil
+		}
+	}
+	return Entry{}, status.Errorf(codes.FailedPrecondition, "szreplay: no recorded call of %s matches the request %s", method, marshalMessage(request))
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// The status of a recorded call, or nil if it succeeded.
+func entryStatus(entry Entry) error {
+	if len(entry.Status) == 0 {
+		return nil
+	}
+	recorded := &spb.Status{}
+	if err := protojson.Unmarshal(entry.Status, recorded); err != nil {
+		return status.Errorf(codes.Internal, "szreplay: entry %d: status: %v", entry.Sequence, err)
+	}
+	return status.FromProto(recorded).Err()
+}
+
+// Find the descriptor of a full gRPC method name, such as "/szengine.SzEngine/AddRecord".
+func findMethod(method string) (protoreflect.MethodDescriptor, error) {
+	serviceName, methodName, found := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !found {
+		return nil, status.Errorf(codes.Unimplemented, "szreplay: malformed method %s", method)
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "szreplay: unknown service %s", serviceName)
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "szreplay: unknown service %s", serviceName)
+	}
+	result := serviceDescriptor.Methods().ByName(protoreflect.Name(methodName))
+	if result == nil {
+		return nil, status.Errorf(codes.Unimplemented, "szreplay: unknown method %s", method)
+	}
+	return result, nil
+}
+
+// Create an empty message of a registered type.
+func newMessage(descriptor protoreflect.MessageDescriptor) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(descriptor.FullName())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "szreplay: message %s: %v", descriptor.FullName(), err)
+	}
+	return messageType.New().Interface(), nil
+}
@@ -0,0 +1,52 @@
+package szreplay
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNewRecorder() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szreplay/szreplay_examples_test.go
+	ctx := context.TODO()
+	captureFile, err := os.Create(filepath.Join(os.TempDir(), "szreplay-capture.jsonl"))
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = captureFile.Close() }()
+	recorder := NewRecorder(captureFile)
+	szAbstractFactory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithUnaryInterceptors(recorder.UnaryClientInterceptor()),
+		szabstractfactory.WithStreamInterceptors(recorder.StreamClientInterceptor()),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = szAbstractFactory.Close() }()
+	// Output:
+}
+
+func ExampleReplayer_NewServer() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szreplay/szreplay_examples_test.go
+	entries, err := ReadEntries(os.Stdin)
+	if err != nil {
+		fmt.Println(err)
+	}
+	replayer := NewReplayer(entries)
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		fmt.Println(err)
+	}
+	grpcServer := replayer.NewServer()
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+	// Output:
+}
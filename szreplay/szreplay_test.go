@@ -0,0 +1,222 @@
+package szreplay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	bufconnAddress    = "passthrough:///bufnet"
+	bufconnBufferSize = 1024 * 1024
+	recordDefinition  = `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "PRIMARY_NAME_LAST": "Smith", "PRIMARY_NAME_FIRST": "Robert", "PHONE_NUMBER": "702-919-1300"}`
+)
+
+type failingWriter struct{}
+
+// A session: the results and errors of calls made with a Szengine.
+type session struct {
+	entity      string
+	exported    []string
+	notFoundErr error
+	withInfo    string
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRecorder(test *testing.T) {
+	entries, _ := recordSession(test)
+	methods := []string{}
+	for _, entry := range entries {
+		methods = append(methods, entry.Method)
+	}
+	assert.Equal(test, []string{
+		"/szengine.SzEngine/AddRecord",
+		"/szengine.SzEngine/GetEntityByRecordId",
+		"/szengine.SzEngine/GetEntityByRecordId",
+		"/szengine.SzEngine/StreamExportJsonEntityReport",
+	}, methods)
+	assert.Equal(test, int64(1), entries[0].Sequence)
+	request := &szpb.AddRecordRequest{}
+	require.NoError(test, protojson.Unmarshal(entries[0].Request, request))
+	assert.Equal(test, recordDefinition, request.GetRecordDefinition())
+	assert.Equal(test, senzing.SzWithInfo, request.GetFlags())
+	assert.Len(test, entries[0].Responses, 1)
+	assert.Empty(test, entries[0].Status)
+	assert.Empty(test, entries[2].Responses)
+	assert.Contains(test, string(entries[2].Status), `"code":5`)
+	assert.Len(test, entries[3].Responses, 1)
+}
+
+func TestRecorder_break(test *testing.T) {
+	ctx := context.TODO()
+	capture := &bytes.Buffer{}
+	recorder := NewRecorder(capture)
+	szEngine := getRecordingSzengine(test, recorder)
+	for _, recordID := range []string{"1001", "1002"} {
+		_, err := szEngine.AddRecord(ctx, "CUSTOMERS", recordID, strings.Replace(recordDefinition, "1001", recordID, 1), senzing.SzNoFlags)
+		require.NoError(test, err)
+	}
+	for _, err := range szEngine.ExportJSONEntities(ctx, senzing.SzExportDefaultFlags) {
+		require.NoError(test, err)
+		break
+	}
+	var entries []Entry
+	require.Eventually(test, func() bool {
+		recorder.mutex.Lock()
+		defer recorder.mutex.Unlock()
+		var err error
+		entries, err = ReadEntries(bytes.NewReader(capture.Bytes()))
+		require.NoError(test, err)
+		return len(entries) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(test, "/szengine.SzEngine/StreamExportJsonEntityReport", entries[2].Method)
+	assert.Len(test, entries[2].Responses, 1)
+	assert.Contains(test, string(entries[2].Status), `"code":1`)
+}
+
+func TestRecorder_Err(test *testing.T) {
+	ctx := context.TODO()
+	recorder := NewRecorder(failingWriter{})
+	szEngine := getRecordingSzengine(test, recorder)
+	_, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.Error(test, err)
+	require.Error(test, recorder.Err())
+}
+
+func TestReadEntries_badLine(test *testing.T) {
+	_, err := ReadEntries(strings.NewReader("{\"sequence\":1}\nnot JSON\n"))
+	require.ErrorContains(test, err, "entry 2")
+}
+
+func TestReplayer(test *testing.T) {
+	entries, recorded := recordSession(test)
+	replayer := NewReplayer(entries)
+	replayed := runSession(test, getReplayingSzengine(test, replayer))
+	assert.Equal(test, recorded.withInfo, replayed.withInfo)
+	assert.Equal(test, recorded.entity, replayed.entity)
+	assert.Equal(test, recorded.exported, replayed.exported)
+	require.ErrorIs(test, replayed.notFoundErr, szerror.ErrSzNotFound)
+	assert.Equal(test, recorded.notFoundErr.Error(), replayed.notFoundErr.Error())
+	assert.Empty(test, replayer.Unused())
+}
+
+func TestReplayer_noMatch(test *testing.T) {
+	ctx := context.TODO()
+	entries, _ := recordSession(test)
+	replayer := NewReplayer(entries)
+	szEngine := getReplayingSzengine(test, replayer)
+	_, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "9999", senzing.SzNoFlags)
+	require.Error(test, err)
+	assert.Equal(test, codes.FailedPrecondition, status.Code(err))
+	_, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzEntityDefaultFlags)
+	require.NoError(test, err)
+	_, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzEntityDefaultFlags)
+	require.Error(test, err, "each entry answers one call")
+	assert.Len(test, replayer.Unused(), 3)
+}
+
+func TestReplayer_WithRecordedLatency(test *testing.T) {
+	ctx := context.TODO()
+	entries, _ := recordSession(test)
+	entries[1].Duration = 50 * time.Millisecond
+	szEngine := getReplayingSzengine(test, NewReplayer(entries, WithRecordedLatency()))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := szEngine.GetEntityByRecordID(timeoutCtx, "CUSTOMERS", "1001", senzing.SzEntityDefaultFlags)
+	require.ErrorIs(test, err, szerror.ErrSzRetryTimeoutExceeded)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func (writer failingWriter) Write(data []byte) (int, error) {
+	_ = data
+	return 0, errors.New("disk full")
+}
+
+func getRecordingSzengine(test *testing.T, recorder *Recorder) *szengine.Szengine {
+	test.Helper()
+	server, err := fakeserver.New(fakeserver.WithDataSources("CUSTOMERS"))
+	require.NoError(test, err)
+	test.Cleanup(server.Close)
+	grpcConnection, err := server.NewClient(
+		grpc.WithUnaryInterceptor(recorder.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(recorder.StreamClientInterceptor()),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = grpcConnection.Close() })
+	return &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+}
+
+func getReplayingSzengine(test *testing.T, replayer *Replayer) *szengine.Szengine {
+	test.Helper()
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := replayer.NewServer()
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+	grpcConnection, err := grpc.NewClient(bufconnAddress,
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = grpcConnection.Close() })
+	return &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+}
+
+// Run a session against a fake server, recording it.  Returns the entries read back from the capture and the session.
+func recordSession(test *testing.T) ([]Entry, session) {
+	test.Helper()
+	capture := &bytes.Buffer{}
+	recorder := NewRecorder(capture)
+	result := runSession(test, getRecordingSzengine(test, recorder))
+	require.NoError(test, recorder.Err())
+	entries, err := ReadEntries(capture)
+	require.NoError(test, err)
+	return entries, result
+}
+
+func runSession(test *testing.T, szEngine *szengine.Szengine) session {
+	test.Helper()
+	ctx := context.TODO()
+	result := session{}
+	var err error
+	result.withInfo, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", recordDefinition, senzing.SzWithInfo)
+	require.NoError(test, err)
+	result.entity, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzEntityDefaultFlags)
+	require.NoError(test, err)
+	_, result.notFoundErr = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1002", senzing.SzNoFlags)
+	require.Error(test, result.notFoundErr)
+	require.True(test, errors.As(result.notFoundErr, new(*helper.SzGrpcError)))
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzExportDefaultFlags) {
+		require.NoError(test, fragment.Error)
+		result.exported = append(result.exported, fragment.Value)
+	}
+	return result
+}
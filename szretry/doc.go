@@ -0,0 +1,16 @@
+/*
+The szretry package retries Senzing gRPC calls that fail with a retryable error.
+
+Errors are classified with helper.ConvertGrpcError(), so a call is retried when the result
+matches szerror.ErrSzRetryable.
+That covers both engine errors, such as a lost database connection,
+and transport errors, such as an unavailable server.
+Retries use exponential backoff with full jitter, a maximum number of attempts,
+and a policy per gRPC method.
+A retry budget stops retries when most calls are failing, so that retries do not amplify an outage.
+
+By default only the methods in DefaultRetryableMethods are retried.
+These are the read-only methods and the record operations that are safe to repeat.
+The interceptor is usually passed to szabstractfactory.WithUnaryInterceptors().
+*/
+package szretry
@@ -0,0 +1,97 @@
+package szretry
+
+import (
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Policy describes how a method is retried.
+type Policy struct {
+	// InitialBackoff is the upper bound of the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxAttempts is the total number of attempts, including the first.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// MaxBackoff caps the upper bound of the delay before any retry.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the upper bound of the delay grows after each retry.
+	Multiplier float64
+}
+
+// Budget limits retries across all methods using the token bucket described in gRPC's retry design (gRFC A6).
+// Each failed attempt removes one token and each successful call adds TokenRatio tokens.
+// Retries are only attempted while more than half of MaxTokens remain.
+type Budget struct {
+	// MaxTokens is the capacity of the bucket.  The bucket starts full.
+	MaxTokens float64
+
+	// TokenRatio is the number of tokens returned by each successful call.
+	TokenRatio float64
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// DefaultBudget allows retries until roughly one in ten calls fail.
+var DefaultBudget = Budget{
+	MaxTokens:  10,
+	TokenRatio: 0.1,
+}
+
+// DefaultPolicy is used for the methods in DefaultRetryableMethods.
+var DefaultPolicy = Policy{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxAttempts:    5,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+}
+
+/*
+DefaultRetryableMethods lists the full gRPC method names retried with DefaultPolicy.
+Methods that create or consume server-side state, such as GetRedoRecord, AddConfig, ReplaceDefaultConfigId,
+CreateConfig, ExportJsonEntityReport and FetchNext, are not retried because repeating them is unsafe.
+Nor is GetStats, which resets the counters it returns: a retry after a lost response would lose them.
+Callers that accept that can opt in with WithMethodPolicy().
+*/
+var DefaultRetryableMethods = []string{
+	"/szconfig.SzConfig/ExportConfig",
+	"/szconfig.SzConfig/GetDataSources",
+	"/szconfigmanager.SzConfigManager/GetConfig",
+	"/szconfigmanager.SzConfigManager/GetConfigs",
+	"/szconfigmanager.SzConfigManager/GetDefaultConfigId",
+	"/szconfigmanager.SzConfigManager/SetDefaultConfigId",
+	"/szdiagnostic.SzDiagnostic/CheckDatastorePerformance",
+	"/szdiagnostic.SzDiagnostic/GetDatastoreInfo",
+	"/szdiagnostic.SzDiagnostic/GetFeature",
+	"/szengine.SzEngine/AddRecord",
+	"/szengine.SzEngine/CountRedoRecords",
+	"/szengine.SzEngine/DeleteRecord",
+	"/szengine.SzEngine/FindInterestingEntitiesByEntityId",
+	"/szengine.SzEngine/FindInterestingEntitiesByRecordId",
+	"/szengine.SzEngine/FindNetworkByEntityId",
+	"/szengine.SzEngine/FindNetworkByRecordId",
+	"/szengine.SzEngine/FindPathByEntityId",
+	"/szengine.SzEngine/FindPathByRecordId",
+	"/szengine.SzEngine/GetActiveConfigId",
+	"/szengine.SzEngine/GetEntityByEntityId",
+	"/szengine.SzEngine/GetEntityByRecordId",
+	"/szengine.SzEngine/GetRecord",
+	"/szengine.SzEngine/GetVirtualEntityByRecordId",
+	"/szengine.SzEngine/HowEntityByEntityId",
+	"/szengine.SzEngine/ProcessRedoRecord",
+	"/szengine.SzEngine/ReevaluateEntity",
+	"/szengine.SzEngine/ReevaluateRecord",
+	"/szengine.SzEngine/SearchByAttributes",
+	"/szengine.SzEngine/WhyEntities",
+	"/szengine.SzEngine/WhyRecordInEntity",
+	"/szengine.SzEngine/WhyRecords",
+	"/szproduct.SzProduct/GetLicense",
+	"/szproduct.SzProduct/GetVersion",
+}
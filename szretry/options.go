@@ -0,0 +1,83 @@
+package szretry
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Option configures a Retryer created by New.
+type Option func(*Retryer) error
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The WithBudget function sets the retry budget shared by all methods.
+The default is DefaultBudget.
+
+Input
+  - budget: The size of the token bucket and the tokens returned by each success.
+*/
+func WithBudget(budget Budget) Option {
+	return func(retryer *Retryer) error {
+		if budget.MaxTokens <= 0 || budget.TokenRatio < 0 {
+			return fmt.Errorf("invalid retry budget: %+v", budget)
+		}
+		retryer.budget = newTokenBucket(budget)
+		return nil
+	}
+}
+
+/*
+The WithDefaultPolicy function sets the policy for the methods in DefaultRetryableMethods.
+The default is DefaultPolicy.
+
+Input
+  - policy: The retry policy.
+*/
+func WithDefaultPolicy(policy Policy) Option {
+	return func(retryer *Retryer) error {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		retryer.defaultPolicy = policy
+		return nil
+	}
+}
+
+/*
+The WithMethodPolicy function sets the policy for one method, overriding any default.
+Use a Policy with MaxAttempts of 1 to stop retrying a method in DefaultRetryableMethods.
+
+Input
+  - method: The full gRPC method name, such as "/szengine.SzEngine/AddRecord".
+  - policy: The retry policy.
+*/
+func WithMethodPolicy(method string, policy Policy) Option {
+	return func(retryer *Retryer) error {
+		if len(method) == 0 {
+			return errors.New("method cannot be empty")
+		}
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		retryer.methodPolicies[method] = policy
+		return nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (policy Policy) validate() error {
+	if policy.MaxAttempts > 1 && (policy.InitialBackoff < 0 || policy.MaxBackoff < policy.InitialBackoff || policy.Multiplier < 1) {
+		return fmt.Errorf("invalid retry policy: %+v", policy)
+	}
+	return nil
+}
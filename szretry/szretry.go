@@ -0,0 +1,169 @@
+package szretry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"google.golang.org/grpc"
+)
+
+// Retryer retries unary gRPC calls according to per-method policies and a shared budget.
+type Retryer struct {
+	budget         *tokenBucket
+	defaultPolicy  Policy
+	methodPolicies map[string]Policy
+	random         func() float64
+	retryable      map[string]bool
+	sleep          func(ctx context.Context, duration time.Duration) error
+}
+
+type tokenBucket struct {
+	maxTokens  float64
+	mutex      sync.Mutex
+	tokenRatio float64
+	tokens     float64
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function creates a Retryer.
+Without options, the methods in DefaultRetryableMethods are retried with DefaultPolicy
+within DefaultBudget, and all other methods are not retried.
+
+Input
+  - options: Options that change the policies or the budget.
+*/
+func New(options ...Option) (*Retryer, error) {
+	result := &Retryer{
+		budget:         newTokenBucket(DefaultBudget),
+		defaultPolicy:  DefaultPolicy,
+		methodPolicies: map[string]Policy{},
+		random:         rand.Float64,
+		retryable:      map[string]bool{},
+		sleep:          sleep,
+	}
+	for _, method := range DefaultRetryableMethods {
+		result.retryable[method] = true
+	}
+	for _, option := range options {
+		if err := option(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+/*
+The IsRetryable function reports whether an error from a Senzing gRPC call is worth retrying.
+The error is converted with helper.ConvertGrpcError() and tested against szerror.ErrSzRetryable.
+Context cancellation and expired deadlines are never retryable, since a retry would fail the same way.
+
+Input
+  - err: The error returned by the call.
+*/
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return errors.Is(helper.ConvertGrpcError(err), szerror.ErrSzRetryable)
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The UnaryClientInterceptor method returns an interceptor that retries unary calls.
+The error from the last attempt is returned if every attempt fails,
+if the retry budget is exhausted, or if the context is done while waiting to retry.
+*/
+func (retryer *Retryer) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+		policy := retryer.getPolicy(method)
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = invoker(ctx, method, request, reply, grpcConnection, options...)
+			if err == nil {
+				retryer.budget.onSuccess()
+				return nil
+			}
+			if policy.MaxAttempts <= 1 || !IsRetryable(err) || ctx.Err() != nil {
+				return err
+			}
+			if !retryer.budget.onFailure() || attempt >= policy.MaxAttempts {
+				return err
+			}
+			if retryer.sleep(ctx, retryer.getBackoff(policy, attempt)) != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Full jitter: a random delay between zero and the exponentially growing upper bound.
+func (retryer *Retryer) getBackoff(policy Policy, attempt int) time.Duration {
+	upperBound := float64(policy.InitialBackoff) * math.Pow(policy.Multiplier, float64(attempt-1))
+	upperBound = math.Min(upperBound, float64(policy.MaxBackoff))
+	return time.Duration(retryer.random() * upperBound)
+}
+
+func (retryer *Retryer) getPolicy(method string) Policy {
+	if policy, ok := retryer.methodPolicies[method]; ok {
+		return policy
+	}
+	if retryer.retryable[method] {
+		return retryer.defaultPolicy
+	}
+	return Policy{MaxAttempts: 1}
+}
+
+// Record a success, returning tokens to the bucket.
+func (bucket *tokenBucket) onSuccess() {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	bucket.tokens = math.Min(bucket.maxTokens, bucket.tokens+bucket.tokenRatio)
+}
+
+// Record a retryable failure and report whether a retry is allowed.
+func (bucket *tokenBucket) onFailure() bool {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	bucket.tokens = math.Max(0, bucket.tokens-1)
+	return bucket.tokens > bucket.maxTokens/2
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newTokenBucket(budget Budget) *tokenBucket {
+	return &tokenBucket{
+		maxTokens:  budget.MaxTokens,
+		tokenRatio: budget.TokenRatio,
+		tokens:     budget.MaxTokens,
+	}
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
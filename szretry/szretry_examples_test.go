@@ -0,0 +1,38 @@
+package szretry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNew() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/szretry/szretry_examples_test.go
+	ctx := context.TODO()
+	retryer, err := New(
+		WithDefaultPolicy(Policy{
+			InitialBackoff: 200 * time.Millisecond,
+			MaxAttempts:    4,
+			MaxBackoff:     5 * time.Second,
+			Multiplier:     2,
+		}),
+		WithMethodPolicy("/szengine.SzEngine/ProcessRedoRecord", Policy{MaxAttempts: 1}),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	szAbstractFactory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithUnaryInterceptors(retryer.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = szAbstractFactory.Close() }()
+	// Output:
+}
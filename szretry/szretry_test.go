@@ -0,0 +1,280 @@
+package szretry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/fakeserver"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szproduct"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	addRecordMethod     = "/szengine.SzEngine/AddRecord"
+	bufconnAddress      = "passthrough:///bufnet"
+	bufconnBufferSize   = 1024 * 1024
+	getRedoRecordMethod = "/szengine.SzEngine/GetRedoRecord"
+	getStatsMethod      = "/szengine.SzEngine/GetStats"
+	getVersionMethod    = "/szproduct.SzProduct/GetVersion"
+)
+
+type testInvoker struct {
+	calls  int
+	errors []error
+}
+
+type testSzEngineServer struct {
+	szpb.UnimplementedSzEngineServer
+	failures int
+	mutex    sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRetryer_New_badOption(test *testing.T) {
+	_, err := New(WithMethodPolicy("", DefaultPolicy))
+	require.Error(test, err)
+	_, err = New(WithDefaultPolicy(Policy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Millisecond, Multiplier: 2}))
+	require.Error(test, err)
+	_, err = New(WithBudget(Budget{}))
+	require.Error(test, err)
+}
+
+func TestRetryer_retryableMethod(test *testing.T) {
+	retryer := getTestRetryer(test)
+	invoker := &testInvoker{errors: []error{unavailable(), unavailable()}}
+	err := invoke(retryer, addRecordMethod, invoker)
+	require.NoError(test, err)
+	assert.Equal(test, 3, invoker.calls)
+}
+
+func TestRetryer_maxAttempts(test *testing.T) {
+	retryer := getTestRetryer(test, WithDefaultPolicy(Policy{MaxAttempts: 3, MaxBackoff: time.Second, Multiplier: 2}))
+	invoker := &testInvoker{errors: []error{unavailable(), unavailable(), unavailable(), unavailable()}}
+	err := invoke(retryer, addRecordMethod, invoker)
+	require.Error(test, err)
+	assert.Equal(test, codes.Unavailable, status.Code(err))
+	assert.Equal(test, 3, invoker.calls)
+}
+
+func TestRetryer_notRetryableMethod(test *testing.T) {
+	retryer := getTestRetryer(test)
+	for _, method := range []string{getRedoRecordMethod, getStatsMethod} {
+		invoker := &testInvoker{errors: []error{unavailable()}}
+		err := invoke(retryer, method, invoker)
+		require.Error(test, err, method)
+		assert.Equal(test, 1, invoker.calls, method)
+	}
+}
+
+func TestRetryer_notRetryableError(test *testing.T) {
+	retryer := getTestRetryer(test)
+	invoker := &testInvoker{errors: []error{status.Error(codes.Unknown, `{"reason": "SENZ0033E|Unknown record"}`)}}
+	err := invoke(retryer, addRecordMethod, invoker)
+	require.Error(test, err)
+	assert.Equal(test, 1, invoker.calls)
+}
+
+func TestRetryer_senzingRetryableError(test *testing.T) {
+	retryer := getTestRetryer(test)
+	invoker := &testInvoker{errors: []error{status.Error(codes.Unknown, `{"reason": "SENZ1007E|Database Connection Lost"}`)}}
+	err := invoke(retryer, addRecordMethod, invoker)
+	require.NoError(test, err)
+	assert.Equal(test, 2, invoker.calls)
+}
+
+func TestRetryer_WithMethodPolicy(test *testing.T) {
+	retryer := getTestRetryer(test,
+		WithMethodPolicy(addRecordMethod, Policy{MaxAttempts: 1}),
+		WithMethodPolicy(getRedoRecordMethod, Policy{MaxAttempts: 2, MaxBackoff: time.Second, Multiplier: 1}),
+	)
+	invoker := &testInvoker{errors: []error{unavailable()}}
+	require.Error(test, invoke(retryer, addRecordMethod, invoker))
+	assert.Equal(test, 1, invoker.calls)
+	invoker = &testInvoker{errors: []error{unavailable()}}
+	require.NoError(test, invoke(retryer, getRedoRecordMethod, invoker))
+	assert.Equal(test, 2, invoker.calls)
+}
+
+func TestRetryer_budget(test *testing.T) {
+	retryer := getTestRetryer(test, WithBudget(Budget{MaxTokens: 4, TokenRatio: 1}))
+
+	// Tokens: 4 -> 3 (retry) -> 2 (no retry, not more than half).
+
+	invoker := &testInvoker{errors: []error{unavailable(), unavailable(), unavailable()}}
+	require.Error(test, invoke(retryer, addRecordMethod, invoker))
+	assert.Equal(test, 2, invoker.calls)
+
+	// Budget exhausted: no retries.
+
+	invoker = &testInvoker{errors: []error{unavailable()}}
+	require.Error(test, invoke(retryer, addRecordMethod, invoker))
+	assert.Equal(test, 1, invoker.calls)
+
+	// Successes refill the budget.
+
+	for i := 0; i < 4; i++ {
+		require.NoError(test, invoke(retryer, addRecordMethod, &testInvoker{}))
+	}
+	invoker = &testInvoker{errors: []error{unavailable()}}
+	require.NoError(test, invoke(retryer, addRecordMethod, invoker))
+	assert.Equal(test, 2, invoker.calls)
+}
+
+func TestRetryer_contextDone(test *testing.T) {
+	retryer, err := New()
+	require.NoError(test, err)
+	ctx, cancel := context.WithCancel(context.TODO())
+	retryer.sleep = func(ctx context.Context, duration time.Duration) error {
+		_ = duration
+		cancel()
+		return ctx.Err()
+	}
+	invoker := &testInvoker{errors: []error{unavailable(), unavailable()}}
+	err = retryer.UnaryClientInterceptor()(ctx, addRecordMethod, nil, nil, nil, invoker.invoke)
+	require.Error(test, err)
+	assert.Equal(test, codes.Unavailable, status.Code(err))
+	assert.Equal(test, 1, invoker.calls)
+}
+
+func TestRetryer_getBackoff(test *testing.T) {
+	retryer := getTestRetryer(test)
+	retryer.random = func() float64 { return 1 }
+	policy := Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	assert.Equal(test, 100*time.Millisecond, retryer.getBackoff(policy, 1))
+	assert.Equal(test, 400*time.Millisecond, retryer.getBackoff(policy, 3))
+	assert.Equal(test, time.Second, retryer.getBackoff(policy, 10))
+	retryer.random = func() float64 { return 0.5 }
+	assert.Equal(test, 50*time.Millisecond, retryer.getBackoff(policy, 1))
+}
+
+func TestIsRetryable(test *testing.T) {
+	assert.False(test, IsRetryable(nil))
+	assert.True(test, IsRetryable(unavailable()))
+	assert.True(test, IsRetryable(status.Error(codes.ResourceExhausted, "overloaded")))
+	assert.False(test, IsRetryable(status.Error(codes.InvalidArgument, "bad")))
+	assert.False(test, IsRetryable(context.Canceled))
+	assert.False(test, IsRetryable(context.DeadlineExceeded))
+	assert.False(test, IsRetryable(errors.New("not a gRPC error")))
+}
+
+func TestRetryer_Szengine_AddRecord(test *testing.T) {
+	ctx := context.TODO()
+	server := &testSzEngineServer{failures: 2}
+	szEngine := getTestSzengine(test, server, getTestRetryer(test))
+	actual, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, 0)
+	require.NoError(test, err)
+	assert.Equal(test, "{}", actual)
+	assert.Equal(test, 0, server.failures)
+}
+
+func TestRetryer_Szengine_AddRecord_exhausted(test *testing.T) {
+	ctx := context.TODO()
+	server := &testSzEngineServer{failures: 10}
+	retryer := getTestRetryer(test, WithDefaultPolicy(Policy{MaxAttempts: 2, MaxBackoff: time.Second, Multiplier: 2}))
+	szEngine := getTestSzengine(test, server, retryer)
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, 0)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	assert.Equal(test, 8, server.failures)
+}
+
+func TestRetryer_Szproduct_GetVersion_unavailableBurst(test *testing.T) {
+	ctx := context.TODO()
+	server, err := fakeserver.New(fakeserver.WithFaults(fakeserver.Unavailable(getVersionMethod, 1, 3)))
+	require.NoError(test, err)
+	defer server.Close()
+	grpcConnection, err := server.NewClient(grpc.WithUnaryInterceptor(getTestRetryer(test).UnaryClientInterceptor()))
+	require.NoError(test, err)
+	defer func() { _ = grpcConnection.Close() }()
+	szProduct := &szproduct.Szproduct{GrpcClient: szproductpb.NewSzProductClient(grpcConnection)}
+	_, err = szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	server.SetFaults(fakeserver.Unavailable(getVersionMethod, 1, 10))
+	_, err = szProduct.GetVersion(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func (invoker *testInvoker) invoke(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, options ...grpc.CallOption) error {
+	_ = ctx
+	_ = method
+	_ = request
+	_ = reply
+	_ = grpcConnection
+	_ = options
+	invoker.calls++
+	if invoker.calls <= len(invoker.errors) {
+		return invoker.errors[invoker.calls-1]
+	}
+	return nil
+}
+
+func (server *testSzEngineServer) AddRecord(ctx context.Context, request *szpb.AddRecordRequest) (*szpb.AddRecordResponse, error) {
+	_ = ctx
+	_ = request
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.failures > 0 {
+		server.failures--
+		return nil, status.Error(codes.Unavailable, "server restarting")
+	}
+	return &szpb.AddRecordResponse{Result: "{}"}, nil
+}
+
+// A Retryer that does not sleep between attempts.
+func getTestRetryer(test *testing.T, options ...Option) *Retryer {
+	result, err := New(options...)
+	require.NoError(test, err)
+	result.sleep = func(ctx context.Context, duration time.Duration) error {
+		_ = duration
+		return ctx.Err()
+	}
+	return result
+}
+
+func getTestSzengine(test *testing.T, server szpb.SzEngineServer, retryer *Retryer) *szengine.Szengine {
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := grpc.NewServer()
+	szpb.RegisterSzEngineServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+	grpcConnection, err := grpc.NewClient(bufconnAddress,
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(retryer.UnaryClientInterceptor()),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = grpcConnection.Close() })
+	return &szengine.Szengine{GrpcClient: szpb.NewSzEngineClient(grpcConnection)}
+}
+
+func invoke(retryer *Retryer, method string, invoker *testInvoker) error {
+	return retryer.UnaryClientInterceptor()(context.TODO(), method, nil, nil, nil, invoker.invoke)
+}
+
+func unavailable() error {
+	return status.Error(codes.Unavailable, "connection refused")
+}
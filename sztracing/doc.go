@@ -0,0 +1,15 @@
+/*
+The sztracing package creates OpenTelemetry spans for calls made to a Senzing gRPC server.
+
+UnaryClientInterceptor and StreamClientInterceptor start a client span for every RPC
+made by the szconfig, szconfigmanager, szdiagnostic, szengine and szproduct clients.
+Each span records the gRPC method and, when present in the request, the data source codes,
+record IDs and entity IDs, including the pairs of WhyRecords(), WhyEntities() and FindPathBy*(),
+the configuration ID and flags.
+Failed calls record the gRPC status code and the Senzing reason code.
+The trace context is injected into the gRPC metadata so that the server can continue the trace.
+
+The interceptors are usually passed to szabstractfactory.WithUnaryInterceptors()
+and szabstractfactory.WithStreamInterceptors().
+*/
+package sztracing
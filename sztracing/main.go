@@ -0,0 +1,90 @@
+package sztracing
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Option configures the interceptors created by UnaryClientInterceptor and StreamClientInterceptor.
+type Option func(*config)
+
+type config struct {
+	hashKey        []byte
+	hashRecordIDs  bool
+	propagator     propagation.TextMapPropagator
+	tracerProvider trace.TracerProvider
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The instrumentation scope name reported with every span.
+const ScopeName = "github.com/senzing-garage/sz-sdk-go-grpc/sztracing"
+
+// Span attribute keys.
+const (
+	ConfigIDKey            = attribute.Key("senzing.config_id")
+	DataSourceCode1Key     = attribute.Key("senzing.data_source_code_1")
+	DataSourceCode2Key     = attribute.Key("senzing.data_source_code_2")
+	DataSourceCodeKey      = attribute.Key("senzing.data_source_code")
+	EndDataSourceCodeKey   = attribute.Key("senzing.end_data_source_code")
+	EndEntityIDKey         = attribute.Key("senzing.end_entity_id")
+	EndRecordIDKey         = attribute.Key("senzing.end_record_id")
+	EntityID1Key           = attribute.Key("senzing.entity_id_1")
+	EntityID2Key           = attribute.Key("senzing.entity_id_2")
+	EntityIDKey            = attribute.Key("senzing.entity_id")
+	ExportFragmentsKey     = attribute.Key("senzing.export.fragments")
+	FlagsKey               = attribute.Key("senzing.flags")
+	GrpcStatusCodeKey      = attribute.Key("rpc.grpc.status_code")
+	ReasonCodeKey          = attribute.Key("senzing.reason_code")
+	RecordID1Key           = attribute.Key("senzing.record_id_1")
+	RecordID2Key           = attribute.Key("senzing.record_id_2")
+	RecordIDKey            = attribute.Key("senzing.record_id")
+	RPCMethodKey           = attribute.Key("rpc.method")
+	RPCServiceKey          = attribute.Key("rpc.service")
+	RPCSystemKey           = attribute.Key("rpc.system")
+	StartDataSourceCodeKey = attribute.Key("senzing.start_data_source_code")
+	StartEntityIDKey       = attribute.Key("senzing.start_entity_id")
+	StartRecordIDKey       = attribute.Key("senzing.start_record_id")
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The span attributes of record IDs, hashed by WithHashedRecordIDs.
+var recordIDKeys = map[attribute.Key]bool{
+	EndRecordIDKey:   true,
+	RecordID1Key:     true,
+	RecordID2Key:     true,
+	RecordIDKey:      true,
+	StartRecordIDKey: true,
+}
+
+// Request fields recorded as span attributes, by protobuf field name.
+var requestFieldAttributes = map[protoreflect.Name]attribute.Key{
+	"configId":            ConfigIDKey,
+	"dataSourceCode":      DataSourceCodeKey,
+	"dataSourceCode1":     DataSourceCode1Key,
+	"dataSourceCode2":     DataSourceCode2Key,
+	"endDataSourceCode":   EndDataSourceCodeKey,
+	"endEntityId":         EndEntityIDKey,
+	"endRecordId":         EndRecordIDKey,
+	"entityId":            EntityIDKey,
+	"entityId1":           EntityID1Key,
+	"entityId2":           EntityID2Key,
+	"flags":               FlagsKey,
+	"recordId":            RecordIDKey,
+	"recordId1":           RecordID1Key,
+	"recordId2":           RecordID2Key,
+	"startDataSourceCode": StartDataSourceCodeKey,
+	"startEntityId":       StartEntityIDKey,
+	"startRecordId":       StartRecordIDKey,
+}
@@ -0,0 +1,280 @@
+package sztracing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/helper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type metadataCarrier metadata.MD
+
+type tracedClientStream struct {
+	grpc.ClientStream
+	config    *config
+	fragments atomic.Int64
+	once      sync.Once
+	sent      bool
+	span      trace.Span
+	stop      func() bool // Stops ending the span when the context is done.  Called by RecvMsg when the stream ends.
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The StreamClientInterceptor function returns an interceptor that creates a span for each streaming call,
+such as those made by ExportJSONEntityReportIterator().
+The span ends when the stream ends, or when the context of the call is done,
+as when the consumer of an export iterator breaks out of its loop,
+and records the number of messages received.
+
+Input
+  - options: Options that set the tracer provider, propagator and record ID hashing.
+*/
+func StreamClientInterceptor(options ...Option) grpc.StreamClientInterceptor {
+	config := newConfig(options...)
+	tracer := config.tracerProvider.Tracer(ScopeName)
+	return func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, streamer grpc.Streamer, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := config.startSpan(ctx, tracer, method)
+		clientStream, err := streamer(ctx, streamDesc, grpcConnection, method, options...)
+		if err != nil {
+			endSpan(span, err)
+			return nil, err
+		}
+		result := &tracedClientStream{
+			ClientStream: clientStream,
+			config:       config,
+			span:         span,
+		}
+		result.stop = context.AfterFunc(ctx, func() { result.end(ctx.Err()) })
+		return result, nil
+	}
+}
+
+/*
+The UnaryClientInterceptor function returns an interceptor that creates a span for each unary call.
+
+Input
+  - options: Options that set the tracer provider, propagator and record ID hashing.
+*/
+func UnaryClientInterceptor(options ...Option) grpc.UnaryClientInterceptor {
+	config := newConfig(options...)
+	tracer := config.tracerProvider.Tracer(ScopeName)
+	return func(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+		ctx, span := config.startSpan(ctx, tracer, method)
+		span.SetAttributes(config.getRequestAttributes(request)...)
+		err := invoker(ctx, method, request, reply, grpcConnection, options...)
+		endSpan(span, err)
+		return err
+	}
+}
+
+/*
+The WithHashedRecordIDs function records an HMAC-SHA256 of each record ID instead of the record ID itself,
+so that traces can be correlated without exposing identifiers.
+
+Input
+  - key: The HMAC key.  Use the same key across services to get matching hashes.
+*/
+func WithHashedRecordIDs(key []byte) Option {
+	return func(config *config) {
+		config.hashRecordIDs = true
+		config.hashKey = key
+	}
+}
+
+/*
+The WithPropagator function sets the propagator that injects the trace context into the gRPC metadata.
+The default is the W3C Trace Context propagator.
+
+Input
+  - propagator: The propagator, for example otel.GetTextMapPropagator().
+*/
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(config *config) {
+		config.propagator = propagator
+	}
+}
+
+/*
+The WithTracerProvider function sets the provider of the tracer that creates spans.
+The default is otel.GetTracerProvider().
+
+Input
+  - tracerProvider: The tracer provider.
+*/
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(config *config) {
+		config.tracerProvider = tracerProvider
+	}
+}
+
+// ----------------------------------------------------------------------------
+// grpc.ClientStream interface methods
+// ----------------------------------------------------------------------------
+
+func (stream *tracedClientStream) RecvMsg(message interface{}) error {
+	err := stream.ClientStream.RecvMsg(message)
+	switch {
+	case err == nil:
+		stream.fragments.Add(1)
+	case errors.Is(err, io.EOF):
+		stream.stop()
+		stream.end(nil)
+	default:
+		stream.stop()
+		stream.end(err)
+	}
+	return err
+}
+
+func (stream *tracedClientStream) SendMsg(message interface{}) error {
+	if !stream.sent {
+		stream.sent = true
+		stream.span.SetAttributes(stream.config.getRequestAttributes(message)...)
+	}
+	return stream.ClientStream.SendMsg(message)
+}
+
+// ----------------------------------------------------------------------------
+// propagation.TextMapCarrier interface methods
+// ----------------------------------------------------------------------------
+
+func (carrier metadataCarrier) Get(key string) string {
+	values := metadata.MD(carrier).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (carrier metadataCarrier) Keys() []string {
+	result := make([]string, 0, len(carrier))
+	for key := range carrier {
+		result = append(result, key)
+	}
+	return result
+}
+
+func (carrier metadataCarrier) Set(key string, value string) {
+	metadata.MD(carrier).Set(key, value)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (config *config) getRequestAttributes(request interface{}) []attribute.KeyValue {
+	message, ok := request.(proto.Message)
+	if !ok {
+		return nil
+	}
+	reflectMessage := message.ProtoReflect()
+	fields := reflectMessage.Descriptor().Fields()
+	result := []attribute.KeyValue{}
+	for fieldName, key := range requestFieldAttributes {
+		field := fields.ByName(fieldName)
+		if field == nil {
+			continue
+		}
+		if key != FlagsKey && !reflectMessage.Has(field) {
+			continue
+		}
+		value := reflectMessage.Get(field)
+		switch field.Kind() {
+		case protoreflect.StringKind:
+			if recordIDKeys[key] && config.hashRecordIDs {
+				result = append(result, key.String(config.hash(value.String())))
+			} else {
+				result = append(result, key.String(value.String()))
+			}
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			result = append(result, key.Int64(value.Int()))
+		default:
+		}
+	}
+	return result
+}
+
+func (config *config) hash(value string) string {
+	mac := hmac.New(sha256.New, config.hashKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (config *config) startSpan(ctx context.Context, tracer trace.Tracer, method string) (context.Context, trace.Span) {
+	name := strings.TrimPrefix(method, "/")
+	service, rpcMethod, _ := strings.Cut(name, "/")
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			RPCSystemKey.String("grpc"),
+			RPCServiceKey.String(service),
+			RPCMethodKey.String(rpcMethod),
+		),
+	)
+	outgoingMetadata, _ := metadata.FromOutgoingContext(ctx)
+	outgoingMetadata = outgoingMetadata.Copy()
+	config.propagator.Inject(ctx, metadataCarrier(outgoingMetadata))
+	return metadata.NewOutgoingContext(ctx, outgoingMetadata), span
+}
+
+// End the span once, when the stream ends or its context is done, whichever is first.
+func (stream *tracedClientStream) end(err error) {
+	stream.once.Do(func() {
+		stream.span.SetAttributes(ExportFragmentsKey.Int64(stream.fragments.Load()))
+		endSpan(stream.span, err)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func endSpan(span trace.Span, err error) {
+	defer span.End()
+	convertedError := helper.ConvertGrpcError(err)
+	grpcStatus := status.Convert(convertedError)
+	span.SetAttributes(GrpcStatusCodeKey.Int64(int64(grpcStatus.Code())))
+	if err == nil {
+		return
+	}
+	description := grpcStatus.Message()
+	var szGrpcError *helper.SzGrpcError
+	if errors.As(convertedError, &szGrpcError) && szGrpcError.ReasonCode > 0 {
+		span.SetAttributes(ReasonCodeKey.Int(szGrpcError.ReasonCode))
+		description = szGrpcError.Reason
+	}
+	span.RecordError(err)
+	span.SetStatus(otelcodes.Error, description)
+}
+
+func newConfig(options ...Option) *config {
+	result := &config{
+		propagator:     propagation.TraceContext{},
+		tracerProvider: otel.GetTracerProvider(),
+	}
+	for _, option := range options {
+		option(result)
+	}
+	return result
+}
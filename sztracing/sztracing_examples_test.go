@@ -0,0 +1,31 @@
+package sztracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szabstractfactory"
+	"go.opentelemetry.io/otel"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleUnaryClientInterceptor() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/sztracing/sztracing_examples_test.go
+	ctx := context.TODO()
+	tracingOptions := []Option{
+		WithTracerProvider(otel.GetTracerProvider()),
+		WithHashedRecordIDs([]byte("my-hash-key")),
+	}
+	szAbstractFactory, err := szabstractfactory.NewSzAbstractFactory(ctx,
+		szabstractfactory.WithUnaryInterceptors(UnaryClientInterceptor(tracingOptions...)),
+		szabstractfactory.WithStreamInterceptors(StreamClientInterceptor(tracingOptions...)),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	defer func() { _ = szAbstractFactory.Close() }()
+	// Output:
+}
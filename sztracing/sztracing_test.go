@@ -0,0 +1,399 @@
+package sztracing
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-grpc/szengine"
+	"github.com/senzing-garage/sz-sdk-go-grpc/szproduct"
+	szenginepb "github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	bufconnAddress    = "passthrough:///bufnet"
+	bufconnBufferSize = 1024 * 1024
+	testVersion       = `{"PRODUCT_NAME":"Senzing API","VERSION":"test"}`
+)
+
+type testSzEngineServer struct {
+	szenginepb.UnimplementedSzEngineServer
+	mutex        sync.Mutex
+	traceParents []string
+}
+
+type testSzProductServer struct {
+	szproductpb.UnimplementedSzProductServer
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestUnaryClientInterceptor(test *testing.T) {
+	ctx := context.TODO()
+	exporter, szEngine, server := getTestSzengine(test)
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, 8)
+	require.NoError(test, err)
+	spans := exporter.GetSpans()
+	require.Len(test, spans, 1)
+	span := spans[0]
+	assert.Equal(test, "szengine.SzEngine/AddRecord", span.Name)
+	assert.Equal(test, trace.SpanKindClient, span.SpanKind)
+	attributes := getAttributes(span)
+	assert.Equal(test, "grpc", attributes[RPCSystemKey].AsString())
+	assert.Equal(test, "szengine.SzEngine", attributes[RPCServiceKey].AsString())
+	assert.Equal(test, "AddRecord", attributes[RPCMethodKey].AsString())
+	assert.Equal(test, "CUSTOMERS", attributes[DataSourceCodeKey].AsString())
+	assert.Equal(test, "1001", attributes[RecordIDKey].AsString())
+	assert.Equal(test, int64(8), attributes[FlagsKey].AsInt64())
+	assert.Equal(test, int64(codes.OK), attributes[GrpcStatusCodeKey].AsInt64())
+	assert.NotContains(test, attributes, ReasonCodeKey)
+	assert.Equal(test, otelcodes.Unset, span.Status.Code)
+
+	// Trace context is propagated to the server.
+
+	traceParents := server.getTraceParents()
+	require.Len(test, traceParents, 1)
+	assert.Contains(test, traceParents[0], span.SpanContext.TraceID().String())
+	assert.Contains(test, traceParents[0], span.SpanContext.SpanID().String())
+}
+
+func TestUnaryClientInterceptor_parentSpan(test *testing.T) {
+	exporter, szEngine, _ := getTestSzengine(test)
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, parent := tracerProvider.Tracer("test").Start(context.TODO(), "load")
+	_, err := szEngine.GetEntityByEntityID(ctx, 42, 0)
+	require.NoError(test, err)
+	parent.End()
+	spans := exporter.GetSpans()
+	require.Len(test, spans, 2)
+	assert.Equal(test, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(test, int64(42), getAttributes(spans[0])[EntityIDKey].AsInt64())
+}
+
+func TestUnaryClientInterceptor_senzingError(test *testing.T) {
+	ctx := context.TODO()
+	exporter, szEngine, _ := getTestSzengine(test)
+	_, err := szEngine.GetRecord(ctx, "CUSTOMERS", "9999", 0)
+	require.Error(test, err)
+	spans := exporter.GetSpans()
+	require.Len(test, spans, 1)
+	attributes := getAttributes(spans[0])
+	assert.Equal(test, int64(33), attributes[ReasonCodeKey].AsInt64())
+	assert.Equal(test, int64(codes.NotFound), attributes[GrpcStatusCodeKey].AsInt64())
+	assert.Equal(test, otelcodes.Error, spans[0].Status.Code)
+	assert.Equal(test, "SENZ0033E|Unknown record", spans[0].Status.Description)
+	require.Len(test, spans[0].Events, 1)
+	assert.Equal(test, "exception", spans[0].Events[0].Name)
+}
+
+func TestUnaryClientInterceptor_transportError(test *testing.T) {
+	ctx := context.TODO()
+	exporter, szEngine, _ := getTestSzengine(test)
+	_, err := szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", 0)
+	require.Error(test, err)
+	spans := exporter.GetSpans()
+	require.Len(test, spans, 1)
+	attributes := getAttributes(spans[0])
+	assert.NotContains(test, attributes, ReasonCodeKey)
+	assert.Equal(test, int64(codes.Unavailable), attributes[GrpcStatusCodeKey].AsInt64())
+	assert.Equal(test, "server restarting", spans[0].Status.Description)
+}
+
+func TestUnaryClientInterceptor_hashedRecordIDs(test *testing.T) {
+	ctx := context.TODO()
+	exporter, szEngine, _ := getTestSzengine(test, WithHashedRecordIDs([]byte("secret")))
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, 0)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, 0)
+	require.NoError(test, err)
+	spans := exporter.GetSpans()
+	require.Len(test, spans, 2)
+	recordID := getAttributes(spans[0])[RecordIDKey].AsString()
+	assert.NotEqual(test, "1001", recordID)
+	assert.Len(test, recordID, 64)
+	assert.Equal(test, recordID, getAttributes(spans[1])[RecordIDKey].AsString())
+	assert.Equal(test, "CUSTOMERS", getAttributes(spans[0])[DataSourceCodeKey].AsString())
+}
+
+func TestUnaryClientInterceptor_szproduct(test *testing.T) {
+	ctx := context.TODO()
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	grpcConnection := getTestConnection(test, func(grpcServer *grpc.Server) {
+		szproductpb.RegisterSzProductServer(grpcServer, &testSzProductServer{})
+	}, WithTracerProvider(tracerProvider))
+	szProduct := &szproduct.Szproduct{GrpcClient: szproductpb.NewSzProductClient(grpcConnection)}
+	actual, err := szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+	spans := exporter.GetSpans()
+	require.Len(test, spans, 1)
+	assert.Equal(test, "szproduct.SzProduct/GetVersion", spans[0].Name)
+	assert.NotContains(test, getAttributes(spans[0]), FlagsKey)
+}
+
+func TestStreamClientInterceptor(test *testing.T) {
+	ctx := context.TODO()
+	exporter, szEngine, server := getTestSzengine(test)
+	count := 0
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, 16) {
+		require.NoError(test, fragment.Error)
+		count++
+	}
+	assert.Equal(test, 3, count)
+	spans := exporter.GetSpans()
+	require.Len(test, spans, 1)
+	assert.Equal(test, "szengine.SzEngine/StreamExportJsonEntityReport", spans[0].Name)
+	attributes := getAttributes(spans[0])
+	assert.Equal(test, int64(16), attributes[FlagsKey].AsInt64())
+	assert.Equal(test, int64(3), attributes[ExportFragmentsKey].AsInt64())
+	assert.Equal(test, int64(codes.OK), attributes[GrpcStatusCodeKey].AsInt64())
+	assert.Len(test, server.getTraceParents(), 1)
+}
+
+func TestStreamClientInterceptor_break(test *testing.T) {
+	ctx := context.TODO()
+	exporter, szEngine, _ := getTestSzengine(test)
+	for _, err := range szEngine.ExportJSONEntities(ctx, 0) {
+		require.NoError(test, err)
+		break
+	}
+	require.Eventually(test, func() bool { return len(exporter.GetSpans()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	attributes := getAttributes(exporter.GetSpans()[0])
+	assert.Equal(test, int64(1), attributes[ExportFragmentsKey].AsInt64())
+	assert.Equal(test, int64(codes.Canceled), attributes[GrpcStatusCodeKey].AsInt64())
+}
+
+func TestStreamClientInterceptor_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	exporter, szEngine, _ := getTestSzengine(test)
+	fragments := szEngine.ExportJSONEntityReportIterator(ctx, 0)
+	fragment := <-fragments
+	require.NoError(test, fragment.Error)
+	cancel()
+	require.Eventually(test, func() bool { return len(exporter.GetSpans()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(test, int64(codes.Canceled), getAttributes(exporter.GetSpans()[0])[GrpcStatusCodeKey].AsInt64())
+}
+
+func TestUnaryClientInterceptor_pairs(test *testing.T) {
+	ctx := context.TODO()
+	hash := func(recordID string) string {
+		return (&config{hashKey: []byte("secret")}).hash(recordID)
+	}
+	testCases := []struct {
+		name     string
+		call     func(szEngine *szengine.Szengine) error
+		expected map[attribute.Key]attribute.Value
+	}{
+		{
+			name: "WhyRecords",
+			call: func(szEngine *szengine.Szengine) error {
+				_, err := szEngine.WhyRecords(ctx, "CUSTOMERS", "1001", "WATCHLIST", "2001", 0)
+				return err
+			},
+			expected: map[attribute.Key]attribute.Value{
+				DataSourceCode1Key: attribute.StringValue("CUSTOMERS"),
+				RecordID1Key:       attribute.StringValue(hash("1001")),
+				DataSourceCode2Key: attribute.StringValue("WATCHLIST"),
+				RecordID2Key:       attribute.StringValue(hash("2001")),
+			},
+		},
+		{
+			name: "WhyEntities",
+			call: func(szEngine *szengine.Szengine) error {
+				_, err := szEngine.WhyEntities(ctx, 1, 2, 0)
+				return err
+			},
+			expected: map[attribute.Key]attribute.Value{
+				EntityID1Key: attribute.Int64Value(1),
+				EntityID2Key: attribute.Int64Value(2),
+			},
+		},
+		{
+			name: "FindPathByEntityID",
+			call: func(szEngine *szengine.Szengine) error {
+				_, err := szEngine.FindPathByEntityID(ctx, 1, 2, 3, "", "", 0)
+				return err
+			},
+			expected: map[attribute.Key]attribute.Value{
+				StartEntityIDKey: attribute.Int64Value(1),
+				EndEntityIDKey:   attribute.Int64Value(2),
+			},
+		},
+		{
+			name: "FindPathByRecordID",
+			call: func(szEngine *szengine.Szengine) error {
+				_, err := szEngine.FindPathByRecordID(ctx, "CUSTOMERS", "1001", "WATCHLIST", "2001", 3, "", "", 0)
+				return err
+			},
+			expected: map[attribute.Key]attribute.Value{
+				StartDataSourceCodeKey: attribute.StringValue("CUSTOMERS"),
+				StartRecordIDKey:       attribute.StringValue(hash("1001")),
+				EndDataSourceCodeKey:   attribute.StringValue("WATCHLIST"),
+				EndRecordIDKey:         attribute.StringValue(hash("2001")),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			exporter, szEngine, _ := getTestSzengine(test, WithHashedRecordIDs([]byte("secret")))
+			require.NoError(test, testCase.call(szEngine))
+			spans := exporter.GetSpans()
+			require.Len(test, spans, 1)
+			attributes := getAttributes(spans[0])
+			for key, value := range testCase.expected {
+				assert.Equal(test, value, attributes[key], key)
+			}
+		})
+	}
+}
+
+func TestMetadataCarrier(test *testing.T) {
+	carrier := metadataCarrier(metadata.MD{})
+	assert.Empty(test, carrier.Get("traceparent"))
+	carrier.Set("Traceparent", "value")
+	assert.Equal(test, "value", carrier.Get("traceparent"))
+	assert.Equal(test, []string{"traceparent"}, carrier.Keys())
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func (server *testSzEngineServer) AddRecord(ctx context.Context, request *szenginepb.AddRecordRequest) (*szenginepb.AddRecordResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return &szenginepb.AddRecordResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) DeleteRecord(ctx context.Context, request *szenginepb.DeleteRecordRequest) (*szenginepb.DeleteRecordResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return nil, status.Error(codes.Unavailable, "server restarting")
+}
+
+func (server *testSzEngineServer) FindPathByEntityId(ctx context.Context, request *szenginepb.FindPathByEntityIdRequest) (*szenginepb.FindPathByEntityIdResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return &szenginepb.FindPathByEntityIdResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) FindPathByRecordId(ctx context.Context, request *szenginepb.FindPathByRecordIdRequest) (*szenginepb.FindPathByRecordIdResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return &szenginepb.FindPathByRecordIdResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) GetEntityByEntityId(ctx context.Context, request *szenginepb.GetEntityByEntityIdRequest) (*szenginepb.GetEntityByEntityIdResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return &szenginepb.GetEntityByEntityIdResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) GetRecord(ctx context.Context, request *szenginepb.GetRecordRequest) (*szenginepb.GetRecordResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return nil, status.Error(codes.NotFound, `{"reason": "SENZ0033E|Unknown record"}`)
+}
+
+func (server *testSzEngineServer) StreamExportJsonEntityReport(request *szenginepb.StreamExportJsonEntityReportRequest, stream szenginepb.SzEngine_StreamExportJsonEntityReportServer) error {
+	_ = request
+	server.saveTraceParent(stream.Context())
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(&szenginepb.StreamExportJsonEntityReportResponse{Result: "{}"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (server *testSzEngineServer) WhyEntities(ctx context.Context, request *szenginepb.WhyEntitiesRequest) (*szenginepb.WhyEntitiesResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return &szenginepb.WhyEntitiesResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) WhyRecords(ctx context.Context, request *szenginepb.WhyRecordsRequest) (*szenginepb.WhyRecordsResponse, error) {
+	_ = request
+	server.saveTraceParent(ctx)
+	return &szenginepb.WhyRecordsResponse{Result: "{}"}, nil
+}
+
+func (server *testSzEngineServer) getTraceParents() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string{}, server.traceParents...)
+}
+
+func (server *testSzEngineServer) saveTraceParent(ctx context.Context) {
+	incomingMetadata, _ := metadata.FromIncomingContext(ctx)
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.traceParents = append(server.traceParents, incomingMetadata.Get("traceparent")...)
+}
+
+func (server *testSzProductServer) GetVersion(ctx context.Context, request *szproductpb.GetVersionRequest) (*szproductpb.GetVersionResponse, error) {
+	_ = ctx
+	_ = request
+	return &szproductpb.GetVersionResponse{Result: testVersion}, nil
+}
+
+func getAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	result := map[attribute.Key]attribute.Value{}
+	for _, keyValue := range span.Attributes {
+		result[keyValue.Key] = keyValue.Value
+	}
+	return result
+}
+
+func getTestConnection(test *testing.T, register func(*grpc.Server), options ...Option) *grpc.ClientConn {
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := grpc.NewServer()
+	register(grpcServer)
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+	result, err := grpc.NewClient(bufconnAddress,
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(options...)),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(options...)),
+	)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = result.Close() })
+	return result
+}
+
+func getTestSzengine(test *testing.T, options ...Option) (*tracetest.InMemoryExporter, *szengine.Szengine, *testSzEngineServer) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	server := &testSzEngineServer{}
+	options = append([]Option{WithTracerProvider(tracerProvider)}, options...)
+	grpcConnection := getTestConnection(test, func(grpcServer *grpc.Server) {
+		szenginepb.RegisterSzEngineServer(grpcServer, server)
+	}, options...)
+	szEngine := &szengine.Szengine{GrpcClient: szenginepb.NewSzEngineClient(grpcConnection)}
+	return exporter, szEngine, server
+}
@@ -0,0 +1,9 @@
+/*
+The tlscredentials package creates gRPC transport credentials for reaching a Senzing gRPC server over TLS or mutual TLS.
+
+Certificate and key files are re-read when they change on disk,
+so rotated certificates are used for new connections without restarting the client.
+The credentials are usually passed to szabstractfactory.WithTLS() so that every Sz object
+created by the factory shares them.
+*/
+package tlscredentials
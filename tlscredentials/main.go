@@ -0,0 +1,23 @@
+package tlscredentials
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Config describes the TLS settings for a connection to a Senzing gRPC server.
+type Config struct {
+	// CACertFile is a PEM bundle of certificate authorities trusted to sign the server's certificate.
+	// If empty, the host's root certificate authorities are used.
+	CACertFile string
+
+	// ClientCertFile is the PEM certificate presented to the server for mutual TLS.
+	// It must be specified together with ClientKeyFile.
+	ClientCertFile string
+
+	// ClientKeyFile is the PEM private key for ClientCertFile.
+	ClientKeyFile string
+
+	// ServerName overrides the host name used to verify the server's certificate.
+	// If empty, the host name of the gRPC address is used.
+	ServerName string
+}
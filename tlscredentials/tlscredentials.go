@@ -0,0 +1,197 @@
+package tlscredentials
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+type reloadingCredentials struct {
+	config       Config
+	fileVersions map[string]fileVersion
+	mutex        sync.Mutex
+	serverName   string
+	tlsConfig    *tls.Config
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function creates client transport credentials from a Config.
+The files named in the Config are read immediately, so configuration errors are reported here
+rather than on the first call.
+Before each new connection the files are checked for changes and re-read if needed.
+If a changed file cannot be loaded, for example because it is only partially written,
+the previously loaded certificates continue to be used.
+
+Input
+  - config: The TLS settings.
+
+Output
+  - Transport credentials for use with grpc.WithTransportCredentials() or szabstractfactory.WithTransportCredentials().
+*/
+func New(config Config) (credentials.TransportCredentials, error) {
+	if (len(config.ClientCertFile) == 0) != (len(config.ClientKeyFile) == 0) {
+		return nil, errors.New("ClientCertFile and ClientKeyFile must be specified together")
+	}
+	result := &reloadingCredentials{
+		config:     config,
+		serverName: config.ServerName,
+	}
+	tlsConfig, fileVersions, err := loadTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	result.tlsConfig = tlsConfig
+	result.fileVersions = fileVersions
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// credentials.TransportCredentials interface methods
+// ----------------------------------------------------------------------------
+
+func (reloading *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	tlsConfig := reloading.getTLSConfig()
+	return credentials.NewTLS(tlsConfig).ClientHandshake(ctx, authority, rawConn)
+}
+
+func (reloading *reloadingCredentials) Clone() credentials.TransportCredentials {
+	reloading.mutex.Lock()
+	defer reloading.mutex.Unlock()
+	fileVersions := make(map[string]fileVersion, len(reloading.fileVersions))
+	for fileName, version := range reloading.fileVersions {
+		fileVersions[fileName] = version
+	}
+	return &reloadingCredentials{
+		config:       reloading.config,
+		fileVersions: fileVersions,
+		serverName:   reloading.serverName,
+		tlsConfig:    reloading.tlsConfig.Clone(),
+	}
+}
+
+func (reloading *reloadingCredentials) Info() credentials.ProtocolInfo {
+	reloading.mutex.Lock()
+	defer reloading.mutex.Unlock()
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "tls",
+		SecurityVersion:  "1.2",
+		ServerName:       reloading.serverName,
+	}
+}
+
+// Deprecated: use grpc.WithAuthority or Config.ServerName instead.
+func (reloading *reloadingCredentials) OverrideServerName(serverName string) error {
+	reloading.mutex.Lock()
+	defer reloading.mutex.Unlock()
+	reloading.serverName = serverName
+	reloading.tlsConfig.ServerName = serverName
+	return nil
+}
+
+func (reloading *reloadingCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	_ = rawConn
+	return nil, nil, errors.New("tlscredentials are for gRPC clients only")
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Return the current tls.Config, reloading it first if any file has changed.
+func (reloading *reloadingCredentials) getTLSConfig() *tls.Config {
+	reloading.mutex.Lock()
+	defer reloading.mutex.Unlock()
+	if reloading.isChanged() {
+		tlsConfig, fileVersions, err := loadTLSConfig(reloading.config)
+		if err == nil {
+			tlsConfig.ServerName = reloading.serverName
+			reloading.tlsConfig = tlsConfig
+			reloading.fileVersions = fileVersions
+		}
+	}
+	return reloading.tlsConfig.Clone()
+}
+
+func (reloading *reloadingCredentials) isChanged() bool {
+	for fileName, version := range reloading.fileVersions {
+		currentVersion, err := statFile(fileName)
+		if err != nil || currentVersion != version {
+			return true
+		}
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func loadTLSConfig(config Config) (*tls.Config, map[string]fileVersion, error) {
+	fileVersions := map[string]fileVersion{}
+	result := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+
+	// Certificate authorities used to verify the server.
+
+	if len(config.CACertFile) > 0 {
+		version, err := statFile(config.CACertFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		pemCerts, err := os.ReadFile(config.CACertFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read CA certificate file %s: %w", config.CACertFile, err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(pemCerts) {
+			return nil, nil, fmt.Errorf("no PEM certificates found in CA certificate file %s", config.CACertFile)
+		}
+		result.RootCAs = certPool
+		fileVersions[config.CACertFile] = version
+	}
+
+	// Client certificate for mutual TLS.
+
+	if len(config.ClientCertFile) > 0 {
+		for _, fileName := range []string{config.ClientCertFile, config.ClientKeyFile} {
+			version, err := statFile(fileName)
+			if err != nil {
+				return nil, nil, err
+			}
+			fileVersions[fileName] = version
+		}
+		certificate, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot load client certificate %s and key %s: %w", config.ClientCertFile, config.ClientKeyFile, err)
+		}
+		result.Certificates = []tls.Certificate{certificate}
+	}
+	return result, fileVersions, nil
+}
+
+func statFile(fileName string) (fileVersion, error) {
+	fileInfo, err := os.Stat(fileName)
+	if err != nil {
+		return fileVersion{}, fmt.Errorf("cannot stat %s: %w", fileName, err)
+	}
+	return fileVersion{modTime: fileInfo.ModTime(), size: fileInfo.Size()}, nil
+}
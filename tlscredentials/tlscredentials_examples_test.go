@@ -0,0 +1,21 @@
+package tlscredentials
+
+import (
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNew() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/tlscredentials/tlscredentials_examples_test.go
+	transportCredentials, err := New(Config{
+		ServerName: "senzing.example.com",
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(transportCredentials.Info().SecurityProtocol)
+	// Output: tls
+}
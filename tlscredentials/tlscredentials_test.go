@@ -0,0 +1,311 @@
+package tlscredentials
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	bufconnAddress    = "passthrough:///bufnet"
+	bufconnBufferSize = 1024 * 1024
+	serverName        = "senzing.test"
+	testVersion       = `{"PRODUCT_NAME":"Senzing API","VERSION":"test"}`
+)
+
+type testCertificateAuthority struct {
+	certificate *x509.Certificate
+	privateKey  *ecdsa.PrivateKey
+	pemBytes    []byte
+}
+
+type testSzProductServer struct {
+	szpb.UnimplementedSzProductServer
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestTLSCredentials_New_badCACertFile(test *testing.T) {
+	_, err := New(Config{CACertFile: filepath.Join(test.TempDir(), "missing.pem")})
+	require.Error(test, err)
+}
+
+func TestTLSCredentials_New_notPEM(test *testing.T) {
+	caCertFile := writeFile(test, test.TempDir(), "ca.pem", []byte("not a certificate"))
+	_, err := New(Config{CACertFile: caCertFile})
+	require.Error(test, err)
+}
+
+func TestTLSCredentials_New_clientCertWithoutKey(test *testing.T) {
+	_, err := New(Config{ClientCertFile: "client.pem"})
+	require.Error(test, err)
+}
+
+func TestTLSCredentials_New_systemRoots(test *testing.T) {
+	transportCredentials, err := New(Config{ServerName: serverName})
+	require.NoError(test, err)
+	assert.Equal(test, "tls", transportCredentials.Info().SecurityProtocol)
+	assert.Equal(test, serverName, transportCredentials.Info().ServerName)
+}
+
+func TestTLSCredentials_Clone(test *testing.T) {
+	transportCredentials, err := New(Config{ServerName: serverName})
+	require.NoError(test, err)
+	clone := transportCredentials.Clone()
+	require.NoError(test, clone.OverrideServerName("other.test")) //nolint:staticcheck
+	assert.Equal(test, serverName, transportCredentials.Info().ServerName)
+	assert.Equal(test, "other.test", clone.Info().ServerName)
+}
+
+func TestTLSCredentials_ServerHandshake(test *testing.T) {
+	transportCredentials, err := New(Config{})
+	require.NoError(test, err)
+	_, _, err = transportCredentials.ServerHandshake(nil)
+	require.Error(test, err)
+}
+
+func TestTLSCredentials_TLS(test *testing.T) {
+	ctx := context.TODO()
+	directory := test.TempDir()
+	certificateAuthority := newTestCertificateAuthority(test)
+	dialer := startTestServer(test, certificateAuthority, nil)
+	transportCredentials, err := New(Config{
+		CACertFile: writeFile(test, directory, "ca.pem", certificateAuthority.pemBytes),
+		ServerName: serverName,
+	})
+	require.NoError(test, err)
+	actual, err := getVersion(ctx, dialer, transportCredentials)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+}
+
+func TestTLSCredentials_TLS_wrongServerName(test *testing.T) {
+	ctx := context.TODO()
+	directory := test.TempDir()
+	certificateAuthority := newTestCertificateAuthority(test)
+	dialer := startTestServer(test, certificateAuthority, nil)
+	transportCredentials, err := New(Config{
+		CACertFile: writeFile(test, directory, "ca.pem", certificateAuthority.pemBytes),
+		ServerName: "wrong.test",
+	})
+	require.NoError(test, err)
+	_, err = getVersion(ctx, dialer, transportCredentials)
+	require.Error(test, err)
+}
+
+func TestTLSCredentials_MutualTLS(test *testing.T) {
+	ctx := context.TODO()
+	directory := test.TempDir()
+	certificateAuthority := newTestCertificateAuthority(test)
+	dialer := startTestServer(test, certificateAuthority, certificateAuthority)
+	clientCertPEM, clientKeyPEM := certificateAuthority.issue(test, "client", false)
+	transportCredentials, err := New(Config{
+		CACertFile:     writeFile(test, directory, "ca.pem", certificateAuthority.pemBytes),
+		ClientCertFile: writeFile(test, directory, "client.pem", clientCertPEM),
+		ClientKeyFile:  writeFile(test, directory, "client.key", clientKeyPEM),
+		ServerName:     serverName,
+	})
+	require.NoError(test, err)
+	actual, err := getVersion(ctx, dialer, transportCredentials)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+}
+
+func TestTLSCredentials_MutualTLS_noClientCertificate(test *testing.T) {
+	ctx := context.TODO()
+	directory := test.TempDir()
+	certificateAuthority := newTestCertificateAuthority(test)
+	dialer := startTestServer(test, certificateAuthority, certificateAuthority)
+	transportCredentials, err := New(Config{
+		CACertFile: writeFile(test, directory, "ca.pem", certificateAuthority.pemBytes),
+		ServerName: serverName,
+	})
+	require.NoError(test, err)
+	_, err = getVersion(ctx, dialer, transportCredentials)
+	require.Error(test, err)
+}
+
+func TestTLSCredentials_MutualTLS_rotatedClientCertificate(test *testing.T) {
+	ctx := context.TODO()
+	directory := test.TempDir()
+	serverAuthority := newTestCertificateAuthority(test)
+	oldClientAuthority := newTestCertificateAuthority(test)
+	newClientAuthority := newTestCertificateAuthority(test)
+	dialer := startTestServer(test, serverAuthority, newClientAuthority)
+
+	// The initial client certificate is not trusted by the server.
+
+	clientCertPEM, clientKeyPEM := oldClientAuthority.issue(test, "client", false)
+	config := Config{
+		CACertFile:     writeFile(test, directory, "ca.pem", serverAuthority.pemBytes),
+		ClientCertFile: writeFile(test, directory, "client.pem", clientCertPEM),
+		ClientKeyFile:  writeFile(test, directory, "client.key", clientKeyPEM),
+		ServerName:     serverName,
+	}
+	transportCredentials, err := New(config)
+	require.NoError(test, err)
+	_, err = getVersion(ctx, dialer, transportCredentials)
+	require.Error(test, err)
+
+	// Rotate the client certificate on disk.  The same credentials pick it up.
+
+	clientCertPEM, clientKeyPEM = newClientAuthority.issue(test, "client", false)
+	rotateFile(test, config.ClientCertFile, clientCertPEM)
+	rotateFile(test, config.ClientKeyFile, clientKeyPEM)
+	actual, err := getVersion(ctx, dialer, transportCredentials)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+}
+
+func TestTLSCredentials_TLS_rotatedCACertificate_partialWrite(test *testing.T) {
+	ctx := context.TODO()
+	directory := test.TempDir()
+	certificateAuthority := newTestCertificateAuthority(test)
+	dialer := startTestServer(test, certificateAuthority, nil)
+	caCertFile := writeFile(test, directory, "ca.pem", certificateAuthority.pemBytes)
+	transportCredentials, err := New(Config{
+		CACertFile: caCertFile,
+		ServerName: serverName,
+	})
+	require.NoError(test, err)
+
+	// A truncated file is ignored; the previously loaded CA remains in use.
+
+	rotateFile(test, caCertFile, certificateAuthority.pemBytes[:20])
+	actual, err := getVersion(ctx, dialer, transportCredentials)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func (server *testSzProductServer) GetVersion(ctx context.Context, request *szpb.GetVersionRequest) (*szpb.GetVersionResponse, error) {
+	_ = ctx
+	_ = request
+	return &szpb.GetVersionResponse{Result: testVersion}, nil
+}
+
+func newTestCertificateAuthority(test *testing.T) *testCertificateAuthority {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(test, err)
+	template := &x509.Certificate{
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		NotAfter:              time.Now().Add(time.Hour),
+		NotBefore:             time.Now().Add(-time.Hour),
+		SerialNumber:          newSerialNumber(test),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(test, err)
+	certificate, err := x509.ParseCertificate(derBytes)
+	require.NoError(test, err)
+	return &testCertificateAuthority{
+		certificate: certificate,
+		privateKey:  privateKey,
+		pemBytes:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}),
+	}
+}
+
+func (certificateAuthority *testCertificateAuthority) issue(test *testing.T, commonName string, isServer bool) ([]byte, []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(test, err)
+	template := &x509.Certificate{
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		NotAfter:     time.Now().Add(time.Hour),
+		NotBefore:    time.Now().Add(-time.Hour),
+		SerialNumber: newSerialNumber(test),
+		Subject:      pkix.Name{CommonName: commonName},
+	}
+	if isServer {
+		template.DNSNames = []string{serverName}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, certificateAuthority.certificate, &privateKey.PublicKey, certificateAuthority.privateKey)
+	require.NoError(test, err)
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(test, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	return certPEM, keyPEM
+}
+
+func getVersion(ctx context.Context, dialer func(context.Context, string) (net.Conn, error), transportCredentials credentials.TransportCredentials) (string, error) {
+	grpcConnection, err := grpc.NewClient(bufconnAddress, grpc.WithContextDialer(dialer), grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return "", err
+	}
+	defer grpcConnection.Close()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	response, err := szpb.NewSzProductClient(grpcConnection).GetVersion(ctx, &szpb.GetVersionRequest{})
+	return response.GetResult(), err
+}
+
+func newSerialNumber(test *testing.T) *big.Int {
+	result, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(test, err)
+	return result
+}
+
+func rotateFile(test *testing.T, fileName string, contents []byte) {
+	require.NoError(test, os.WriteFile(fileName, contents, 0600))
+	future := time.Now().Add(time.Minute)
+	require.NoError(test, os.Chtimes(fileName, future, future))
+}
+
+// Start a TLS server.  If clientAuthority is not nil, the server requires client certificates signed by it.
+func startTestServer(test *testing.T, serverAuthority *testCertificateAuthority, clientAuthority *testCertificateAuthority) func(context.Context, string) (net.Conn, error) {
+	serverCertPEM, serverKeyPEM := serverAuthority.issue(test, serverName, true)
+	serverCertificate, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(test, err)
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{serverCertificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientAuthority != nil {
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(clientAuthority.certificate)
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		tlsConfig.ClientCAs = clientCAs
+	}
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	szpb.RegisterSzProductServer(grpcServer, &testSzProductServer{})
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+}
+
+func writeFile(test *testing.T, directory string, fileName string, contents []byte) string {
+	result := filepath.Join(directory, fileName)
+	require.NoError(test, os.WriteFile(result, contents, 0600))
+	return result
+}
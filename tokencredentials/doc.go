@@ -0,0 +1,11 @@
+/*
+The tokencredentials package attaches bearer tokens to every call made to a Senzing gRPC server.
+
+A TokenSource supplies the token.
+Implementations are provided for a static token, a token file that is re-read when it changes,
+and a callback that is invoked again when the previous token expires.
+Credentials wraps a TokenSource as a credentials.PerRPCCredentials and is usually passed to
+szabstractfactory.WithPerRPCCredentials().
+Failures to obtain a token are returned as *TokenError values rather than gRPC status errors.
+*/
+package tokencredentials
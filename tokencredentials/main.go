@@ -0,0 +1,61 @@
+package tokencredentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types - interface
+// ----------------------------------------------------------------------------
+
+// TokenSource supplies the bearer token sent with each call.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// ----------------------------------------------------------------------------
+// Types - struct
+// ----------------------------------------------------------------------------
+
+// RefreshFunc obtains a new token and the time it expires.
+// A zero expiry means the token does not expire.
+type RefreshFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// TokenError reports a failure to obtain or use a bearer token.
+// Use errors.As() to retrieve it from an error returned by an Sz method.
+type TokenError struct {
+	Err    error  // The underlying error, for example ErrTokenEmpty or an *fs.PathError.
+	Source string // A description of the TokenSource, such as "file /run/secrets/token".
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The default time before expiry at which a RefreshingToken obtains a new token.
+const DefaultRefreshLeeway = 30 * time.Second
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	ErrTokenEmpty    = errors.New("bearer token is empty")
+	ErrTokenExpired  = errors.New("bearer token has expired")
+	ErrTokenRejected = errors.New("bearer token was rejected by the server")
+)
+
+// ----------------------------------------------------------------------------
+// TokenError methods
+// ----------------------------------------------------------------------------
+
+func (tokenError *TokenError) Error() string {
+	return fmt.Sprintf("bearer token from %s: %v", tokenError.Source, tokenError.Err)
+}
+
+func (tokenError *TokenError) Unwrap() error {
+	return tokenError.Err
+}
@@ -0,0 +1,157 @@
+package tokencredentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Credentials is a credentials.PerRPCCredentials that sends a bearer token from a TokenSource
+// in the "authorization" metadata of every call.
+type Credentials struct {
+	requireTransportSecurity bool
+	source                   TokenSource
+}
+
+// A client stream whose received errors are checked for a rejected token.
+type checkedClientStream struct {
+	grpc.ClientStream
+	tokenCredentials *Credentials
+}
+
+type invalidator interface {
+	Invalidate()
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+The New function creates per-RPC credentials that require a TLS connection,
+so that the token is never sent in clear text.
+
+Input
+  - source: The TokenSource supplying the bearer token.
+*/
+func New(source TokenSource) *Credentials {
+	return &Credentials{
+		requireTransportSecurity: true,
+		source:                   source,
+	}
+}
+
+/*
+The NewInsecure function creates per-RPC credentials that may also be used over an insecure connection.
+It is intended for development and testing.
+
+Input
+  - source: The TokenSource supplying the bearer token.
+*/
+func NewInsecure(source TokenSource) *Credentials {
+	return &Credentials{
+		source: source,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// credentials.PerRPCCredentials interface methods
+// ----------------------------------------------------------------------------
+
+// GetRequestMetadata returns the "authorization" header for a call.
+func (tokenCredentials *Credentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	_ = uri
+	token, err := tokenCredentials.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]string{
+		"authorization": "Bearer " + token,
+	}
+	return result, nil
+}
+
+// RequireTransportSecurity reports whether the credentials require a TLS connection.
+func (tokenCredentials *Credentials) RequireTransportSecurity() bool {
+	return tokenCredentials.requireTransportSecurity
+}
+
+// ----------------------------------------------------------------------------
+// Interceptors
+// ----------------------------------------------------------------------------
+
+/*
+The StreamClientInterceptor method returns an interceptor that obtains the token before a stream is opened.
+See UnaryClientInterceptor.
+A rejected token is detected both when the stream is opened and when a message is received,
+as a server reports a rejected token on the first receive.
+*/
+func (tokenCredentials *Credentials) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, streamer grpc.Streamer, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		if _, err := tokenCredentials.source.Token(ctx); err != nil {
+			return nil, err
+		}
+		clientStream, err := streamer(ctx, streamDesc, grpcConnection, method, options...)
+		if err != nil {
+			return nil, tokenCredentials.checkRejected(err)
+		}
+		return &checkedClientStream{ClientStream: clientStream, tokenCredentials: tokenCredentials}, nil
+	}
+}
+
+/*
+The UnaryClientInterceptor method returns an interceptor that obtains the token before each call.
+Without it, gRPC reports a token failure as an opaque "Unauthenticated" status.
+With it, the caller receives the *TokenError from the TokenSource.
+If the server rejects the token with codes.Unauthenticated, the TokenSource is invalidated
+so the next call obtains a fresh token, and a *TokenError wrapping ErrTokenRejected is returned.
+*/
+func (tokenCredentials *Credentials) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, reply interface{}, grpcConnection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+		if _, err := tokenCredentials.source.Token(ctx); err != nil {
+			return err
+		}
+		err := invoker(ctx, method, request, reply, grpcConnection, options...)
+		return tokenCredentials.checkRejected(err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// grpc.ClientStream interface methods
+// ----------------------------------------------------------------------------
+
+func (stream *checkedClientStream) RecvMsg(message interface{}) error {
+	return stream.tokenCredentials.checkRejected(stream.ClientStream.RecvMsg(message))
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (tokenCredentials *Credentials) checkRejected(err error) error {
+	if status.Code(err) != codes.Unauthenticated {
+		return err
+	}
+	if source, ok := tokenCredentials.source.(invalidator); ok {
+		source.Invalidate()
+	}
+	return &TokenError{
+		Source: describe(tokenCredentials.source),
+		Err:    errors.Join(ErrTokenRejected, err),
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func describe(source TokenSource) string {
+	if stringer, ok := source.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%T", source)
+}
@@ -0,0 +1,39 @@
+package tokencredentials
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleNew() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/tokencredentials/tokencredentials_examples_test.go
+	ctx := context.TODO()
+	tokenCredentials := New(NewStaticToken("my-token"))
+	requestMetadata, err := tokenCredentials.GetRequestMetadata(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(requestMetadata["authorization"])
+	// Output: Bearer my-token
+}
+
+func ExampleNewRefreshingToken() {
+	// For more information, visit https://github.com/senzing-garage/sz-sdk-go-grpc/blob/main/tokencredentials/tokencredentials_examples_test.go
+	ctx := context.TODO()
+	refresh := func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		return "fetched-token", time.Now().Add(time.Hour), nil
+	}
+	tokenSource := NewRefreshingToken(refresh, time.Minute)
+	token, err := tokenSource.Token(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(token)
+	// Output: fetched-token
+}
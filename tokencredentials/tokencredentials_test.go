@@ -0,0 +1,266 @@
+package tokencredentials
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	szpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	bufconnAddress    = "passthrough:///bufnet"
+	bufconnBufferSize = 1024 * 1024
+	testVersion       = `{"PRODUCT_NAME":"Senzing API","VERSION":"test"}`
+)
+
+type testSzProductServer struct {
+	szpb.UnimplementedSzProductServer
+	mutex       sync.Mutex
+	validTokens map[string]bool
+	seenTokens  []string
+}
+
+// A client stream whose receives fail with err.
+type testClientStream struct {
+	grpc.ClientStream
+	err error
+}
+
+type testTokenSource struct{}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestTokenCredentials_GetRequestMetadata(test *testing.T) {
+	ctx := context.TODO()
+	tokenCredentials := New(NewStaticToken("abc"))
+	actual, err := tokenCredentials.GetRequestMetadata(ctx, "uri")
+	require.NoError(test, err)
+	assert.Equal(test, map[string]string{"authorization": "Bearer abc"}, actual)
+}
+
+func TestTokenCredentials_GetRequestMetadata_error(test *testing.T) {
+	ctx := context.TODO()
+	tokenCredentials := New(NewStaticToken(""))
+	_, err := tokenCredentials.GetRequestMetadata(ctx)
+	require.ErrorIs(test, err, ErrTokenEmpty)
+}
+
+func TestTokenCredentials_RequireTransportSecurity(test *testing.T) {
+	assert.True(test, New(NewStaticToken("abc")).RequireTransportSecurity())
+	assert.False(test, NewInsecure(NewStaticToken("abc")).RequireTransportSecurity())
+}
+
+func TestTokenCredentials_New_insecureConnection(test *testing.T) {
+	ctx := context.TODO()
+	dialer, _ := startTestServer(test, "abc")
+	_, err := getVersion(ctx, dialer, New(NewStaticToken("abc")))
+	require.Error(test, err)
+}
+
+func TestTokenCredentials_StaticToken(test *testing.T) {
+	ctx := context.TODO()
+	dialer, server := startTestServer(test, "abc")
+	actual, err := getVersion(ctx, dialer, NewInsecure(NewStaticToken("abc")))
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+	assert.Equal(test, []string{"Bearer abc"}, server.getSeenTokens())
+}
+
+func TestTokenCredentials_StaticToken_empty(test *testing.T) {
+	ctx := context.TODO()
+	dialer, server := startTestServer(test, "abc")
+	_, err := getVersion(ctx, dialer, NewInsecure(NewStaticToken("")))
+	var tokenError *TokenError
+	require.ErrorAs(test, err, &tokenError)
+	require.ErrorIs(test, err, ErrTokenEmpty)
+	assert.Equal(test, "static token", tokenError.Source)
+	assert.Empty(test, server.getSeenTokens())
+}
+
+func TestTokenCredentials_StaticToken_rejected(test *testing.T) {
+	ctx := context.TODO()
+	dialer, _ := startTestServer(test, "abc")
+	_, err := getVersion(ctx, dialer, NewInsecure(NewStaticToken("xyz")))
+	var tokenError *TokenError
+	require.ErrorAs(test, err, &tokenError)
+	require.ErrorIs(test, err, ErrTokenRejected)
+	assert.Equal(test, codes.Unauthenticated, status.Code(err))
+}
+
+func TestTokenCredentials_FileToken_rotated(test *testing.T) {
+	ctx := context.TODO()
+	dialer, server := startTestServer(test, "first", "second")
+	fileName := writeTokenFile(test, "first\n")
+	tokenCredentials := NewInsecure(NewFileToken(fileName))
+	_, err := getVersion(ctx, dialer, tokenCredentials)
+	require.NoError(test, err)
+	rotateTokenFile(test, fileName, "second\n")
+	_, err = getVersion(ctx, dialer, tokenCredentials)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"Bearer first", "Bearer second"}, server.getSeenTokens())
+}
+
+func TestTokenCredentials_FileToken_missing(test *testing.T) {
+	ctx := context.TODO()
+	dialer, _ := startTestServer(test, "abc")
+	_, err := getVersion(ctx, dialer, NewInsecure(NewFileToken("/no/such/token")))
+	var tokenError *TokenError
+	require.ErrorAs(test, err, &tokenError)
+	assert.Equal(test, "file /no/such/token", tokenError.Source)
+}
+
+func TestTokenCredentials_RefreshingToken_rejectedThenRefreshed(test *testing.T) {
+	ctx := context.TODO()
+	dialer, server := startTestServer(test, "token-2")
+	refreshCount := 0
+	refresh := func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		refreshCount++
+		return fmt.Sprintf("token-%d", refreshCount), time.Time{}, nil
+	}
+	tokenCredentials := NewInsecure(NewRefreshingToken(refresh, 0))
+	_, err := getVersion(ctx, dialer, tokenCredentials)
+	require.ErrorIs(test, err, ErrTokenRejected)
+	actual, err := getVersion(ctx, dialer, tokenCredentials)
+	require.NoError(test, err)
+	assert.Equal(test, testVersion, actual)
+	assert.Equal(test, 2, refreshCount)
+	assert.Equal(test, []string{"Bearer token-1", "Bearer token-2"}, server.getSeenTokens())
+}
+
+func TestTokenCredentials_StreamClientInterceptor(test *testing.T) {
+	ctx := context.TODO()
+	interceptor := NewInsecure(NewStaticToken("")).StreamClientInterceptor()
+	streamer := func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, nil
+	}
+	_, err := interceptor(ctx, &grpc.StreamDesc{}, nil, "/test", streamer)
+	require.ErrorIs(test, err, ErrTokenEmpty)
+}
+
+func TestTokenCredentials_StreamClientInterceptor_rejected(test *testing.T) {
+	ctx := context.TODO()
+	interceptor := NewInsecure(NewStaticToken("abc")).StreamClientInterceptor()
+	streamer := func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	_, err := interceptor(ctx, &grpc.StreamDesc{}, nil, "/test", streamer)
+	require.ErrorIs(test, err, ErrTokenRejected)
+}
+
+func TestTokenCredentials_StreamClientInterceptor_rejectedOnReceive(test *testing.T) {
+	ctx := context.TODO()
+	refreshCount := 0
+	refresh := func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		refreshCount++
+		return fmt.Sprintf("token-%d", refreshCount), time.Time{}, nil
+	}
+	tokenCredentials := NewInsecure(NewRefreshingToken(refresh, 0))
+	interceptor := tokenCredentials.StreamClientInterceptor()
+	streamer := func(ctx context.Context, streamDesc *grpc.StreamDesc, grpcConnection *grpc.ClientConn, method string, options ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{err: status.Error(codes.Unauthenticated, "invalid bearer token")}, nil
+	}
+	clientStream, err := interceptor(ctx, &grpc.StreamDesc{}, nil, "/test", streamer)
+	require.NoError(test, err)
+	err = clientStream.RecvMsg(nil)
+	require.ErrorIs(test, err, ErrTokenRejected)
+	var tokenError *TokenError
+	require.ErrorAs(test, err, &tokenError)
+	token, err := tokenCredentials.source.Token(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "token-2", token)
+}
+
+func TestTokenCredentials_describe(test *testing.T) {
+	assert.Equal(test, "*tokencredentials.testTokenSource", describe(&testTokenSource{}))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func (stream *testClientStream) RecvMsg(message interface{}) error {
+	_ = message
+	return stream.err
+}
+
+func (source *testTokenSource) Token(ctx context.Context) (string, error) {
+	_ = ctx
+	return "abc", nil
+}
+
+func (server *testSzProductServer) GetVersion(ctx context.Context, request *szpb.GetVersionRequest) (*szpb.GetVersionResponse, error) {
+	_ = request
+	incomingMetadata, _ := metadata.FromIncomingContext(ctx)
+	authorization := incomingMetadata.Get("authorization")
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.seenTokens = append(server.seenTokens, authorization...)
+	if len(authorization) != 1 || !server.validTokens[authorization[0]] {
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return &szpb.GetVersionResponse{Result: testVersion}, nil
+}
+
+func (server *testSzProductServer) getSeenTokens() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string{}, server.seenTokens...)
+}
+
+func getVersion(ctx context.Context, dialer func(context.Context, string) (net.Conn, error), tokenCredentials *Credentials) (string, error) {
+	grpcConnection, err := grpc.NewClient(bufconnAddress,
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokenCredentials),
+		grpc.WithUnaryInterceptor(tokenCredentials.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer grpcConnection.Close()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	response, err := szpb.NewSzProductClient(grpcConnection).GetVersion(ctx, &szpb.GetVersionRequest{})
+	return response.GetResult(), err
+}
+
+func rotateTokenFile(test *testing.T, fileName string, contents string) {
+	writeTokenFileContents(test, fileName, contents)
+	future := time.Now().Add(time.Minute)
+	require.NoError(test, os.Chtimes(fileName, future, future))
+}
+
+// Start a server that accepts only the given bearer tokens.
+func startTestServer(test *testing.T, validTokens ...string) (func(context.Context, string) (net.Conn, error), *testSzProductServer) {
+	server := &testSzProductServer{validTokens: map[string]bool{}}
+	for _, validToken := range validTokens {
+		server.validTokens["Bearer "+validToken] = true
+	}
+	listener := bufconn.Listen(bufconnBufferSize)
+	grpcServer := grpc.NewServer()
+	szpb.RegisterSzProductServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	test.Cleanup(grpcServer.Stop)
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		_ = address
+		return listener.DialContext(ctx)
+	}
+	return dialer, server
+}
@@ -0,0 +1,183 @@
+package tokencredentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaticToken is a TokenSource that always returns the same token.
+type StaticToken struct {
+	token string
+}
+
+// FileToken is a TokenSource that reads the token from a file, such as a mounted Kubernetes secret.
+// The file is read again whenever its modification time or size changes.
+type FileToken struct {
+	fileName string
+	modTime  time.Time
+	mutex    sync.Mutex
+	size     int64
+	token    string
+}
+
+// RefreshingToken is a TokenSource that calls a RefreshFunc to obtain a token
+// and calls it again shortly before the token expires.
+type RefreshingToken struct {
+	expiry  time.Time
+	leeway  time.Duration
+	mutex   sync.Mutex
+	now     func() time.Time
+	refresh RefreshFunc
+	token   string
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+The NewStaticToken function creates a TokenSource for a token that never changes.
+
+Input
+  - token: The bearer token.
+*/
+func NewStaticToken(token string) *StaticToken {
+	return &StaticToken{
+		token: token,
+	}
+}
+
+/*
+The NewFileToken function creates a TokenSource that reads the token from a file.
+Leading and trailing white space in the file is ignored.
+
+Input
+  - fileName: The path of the file containing the token.
+*/
+func NewFileToken(fileName string) *FileToken {
+	return &FileToken{
+		fileName: fileName,
+	}
+}
+
+/*
+The NewRefreshingToken function creates a TokenSource that obtains tokens from a callback.
+The callback is invoked on first use and again once the current token is within leeway of its expiry.
+If a refresh fails while the current token has not yet expired, the current token continues to be used.
+
+Input
+  - refresh: The callback that obtains a token and its expiry.
+  - leeway: How long before expiry to refresh. If zero, DefaultRefreshLeeway is used.
+*/
+func NewRefreshingToken(refresh RefreshFunc, leeway time.Duration) *RefreshingToken {
+	if leeway == 0 {
+		leeway = DefaultRefreshLeeway
+	}
+	return &RefreshingToken{
+		leeway:  leeway,
+		now:     time.Now,
+		refresh: refresh,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// StaticToken methods
+// ----------------------------------------------------------------------------
+
+// Token returns the static token.
+func (source *StaticToken) Token(ctx context.Context) (string, error) {
+	_ = ctx
+	if len(source.token) == 0 {
+		return "", &TokenError{Source: source.String(), Err: ErrTokenEmpty}
+	}
+	return source.token, nil
+}
+
+func (source *StaticToken) String() string {
+	return "static token"
+}
+
+// ----------------------------------------------------------------------------
+// FileToken methods
+// ----------------------------------------------------------------------------
+
+// Invalidate forces the file to be read again on the next call to Token.
+func (source *FileToken) Invalidate() {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	source.token = ""
+}
+
+// Token returns the token in the file, reading the file again if it has changed.
+func (source *FileToken) Token(ctx context.Context) (string, error) {
+	_ = ctx
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	fileInfo, err := os.Stat(source.fileName)
+	if err != nil {
+		return "", &TokenError{Source: source.String(), Err: err}
+	}
+	if len(source.token) > 0 && fileInfo.ModTime().Equal(source.modTime) && fileInfo.Size() == source.size {
+		return source.token, nil
+	}
+	contents, err := os.ReadFile(source.fileName)
+	if err != nil {
+		return "", &TokenError{Source: source.String(), Err: err}
+	}
+	token := strings.TrimSpace(string(contents))
+	if len(token) == 0 {
+		return "", &TokenError{Source: source.String(), Err: ErrTokenEmpty}
+	}
+	source.token = token
+	source.modTime = fileInfo.ModTime()
+	source.size = fileInfo.Size()
+	return source.token, nil
+}
+
+func (source *FileToken) String() string {
+	return fmt.Sprintf("file %s", source.fileName)
+}
+
+// ----------------------------------------------------------------------------
+// RefreshingToken methods
+// ----------------------------------------------------------------------------
+
+// Invalidate forces a refresh on the next call to Token.
+func (source *RefreshingToken) Invalidate() {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	source.token = ""
+}
+
+// Token returns the current token, refreshing it first if it is missing or about to expire.
+func (source *RefreshingToken) Token(ctx context.Context) (string, error) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	now := source.now()
+	if len(source.token) > 0 && (source.expiry.IsZero() || now.Add(source.leeway).Before(source.expiry)) {
+		return source.token, nil
+	}
+	token, expiry, err := source.refresh(ctx)
+	switch {
+	case err != nil:
+		if len(source.token) > 0 && (source.expiry.IsZero() || now.Before(source.expiry)) {
+			return source.token, nil
+		}
+		return "", &TokenError{Source: source.String(), Err: err}
+	case len(token) == 0:
+		return "", &TokenError{Source: source.String(), Err: ErrTokenEmpty}
+	case !expiry.IsZero() && !now.Before(expiry):
+		return "", &TokenError{Source: source.String(), Err: ErrTokenExpired}
+	}
+	source.token = token
+	source.expiry = expiry
+	return source.token, nil
+}
+
+func (source *RefreshingToken) String() string {
+	return "refreshing token"
+}
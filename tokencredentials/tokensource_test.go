@@ -0,0 +1,164 @@
+package tokencredentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestTokenError_Error(test *testing.T) {
+	tokenError := &TokenError{Source: "static token", Err: ErrTokenEmpty}
+	assert.Equal(test, "bearer token from static token: bearer token is empty", tokenError.Error())
+	require.ErrorIs(test, tokenError, ErrTokenEmpty)
+}
+
+func TestStaticToken_Token(test *testing.T) {
+	ctx := context.TODO()
+	actual, err := NewStaticToken("abc").Token(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "abc", actual)
+}
+
+func TestFileToken_Token(test *testing.T) {
+	ctx := context.TODO()
+	fileName := writeTokenFile(test, "  abc\n")
+	actual, err := NewFileToken(fileName).Token(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "abc", actual)
+}
+
+func TestFileToken_Token_empty(test *testing.T) {
+	ctx := context.TODO()
+	fileName := writeTokenFile(test, "\n")
+	_, err := NewFileToken(fileName).Token(ctx)
+	require.ErrorIs(test, err, ErrTokenEmpty)
+}
+
+func TestFileToken_Invalidate(test *testing.T) {
+	ctx := context.TODO()
+	fileName := writeTokenFile(test, "abc")
+	source := NewFileToken(fileName)
+	_, err := source.Token(ctx)
+	require.NoError(test, err)
+	source.Invalidate()
+	writeTokenFileContents(test, fileName, "xyz")
+	actual, err := source.Token(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "xyz", actual)
+}
+
+func TestRefreshingToken_Token_cached(test *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	refreshCount := 0
+	source := NewRefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		refreshCount++
+		return "abc", now.Add(time.Hour), nil
+	}, time.Minute)
+	source.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		actual, err := source.Token(ctx)
+		require.NoError(test, err)
+		assert.Equal(test, "abc", actual)
+	}
+	assert.Equal(test, 1, refreshCount)
+}
+
+func TestRefreshingToken_Token_nearExpiry(test *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	tokens := []string{"first", "second"}
+	refreshCount := 0
+	source := NewRefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		refreshCount++
+		return tokens[refreshCount-1], now.Add(time.Hour), nil
+	}, time.Minute)
+	source.now = func() time.Time { return now }
+	actual, err := source.Token(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "first", actual)
+	source.now = func() time.Time { return now.Add(time.Hour - 30*time.Second) }
+	actual, err = source.Token(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "second", actual)
+}
+
+func TestRefreshingToken_Token_refreshFailsBeforeExpiry(test *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+	refreshError := errors.New("identity provider unavailable")
+	refreshCount := 0
+	source := NewRefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		refreshCount++
+		if refreshCount > 1 {
+			return "", time.Time{}, refreshError
+		}
+		return "abc", now.Add(time.Hour), nil
+	}, time.Minute)
+	source.now = func() time.Time { return now }
+	_, err := source.Token(ctx)
+	require.NoError(test, err)
+
+	// Within leeway: the refresh fails but the token is still valid.
+
+	source.now = func() time.Time { return now.Add(time.Hour - 30*time.Second) }
+	actual, err := source.Token(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "abc", actual)
+
+	// Past expiry: the refresh failure is returned.
+
+	source.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = source.Token(ctx)
+	var tokenError *TokenError
+	require.ErrorAs(test, err, &tokenError)
+	require.ErrorIs(test, err, refreshError)
+	assert.Equal(test, "refreshing token", tokenError.Source)
+}
+
+func TestRefreshingToken_Token_alreadyExpired(test *testing.T) {
+	ctx := context.TODO()
+	source := NewRefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		return "abc", time.Now().Add(-time.Minute), nil
+	}, 0)
+	_, err := source.Token(ctx)
+	require.ErrorIs(test, err, ErrTokenExpired)
+}
+
+func TestRefreshingToken_Token_empty(test *testing.T) {
+	ctx := context.TODO()
+	source := NewRefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		_ = ctx
+		return "", time.Time{}, nil
+	}, 0)
+	_, err := source.Token(ctx)
+	require.ErrorIs(test, err, ErrTokenEmpty)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func writeTokenFile(test *testing.T, contents string) string {
+	result := filepath.Join(test.TempDir(), "token")
+	writeTokenFileContents(test, result, contents)
+	return result
+}
+
+func writeTokenFileContents(test *testing.T, fileName string, contents string) {
+	require.NoError(test, os.WriteFile(fileName, []byte(contents), 0600))
+}